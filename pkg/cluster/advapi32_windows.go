@@ -0,0 +1,9 @@
+package cluster
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+var (
+	advapi32_dll = windows.NewLazySystemDLL("advapi32.dll")
+)
@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// Backend opens clusters. NativeBackend calls into clusapi.dll,
+// NewMemoryBackend returns an in-memory fake for unit tests.
+type Backend interface {
+	OpenCluster() (Cluster, error)
+	OpenRemoteCluster(clusterName string) (Cluster, error)
+}
+
+// Cluster is the interface form of ClusterHandle
+type Cluster interface {
+	OpenResource(resourceName string) (Resource, error)
+	Close() error
+}
+
+// Resource is the interface form of ResourceHandle
+type Resource interface {
+	// GetKey gets the root cluster registry key of the resource
+	GetKey(samDesired int) (Key, error)
+	Close() error
+}
+
+// Key is the interface form of KeyHandle
+type Key interface {
+	CreateKey(keyName string, samDesired int) (key Key, created bool, err error)
+	OpenKey(keyName string, samDesired int) (Key, error)
+	EnumKeys() ([]string, error)
+	DeleteKey(keyName string) error
+	DeleteTree(keyName string) error
+	QueryInfo() (KeyInfo, error)
+	SetValue(value string, dwType uint32, data []byte) error
+	QueryValue(valueName string) (dwType uint32, data []byte, err error)
+	DeleteValue(valueName string) error
+	LoadValues() (map[string]RegistryValue, error)
+	CreateBatch() (RegBatch, error)
+	Close() error
+}
+
+// KeyInfo is returned by QueryInfo, name lengths are in characters
+// and value lengths in bytes
+type KeyInfo struct {
+	SubKeys               uint32
+	MaxSubKeyLen          uint32
+	Values                uint32
+	MaxValueNameLen       uint32
+	MaxValueLen           uint32
+	SecurityDescriptorLen uint32
+	LastWriteTime         time.Time
+}
+
+// RegBatch is the interface form of RegBatchHandle
+type RegBatch interface {
+	BatchAddCommand(command ClusterRegCommand, value string, dwType uint32, data []byte) error
+	CloseBatch(commit bool) (error, int)
+}
+
+// deleteTree deletes keyName below key and everything under it
+// using only the Key primitives so every backend shares it
+func deleteTree(key Key, keyName string) error {
+	subkey, err := key.OpenKey(keyName, KEY_ALL_ACCESS)
+	if err != nil {
+		return err
+	}
+	defer subkey.Close()
+
+	names, err := subkey.EnumKeys()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err = deleteTree(subkey, name); err != nil {
+			return err
+		}
+	}
+
+	if keyName != "" {
+		return key.DeleteKey(keyName)
+	}
+
+	values, err := subkey.LoadValues()
+	if err != nil {
+		return err
+	}
+	for name := range values {
+		if err = subkey.DeleteValue(name); err != nil && !errors.Is(err, errors.ERROR_FILE_NOT_FOUND) {
+			return err
+		}
+	}
+	return nil
+}
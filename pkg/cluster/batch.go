@@ -0,0 +1,292 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+	"github.com/KnicKnic/go-windows/pkg/util/guid"
+)
+
+// ExpandString is stored as REG_EXPAND_SZ by Batch.Set
+type ExpandString string
+
+// BatchOp is a single command of a Batch
+type BatchOp struct {
+	Command ClusterRegCommand
+	Name    string
+	DwType  uint32
+	Data    []byte
+}
+
+// BatchError is returned by Batch.Commit when the batch fails
+type BatchError struct {
+	// Index of the failed operation, -1 if the batch failed before running any
+	Index int
+	// Op is the failed operation, it is the zero value when Index is -1
+	Op  BatchOp
+	Err error
+}
+
+func (e *BatchError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("cluster registry batch failed: %v", e.Err)
+	}
+	return fmt.Sprintf("cluster registry batch operation %d %s %q failed: %v", e.Index, e.Op.Command, e.Op.Name, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// Batch builds a cluster registry batch. Errors from adding operations are
+// kept and returned by Commit so calls can be chained
+//
+//	err := batch.IfEqual("Version", uint32(1)).
+//		Set("Version", uint32(2)).
+//		CreateKey("Parameters").
+//		Set("Path", ExpandString(`%SystemRoot%\temp`)).
+//		Commit()
+type Batch struct {
+	batch  RegBatch
+	ops    []BatchOp
+	err    error
+	closed bool
+}
+
+// NewBatch creates a batch on key, CreateKey and DeleteKey paths
+// are relative to key
+func NewBatch(key Key) (*Batch, error) {
+	batch, err := key.CreateBatch()
+	if err != nil {
+		return nil, err
+	}
+	return WrapBatch(batch), nil
+}
+
+// WrapBatch builds on an already created RegBatch
+func WrapBatch(batch RegBatch) *Batch {
+	return &Batch{batch: batch}
+}
+
+// Ops returns the operations added so far
+func (b *Batch) Ops() []BatchOp {
+	return append([]BatchOp(nil), b.ops...)
+}
+
+func (b *Batch) add(command ClusterRegCommand, name string, dwType uint32, data []byte) *Batch {
+	if b.err != nil {
+		return b
+	}
+	if b.closed {
+		b.err = errors.ERROR_INVALID_HANDLE
+		return b
+	}
+	if err := b.batch.BatchAddCommand(command, name, dwType, data); err != nil {
+		b.err = &BatchError{Index: len(b.ops), Op: BatchOp{command, name, dwType, data}, Err: err}
+		return b
+	}
+	b.ops = append(b.ops, BatchOp{command, name, dwType, data})
+	return b
+}
+
+func (b *Batch) addValue(command ClusterRegCommand, name string, value interface{}) *Batch {
+	if b.err != nil {
+		return b
+	}
+	dwType, data, err := encodeBatchValue(value)
+	if err != nil {
+		b.err = &BatchError{Index: len(b.ops), Op: BatchOp{Command: command, Name: name}, Err: err}
+		return b
+	}
+	return b.add(command, name, dwType, data)
+}
+
+// Set sets the value name of the current key, see encodeBatchValue
+// for how value is stored
+func (b *Batch) Set(name string, value interface{}) *Batch {
+	return b.addValue(CLUSREG_SET_VALUE, name, value)
+}
+
+// Delete deletes the value name of the current key
+func (b *Batch) Delete(name string) *Batch {
+	return b.add(CLUSREG_DELETE_VALUE, name, 0, nil)
+}
+
+// CreateKey creates the subkey path and makes it the current key
+// for the following operations
+func (b *Batch) CreateKey(path string) *Batch {
+	return b.add(CLUSREG_CREATE_KEY, path, 0, nil)
+}
+
+// DeleteKey deletes the subkey path
+func (b *Batch) DeleteKey(path string) *Batch {
+	return b.add(CLUSREG_DELETE_KEY, path, 0, nil)
+}
+
+// IfEqual fails the batch unless the value name of the current key
+// has the type and data of value
+func (b *Batch) IfEqual(name string, value interface{}) *Batch {
+	return b.addValue(CLUSREG_CONDITION_IS_EQUAL, name, value)
+}
+
+// IfNotExists fails the batch if the value name exists in the current key
+func (b *Batch) IfNotExists(name string) *Batch {
+	return b.add(CLUSREG_CONDITION_NOT_EXISTS, name, 0, nil)
+}
+
+// Commit runs the batch, the returned error is a *BatchError
+// if adding an operation failed nothing is run
+func (b *Batch) Commit() error {
+	if b.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	b.closed = true
+	if b.err != nil {
+		b.batch.CloseBatch(false)
+		return b.err
+	}
+
+	err, failed := b.batch.CloseBatch(true)
+	if err == nil {
+		return nil
+	}
+	batchErr := &BatchError{Index: -1, Err: err}
+	if failed >= 0 && failed < len(b.ops) {
+		batchErr.Index = failed
+		batchErr.Op = b.ops[failed]
+	}
+	return batchErr
+}
+
+// Rollback discards the batch without running it
+func (b *Batch) Rollback() error {
+	if b.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	b.closed = true
+	err, _ := b.batch.CloseBatch(false)
+	return err
+}
+
+// encodeBatchValue returns the registry type and data for value
+// string is REG_SZ, ExpandString is REG_EXPAND_SZ, []string is REG_MULTI_SZ,
+// uint32, int32, bool and int or uint that fit 32 bits are REG_DWORD,
+// uint64 and int64 are REG_QWORD,
+// []byte and guid.GUID are REG_BINARY and RegistryValue is stored as is
+func encodeBatchValue(value interface{}) (dwType uint32, data []byte, err error) {
+	switch v := value.(type) {
+	case string:
+		dwType = REG_SZ
+		data, err = util.StringToUTF16Bytes(v)
+	case ExpandString:
+		dwType = REG_EXPAND_SZ
+		data, err = util.StringToUTF16Bytes(string(v))
+	case []string:
+		dwType = REG_MULTI_SZ
+		data, err = util.StringsToUTF16Bytes(v)
+	case uint32:
+		dwType, data = REG_DWORD, util.Uint32ToByte(v)
+	case int32:
+		dwType, data = REG_DWORD, util.Uint32ToByte(uint32(v))
+	case int:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			err = fmt.Errorf("batch value %d overflows a dword, use int64: %w", v, errors.ERROR_INVALID_PARAMETER)
+			return
+		}
+		dwType, data = REG_DWORD, util.Uint32ToByte(uint32(int32(v)))
+	case uint:
+		if uint64(v) > math.MaxUint32 {
+			err = fmt.Errorf("batch value %d overflows a dword, use uint64: %w", v, errors.ERROR_INVALID_PARAMETER)
+			return
+		}
+		dwType, data = REG_DWORD, util.Uint32ToByte(uint32(v))
+	case bool:
+		dwType, data = REG_DWORD, util.Uint32ToByte(0)
+		if v {
+			data = util.Uint32ToByte(1)
+		}
+	case uint64:
+		dwType, data = REG_QWORD, util.Uint64ToByte(v)
+	case int64:
+		dwType, data = REG_QWORD, util.Uint64ToByte(uint64(v))
+	case []byte:
+		dwType, data = REG_BINARY, append([]byte(nil), v...)
+	case guid.GUID:
+		dwType = REG_BINARY
+		data, err = v.ToByte()
+	case RegistryValue:
+		dwType, data = v.DwType, append([]byte(nil), v.Data...)
+	default:
+		err = fmt.Errorf("unsupported batch value type %T: %w", value, errors.ERROR_INVALID_PARAMETER)
+	}
+	return
+}
+
+// BatchRecorder is a RegBatch that records the commands added to it
+// so code building batches can be tested without a cluster
+type BatchRecorder struct {
+	Commands  []BatchOp
+	Closed    bool
+	Committed bool
+
+	// CloseError and FailedCommand are returned by CloseBatch when committing
+	CloseError    error
+	FailedCommand int
+}
+
+// BatchAddCommand records the command
+func (recorder *BatchRecorder) BatchAddCommand(command ClusterRegCommand, value string, dwType uint32, data []byte) error {
+	if recorder.Closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	recorder.Commands = append(recorder.Commands, BatchOp{command, value, dwType, append([]byte(nil), data...)})
+	return nil
+}
+
+// CloseBatch marks the recorder closed
+func (recorder *BatchRecorder) CloseBatch(commit bool) (error, int) {
+	if recorder.Closed {
+		return errors.ERROR_INVALID_HANDLE, -1
+	}
+	recorder.Closed = true
+	if !commit {
+		return nil, 0
+	}
+	recorder.Committed = true
+	if recorder.CloseError != nil {
+		return recorder.CloseError, recorder.FailedCommand
+	}
+	return nil, 0
+}
+
+var clusterRegCommandNames = map[ClusterRegCommand]string{
+	CLUSREG_COMMAND_NONE:              "CLUSREG_COMMAND_NONE",
+	CLUSREG_SET_VALUE:                 "CLUSREG_SET_VALUE",
+	CLUSREG_CREATE_KEY:                "CLUSREG_CREATE_KEY",
+	CLUSREG_DELETE_KEY:                "CLUSREG_DELETE_KEY",
+	CLUSREG_DELETE_VALUE:              "CLUSREG_DELETE_VALUE",
+	CLUSREG_SET_KEY_SECURITY:          "CLUSREG_SET_KEY_SECURITY",
+	CLUSREG_VALUE_DELETED:             "CLUSREG_VALUE_DELETED",
+	CLUSREG_READ_KEY:                  "CLUSREG_READ_KEY",
+	CLUSREG_READ_VALUE:                "CLUSREG_READ_VALUE",
+	CLUSREG_READ_ERROR:                "CLUSREG_READ_ERROR",
+	CLUSREG_CONTROL_COMMAND:           "CLUSREG_CONTROL_COMMAND",
+	CLUSREG_CONDITION_EXISTS:          "CLUSREG_CONDITION_EXISTS",
+	CLUSREG_CONDITION_NOT_EXISTS:      "CLUSREG_CONDITION_NOT_EXISTS",
+	CLUSREG_CONDITION_IS_EQUAL:        "CLUSREG_CONDITION_IS_EQUAL",
+	CLUSREG_CONDITION_IS_NOT_EQUAL:    "CLUSREG_CONDITION_IS_NOT_EQUAL",
+	CLUSREG_CONDITION_IS_GREATER_THAN: "CLUSREG_CONDITION_IS_GREATER_THAN",
+	CLUSREG_CONDITION_IS_LESS_THAN:    "CLUSREG_CONDITION_IS_LESS_THAN",
+	CLUSREG_CONDITION_KEY_EXISTS:      "CLUSREG_CONDITION_KEY_EXISTS",
+	CLUSREG_CONDITION_KEY_NOT_EXISTS:  "CLUSREG_CONDITION_KEY_NOT_EXISTS",
+}
+
+func (command ClusterRegCommand) String() string {
+	if name, ok := clusterRegCommandNames[command]; ok {
+		return name
+	}
+	return fmt.Sprintf("ClusterRegCommand(%d)", uint32(command))
+}
@@ -0,0 +1,157 @@
+package cluster
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util/guid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchCompose(t *testing.T) {
+	recorder := &BatchRecorder{}
+	id, _ := guid.FromString("206994D6-C7B7-ABDB-D89E-AB9CBF3853C4")
+	idBytes, _ := id.ToByte()
+
+	err := WrapBatch(recorder).
+		IfNotExists("created").
+		IfEqual("version", uint32(1)).
+		Set("version", uint32(2)).
+		Set("name", "ab").
+		Set("path", ExpandString("%a%")).
+		Set("servers", []string{"a"}).
+		Set("size", uint64(1)).
+		Set("enabled", true).
+		Set("id", id).
+		Set("raw", RegistryValue{Data: []byte{1}, DwType: REG_NONE}).
+		CreateKey(`sub\key`).
+		Delete("old").
+		DeleteKey("other").
+		Commit()
+	assert.Nil(t, err)
+	assert.True(t, recorder.Committed)
+
+	assert.Equal(t, []BatchOp{
+		{CLUSREG_CONDITION_NOT_EXISTS, "created", 0, nil},
+		{CLUSREG_CONDITION_IS_EQUAL, "version", REG_DWORD, []byte{1, 0, 0, 0}},
+		{CLUSREG_SET_VALUE, "version", REG_DWORD, []byte{2, 0, 0, 0}},
+		{CLUSREG_SET_VALUE, "name", REG_SZ, []byte{'a', 0, 'b', 0, 0, 0}},
+		{CLUSREG_SET_VALUE, "path", REG_EXPAND_SZ, []byte{'%', 0, 'a', 0, '%', 0, 0, 0}},
+		{CLUSREG_SET_VALUE, "servers", REG_MULTI_SZ, []byte{'a', 0, 0, 0, 0, 0}},
+		{CLUSREG_SET_VALUE, "size", REG_QWORD, []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{CLUSREG_SET_VALUE, "enabled", REG_DWORD, []byte{1, 0, 0, 0}},
+		{CLUSREG_SET_VALUE, "id", REG_BINARY, idBytes},
+		{CLUSREG_SET_VALUE, "raw", REG_NONE, []byte{1}},
+		{CLUSREG_CREATE_KEY, `sub\key`, 0, nil},
+		{CLUSREG_DELETE_VALUE, "old", 0, nil},
+		{CLUSREG_DELETE_KEY, "other", 0, nil},
+	}, recorder.Commands)
+
+	// the recorder is closed
+	err = WrapBatch(recorder).Set("a", "b").Commit()
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err.(*BatchError).Err)
+}
+
+func TestBatchCommitError(t *testing.T) {
+	recorder := &BatchRecorder{CloseError: errors.ERROR_INVALID_DATA, FailedCommand: 1}
+	batch := WrapBatch(recorder).Set("a", uint32(1)).IfEqual("b", "value")
+	assert.Equal(t, 2, len(batch.Ops()))
+
+	err := batch.Commit()
+	batchErr, ok := err.(*BatchError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, batchErr.Index)
+	assert.Equal(t, CLUSREG_CONDITION_IS_EQUAL, batchErr.Op.Command)
+	assert.Equal(t, "b", batchErr.Op.Name)
+	assert.Equal(t, errors.ERROR_INVALID_DATA, batchErr.Unwrap())
+	assert.Contains(t, err.Error(), `CLUSREG_CONDITION_IS_EQUAL "b"`)
+
+	// a failure before any command ran has no operation
+	recorder = &BatchRecorder{CloseError: errors.ERROR_INVALID_HANDLE, FailedCommand: -1}
+	err = WrapBatch(recorder).Set("a", uint32(1)).Commit()
+	assert.Equal(t, &BatchError{Index: -1, Err: errors.ERROR_INVALID_HANDLE}, err)
+}
+
+func TestBatchAddError(t *testing.T) {
+	recorder := &BatchRecorder{}
+	err := WrapBatch(recorder).Set("a", uint32(1)).Set("b", 1.5).Set("c", uint32(1)).Commit()
+
+	batchErr, ok := err.(*BatchError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, batchErr.Index)
+	assert.Equal(t, "b", batchErr.Op.Name)
+
+	// nothing is run once adding failed
+	assert.True(t, recorder.Closed)
+	assert.False(t, recorder.Committed)
+	assert.Equal(t, 1, len(recorder.Commands))
+}
+
+func TestBatchRollback(t *testing.T) {
+	recorder := &BatchRecorder{}
+	batch := WrapBatch(recorder).Set("a", "b")
+	assert.Nil(t, batch.Rollback())
+	assert.True(t, recorder.Closed)
+	assert.False(t, recorder.Committed)
+
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, batch.Commit())
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, batch.Rollback())
+}
+
+func TestBatchMemory(t *testing.T) {
+	_, key := openMemoryKey(t)
+	defer key.Close()
+
+	batch, err := NewBatch(key)
+	assert.Nil(t, err)
+	err = batch.IfNotExists("version").Set("version", uint32(1)).CreateKey("sub").Set("name", "a").Commit()
+	assert.Nil(t, err)
+
+	batch, err = NewBatch(key)
+	assert.Nil(t, err)
+	err = batch.Set("other", uint32(1)).IfEqual("version", uint32(2)).Set("version", uint32(3)).Commit()
+	batchErr, ok := err.(*BatchError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, batchErr.Index)
+	assert.Equal(t, CLUSREG_CONDITION_IS_EQUAL, batchErr.Op.Command)
+	assert.Equal(t, "version", batchErr.Op.Name)
+
+	_, data, err := key.QueryValue("version")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 0, 0, 0}, data)
+	_, _, err = key.QueryValue("other")
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, err)
+}
+
+func TestEncodeBatchValueInt(t *testing.T) {
+	dwType, data, err := encodeBatchValue(30)
+	assert.Nil(t, err)
+	assert.Equal(t, REG_DWORD, dwType)
+	assert.Equal(t, []byte{30, 0, 0, 0}, data)
+
+	_, data, err = encodeBatchValue(-1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff}, data)
+
+	dwType, data, err = encodeBatchValue(uint(7))
+	assert.Nil(t, err)
+	assert.Equal(t, REG_DWORD, dwType)
+	assert.Equal(t, []byte{7, 0, 0, 0}, data)
+
+	if strconv.IntSize == 64 {
+		large := int64(1) << 40
+		_, _, err = encodeBatchValue(int(large))
+		assert.True(t, errors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+		_, _, err = encodeBatchValue(uint(large))
+		assert.True(t, errors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+	}
+
+	_, _, err = encodeBatchValue(1.5)
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+	assert.Contains(t, err.Error(), "unsupported batch value type float64")
+
+	recorder := &BatchRecorder{}
+	assert.Nil(t, WrapBatch(recorder).Set("Timeout", 30).Commit())
+	assert.Equal(t, []BatchOp{{Command: CLUSREG_SET_VALUE, Name: "Timeout", DwType: REG_DWORD, Data: []byte{30, 0, 0, 0}}}, recorder.Commands)
+}
@@ -0,0 +1,56 @@
+package cluster
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// retryMoreData calls call until it stops returning ERROR_MORE_DATA
+// call must (re)allocate its buffers from the sizes the previous call wrote back
+func retryMoreData(call func() syscall.Errno) error {
+	for {
+		lastError := call()
+		if lastError != errors.ERROR_MORE_DATA {
+			return errors.NotZero(lastError)
+		}
+	}
+}
+
+// maxGuessedOutBufferSize bounds the output buffer retryOutBuffer grows
+// by doubling when a call returns ERROR_MORE_DATA without the size it needs
+const maxGuessedOutBufferSize = 64 * 1024 * 1024
+
+// retryOutBuffer calls call with an output buffer grown to the size call
+// wrote to bytesReturned until it stops returning ERROR_MORE_DATA, the
+// returned slice is trimmed to bytesReturned. Some calls return
+// ERROR_MORE_DATA without raising bytesReturned, the buffer is then doubled
+func retryOutBuffer(initialSize uint32, call func(out []byte, bytesReturned *uint32) syscall.Errno) ([]byte, error) {
+	size := initialSize
+	for {
+		out := make([]byte, size)
+		var bytesReturned uint32
+		lastError := call(out, &bytesReturned)
+		if lastError != errors.ERROR_MORE_DATA {
+			if err := errors.NotZero(lastError); err != nil {
+				return nil, err
+			}
+			if bytesReturned > uint32(len(out)) {
+				bytesReturned = uint32(len(out))
+			}
+			return out[:bytesReturned], nil
+		}
+
+		if bytesReturned > size {
+			size = bytesReturned
+			continue
+		}
+		if size >= maxGuessedOutBufferSize {
+			return nil, fmt.Errorf("output buffer of %d bytes is too small and no size was returned: %w", size, errors.ERROR_MORE_DATA)
+		}
+		if size *= 2; size == 0 {
+			size = 256
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cluster
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryOutBufferGrows(t *testing.T) {
+	var sizes []int
+	out, err := retryOutBuffer(4, func(out []byte, bytesReturned *uint32) syscall.Errno {
+		sizes = append(sizes, len(out))
+		*bytesReturned = 10
+		if len(out) < 10 {
+			return errors.ERROR_MORE_DATA.(syscall.Errno)
+		}
+		copy(out, "0123456789")
+		return 0
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4, 10}, sizes)
+	assert.Equal(t, []byte("0123456789"), out)
+}
+
+func TestRetryOutBufferTrims(t *testing.T) {
+	out, err := retryOutBuffer(16, func(out []byte, bytesReturned *uint32) syscall.Errno {
+		*bytesReturned = 3
+		return 0
+	})
+	assert.Nil(t, err)
+	assert.Len(t, out, 3)
+
+	_, err = retryOutBuffer(16, func(out []byte, bytesReturned *uint32) syscall.Errno {
+		return errors.ERROR_INVALID_PARAMETER.(syscall.Errno)
+	})
+	assert.NotNil(t, err)
+}
+
+func TestRetryOutBufferGuessesSize(t *testing.T) {
+	var sizes []int
+	out, err := retryOutBuffer(0, func(out []byte, bytesReturned *uint32) syscall.Errno {
+		sizes = append(sizes, len(out))
+		if len(out) < 1000 {
+			return errors.ERROR_MORE_DATA.(syscall.Errno)
+		}
+		*bytesReturned = 5
+		return 0
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 256, 512, 1024}, sizes)
+	assert.Len(t, out, 5)
+}
+
+func TestRetryOutBufferGivesUp(t *testing.T) {
+	calls := 0
+	_, err := retryOutBuffer(4, func(out []byte, bytesReturned *uint32) syscall.Errno {
+		calls++
+		return errors.ERROR_MORE_DATA.(syscall.Errno)
+	})
+	assert.True(t, errors.Is(err, errors.ERROR_MORE_DATA), err)
+	assert.Equal(t, 25, calls)
+}
@@ -0,0 +1,503 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// RetryPolicy is how often and how fast a Client retries an operation
+type RetryPolicy struct {
+	// MaxAttempts is the number of calls of an operation, 0 calls it until ctx is done
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff bounds the wait between retries, 0 does not bound it
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after every retry, below 1 the wait stays InitialBackoff
+	Multiplier float64
+}
+
+// DefaultRetryPolicy makes 5 calls waiting 100ms, 200ms, 400ms and 800ms in between
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// backoff returns the wait before retry, the first retry is 1
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	wait := float64(policy.InitialBackoff)
+	if policy.Multiplier > 1 {
+		for i := 1; i < retry; i++ {
+			wait *= policy.Multiplier
+			if policy.MaxBackoff > 0 && wait >= float64(policy.MaxBackoff) {
+				break
+			}
+		}
+	}
+	if policy.MaxBackoff > 0 && wait > float64(policy.MaxBackoff) {
+		return policy.MaxBackoff
+	}
+	return time.Duration(wait)
+}
+
+// Client is a cluster connection that survives restarts of the cluster service
+// and failovers of the node it is connected to. After RPC_S_SERVER_UNAVAILABLE
+// or EPT_S_NOT_REGISTERED the cluster is reopened the way the Client was opened
+// and the ClientResource and ClientKey handles are reopened on their next call.
+//
+// Idempotent operations are retried after these and other transient errors,
+// see errors.IsTransient, following the RetryPolicy until ctx is done.
+// Operations that are not idempotent, such as deletes, return the error
+// and reconnect on the next call.
+type Client struct {
+	backend     Backend
+	remote      bool
+	clusterName string
+	policy      RetryPolicy
+
+	mu      sync.Mutex
+	cluster Cluster
+	// generation counts the opens of the cluster, handles opened
+	// with an older generation are reopened before their next call
+	generation uint64
+	closed     bool
+}
+
+// ClientResource is a resource of a Client
+type ClientResource struct {
+	client *Client
+	name   string
+
+	mu         sync.Mutex
+	handle     Resource
+	generation uint64
+	closed     bool
+}
+
+// ClientKey is a registry key of a ClientResource, path is relative to the resource root
+type ClientKey struct {
+	resource   *ClientResource
+	path       string
+	samDesired int
+
+	mu         sync.Mutex
+	handle     Key
+	generation uint64
+	closed     bool
+}
+
+// OpenClient opens the local cluster through backend, it is reopened with OpenCluster
+func OpenClient(ctx context.Context, backend Backend, policy RetryPolicy) (*Client, error) {
+	return openClient(ctx, &Client{backend: backend, policy: policy})
+}
+
+// OpenRemoteClient opens clusterName through backend, it is reopened with OpenRemoteCluster
+func OpenRemoteClient(ctx context.Context, backend Backend, clusterName string, policy RetryPolicy) (*Client, error) {
+	return openClient(ctx, &Client{backend: backend, remote: true, clusterName: clusterName, policy: policy})
+}
+
+func openClient(ctx context.Context, client *Client) (*Client, error) {
+	err := client.retry(ctx, true, func() (uint64, error) {
+		_, _, err := client.current()
+		return 0, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (client *Client) open() (Cluster, error) {
+	if client.remote {
+		return client.backend.OpenRemoteCluster(client.clusterName)
+	}
+	return client.backend.OpenCluster()
+}
+
+// current returns the cluster and its generation, opening it if it was dropped
+func (client *Client) current() (Cluster, uint64, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if client.closed {
+		return nil, 0, errors.ERROR_INVALID_HANDLE
+	}
+	if client.cluster == nil {
+		cluster, err := client.open()
+		if err != nil {
+			return nil, 0, err
+		}
+		client.cluster = cluster
+		client.generation++
+	}
+	return client.cluster, client.generation, nil
+}
+
+// invalidate drops the cluster of generation after it became unavailable
+func (client *Client) invalidate(generation uint64) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if client.cluster != nil && client.generation == generation {
+		client.cluster.Close()
+		client.cluster = nil
+	}
+}
+
+// stale reports whether the cluster of generation was dropped since
+func (client *Client) stale(generation uint64) bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.cluster == nil || client.generation != generation
+}
+
+// retry calls op until it succeeds or returns an error that is not transient.
+// op returns the generation of the handle it called, 0 when it failed before
+// calling one. After a reconnect error the cluster of that generation is dropped,
+// op is only called again if it is idempotent or had not called a handle.
+// The last error is returned when the policy runs out of attempts or ctx is done
+func (client *Client) retry(ctx context.Context, idempotent bool, op func() (uint64, error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		generation, err := op()
+		if err == nil {
+			return nil
+		}
+
+		switch {
+		case generation == 0:
+			if !errors.IsTransient(err) {
+				return err
+			}
+		case isReconnectError(err):
+			client.invalidate(generation)
+			if !idempotent {
+				return err
+			}
+		case errors.Is(err, errors.ERROR_INVALID_HANDLE) && client.stale(generation):
+			// another call reconnected and closed the handle before op used it
+		case !idempotent || !errors.IsTransient(err):
+			return err
+		}
+
+		if client.policy.MaxAttempts > 0 && attempt >= client.policy.MaxAttempts {
+			return err
+		}
+		timer := time.NewTimer(client.policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// Close closes the cluster, close the resources and keys of the client first.
+// Closing it again returns ERROR_INVALID_HANDLE
+func (client *Client) Close() error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if client.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	client.closed = true
+	if client.cluster == nil {
+		return nil
+	}
+	err := client.cluster.Close()
+	client.cluster = nil
+	if isReconnectError(err) {
+		// the handle belonged to a cluster that is gone
+		return nil
+	}
+	return err
+}
+
+// OpenResource opens resourceName, it is reopened by name after a reconnect
+func (client *Client) OpenResource(ctx context.Context, resourceName string) (*ClientResource, error) {
+	resource := &ClientResource{client: client, name: resourceName}
+	err := client.retry(ctx, true, func() (uint64, error) {
+		_, _, err := resource.current()
+		return 0, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Name returns the name the resource was opened with
+func (resource *ClientResource) Name() string {
+	return resource.name
+}
+
+// current returns the resource handle of the current generation, reopening it if needed
+func (resource *ClientResource) current() (Resource, uint64, error) {
+	cluster, generation, err := resource.client.current()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resource.mu.Lock()
+	defer resource.mu.Unlock()
+
+	if resource.closed {
+		return nil, 0, errors.ERROR_INVALID_HANDLE
+	}
+	if resource.handle != nil && resource.generation == generation {
+		return resource.handle, generation, nil
+	}
+	if resource.handle != nil {
+		resource.handle.Close()
+		resource.handle = nil
+	}
+	handle, err := cluster.OpenResource(resource.name)
+	if err != nil {
+		if isReconnectError(err) {
+			resource.client.invalidate(generation)
+		}
+		return nil, 0, err
+	}
+	resource.handle = handle
+	resource.generation = generation
+	return handle, generation, nil
+}
+
+// GetKey opens the root registry key of the resource
+func (resource *ClientResource) GetKey(ctx context.Context, samDesired int) (*ClientKey, error) {
+	key := &ClientKey{resource: resource, samDesired: samDesired}
+	err := resource.client.retry(ctx, true, func() (uint64, error) {
+		_, _, err := key.current()
+		return 0, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Close closes the resource handle, closing it again returns ERROR_INVALID_HANDLE
+func (resource *ClientResource) Close() error {
+	resource.mu.Lock()
+	defer resource.mu.Unlock()
+
+	if resource.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	resource.closed = true
+	return closeClientHandle(resource.handle, resource.generation, resource.client)
+}
+
+// closeClientHandle closes handle, a handle of a dropped cluster
+// may fail to close and is not reported
+func closeClientHandle(handle interface{ Close() error }, generation uint64, client *Client) error {
+	if handle == nil {
+		return nil
+	}
+	err := handle.Close()
+	if err != nil && (isReconnectError(err) || client.stale(generation)) {
+		return nil
+	}
+	return err
+}
+
+// Path returns the path of the key below the resource root
+func (key *ClientKey) Path() string {
+	return key.path
+}
+
+// current returns the key handle of the current generation, reopening it if needed
+func (key *ClientKey) current() (Key, uint64, error) {
+	resource, generation, err := key.resource.current()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	key.mu.Lock()
+	defer key.mu.Unlock()
+
+	if key.closed {
+		return nil, 0, errors.ERROR_INVALID_HANDLE
+	}
+	if key.handle != nil && key.generation == generation {
+		return key.handle, generation, nil
+	}
+	if key.handle != nil {
+		key.handle.Close()
+		key.handle = nil
+	}
+	handle, err := key.open(resource)
+	if err != nil {
+		if isReconnectError(err) {
+			key.resource.client.invalidate(generation)
+		}
+		return nil, 0, err
+	}
+	key.handle = handle
+	key.generation = generation
+	return handle, generation, nil
+}
+
+func (key *ClientKey) open(resource Resource) (Key, error) {
+	root, err := resource.GetKey(key.samDesired)
+	if err != nil || key.path == "" {
+		return root, err
+	}
+	defer root.Close()
+	return root.OpenKey(key.path, key.samDesired)
+}
+
+// set makes handle of generation the handle of the key
+func (key *ClientKey) set(handle Key, generation uint64) {
+	key.mu.Lock()
+	defer key.mu.Unlock()
+
+	if key.handle != nil {
+		key.handle.Close()
+	}
+	key.handle = handle
+	key.generation = generation
+}
+
+func (key *ClientKey) subkey(keyName string, samDesired int) *ClientKey {
+	path := keyName
+	switch {
+	case keyName == "":
+		path = key.path
+	case key.path != "":
+		path = key.path + `\` + keyName
+	}
+	return &ClientKey{resource: key.resource, path: path, samDesired: samDesired}
+}
+
+// call runs op with the current key handle, retrying it if it is idempotent
+func (key *ClientKey) call(ctx context.Context, idempotent bool, op func(Key) error) error {
+	return key.resource.client.retry(ctx, idempotent, func() (uint64, error) {
+		handle, generation, err := key.current()
+		if err != nil {
+			return 0, err
+		}
+		return generation, op(handle)
+	})
+}
+
+// OpenKey opens keyName below the key
+func (key *ClientKey) OpenKey(ctx context.Context, keyName string, samDesired int) (*ClientKey, error) {
+	subkey := key.subkey(keyName, samDesired)
+	err := key.resource.client.retry(ctx, true, func() (uint64, error) {
+		_, _, err := subkey.current()
+		return 0, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subkey, nil
+}
+
+// CreateKey opens keyName below the key, creating it if it does not exist.
+// created is also true when an attempt that failed to return had created the key
+func (key *ClientKey) CreateKey(ctx context.Context, keyName string, samDesired int) (subkey *ClientKey, created bool, err error) {
+	subkey = key.subkey(keyName, samDesired)
+	err = key.resource.client.retry(ctx, true, func() (uint64, error) {
+		handle, generation, err := key.current()
+		if err != nil {
+			return 0, err
+		}
+		opened, wasCreated, err := handle.CreateKey(keyName, samDesired)
+		if err != nil {
+			return generation, err
+		}
+		created = created || wasCreated
+		subkey.set(opened, generation)
+		return generation, nil
+	})
+	if err != nil {
+		return nil, created, err
+	}
+	return subkey, created, nil
+}
+
+// EnumKeys returns the names of the subkeys
+func (key *ClientKey) EnumKeys(ctx context.Context) (names []string, err error) {
+	err = key.call(ctx, true, func(handle Key) (err error) {
+		names, err = handle.EnumKeys()
+		return
+	})
+	return
+}
+
+// QueryInfo returns the counts and sizes of the subkeys and values
+func (key *ClientKey) QueryInfo(ctx context.Context) (info KeyInfo, err error) {
+	err = key.call(ctx, true, func(handle Key) (err error) {
+		info, err = handle.QueryInfo()
+		return
+	})
+	return
+}
+
+// QueryValue returns the type and data of valueName
+func (key *ClientKey) QueryValue(ctx context.Context, valueName string) (dwType uint32, data []byte, err error) {
+	err = key.call(ctx, true, func(handle Key) (err error) {
+		dwType, data, err = handle.QueryValue(valueName)
+		return
+	})
+	return
+}
+
+// LoadValues returns every value of the key
+func (key *ClientKey) LoadValues(ctx context.Context) (values map[string]RegistryValue, err error) {
+	err = key.call(ctx, true, func(handle Key) (err error) {
+		values, err = handle.LoadValues()
+		return
+	})
+	return
+}
+
+// SetValue sets value to data, it is retried as setting it again has the same result
+func (key *ClientKey) SetValue(ctx context.Context, value string, dwType uint32, data []byte) error {
+	return key.call(ctx, true, func(handle Key) error {
+		return handle.SetValue(value, dwType, data)
+	})
+}
+
+// DeleteValue deletes valueName, it is not retried
+func (key *ClientKey) DeleteValue(ctx context.Context, valueName string) error {
+	return key.call(ctx, false, func(handle Key) error {
+		return handle.DeleteValue(valueName)
+	})
+}
+
+// DeleteKey deletes the subkey keyName, it is not retried
+func (key *ClientKey) DeleteKey(ctx context.Context, keyName string) error {
+	return key.call(ctx, false, func(handle Key) error {
+		return handle.DeleteKey(keyName)
+	})
+}
+
+// DeleteTree deletes keyName and everything under it, it is not retried
+func (key *ClientKey) DeleteTree(ctx context.Context, keyName string) error {
+	return key.call(ctx, false, func(handle Key) error {
+		return handle.DeleteTree(keyName)
+	})
+}
+
+// Close closes the key handle, closing it again returns ERROR_INVALID_HANDLE
+func (key *ClientKey) Close() error {
+	key.mu.Lock()
+	defer key.mu.Unlock()
+
+	if key.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	key.closed = true
+	return closeClientHandle(key.handle, key.generation, key.resource.client)
+}
@@ -0,0 +1,426 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// faultBackend wraps a MemoryBackend to inject failures. restart makes every
+// handle opened before fail with RPC_S_SERVER_UNAVAILABLE, stop makes the opens
+// fail with EPT_S_NOT_REGISTERED and failNext fails the next calls with err
+type faultBackend struct {
+	memory *MemoryBackend
+
+	mu          sync.Mutex
+	epoch       int
+	stopped     bool
+	failures    int
+	failErr     error
+	opens       int
+	remoteOpens int
+	calls       int
+}
+
+type (
+	faultCluster struct {
+		Cluster
+		backend *faultBackend
+		epoch   int
+	}
+	faultResource struct {
+		Resource
+		backend *faultBackend
+		epoch   int
+	}
+	faultKey struct {
+		Key
+		backend *faultBackend
+		epoch   int
+	}
+)
+
+func newFaultBackend() *faultBackend {
+	memory := NewMemoryBackend("test1")
+	memory.AddResource(memResourceName)
+	return &faultBackend{memory: memory}
+}
+
+func (backend *faultBackend) restart() {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.epoch++
+}
+
+func (backend *faultBackend) stop(stopped bool) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.stopped = stopped
+	if stopped {
+		backend.epoch++
+	}
+}
+
+func (backend *faultBackend) failNext(count int, err error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.failures = count
+	backend.failErr = err
+}
+
+func (backend *faultBackend) counts() (opens int, remoteOpens int, calls int) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	return backend.opens, backend.remoteOpens, backend.calls
+}
+
+// check fails a call on a handle of epoch
+func (backend *faultBackend) check(epoch int) error {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.calls++
+	if backend.failures > 0 {
+		backend.failures--
+		return backend.failErr
+	}
+	if epoch != backend.epoch {
+		return errors.RPC_S_SERVER_UNAVAILABLE
+	}
+	return nil
+}
+
+func (backend *faultBackend) open(remote bool) (int, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if backend.stopped {
+		return 0, errors.EPT_S_NOT_REGISTERED
+	}
+	if remote {
+		backend.remoteOpens++
+	} else {
+		backend.opens++
+	}
+	return backend.epoch, nil
+}
+
+func (backend *faultBackend) OpenCluster() (Cluster, error) {
+	epoch, err := backend.open(false)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := backend.memory.OpenCluster()
+	return faultCluster{cluster, backend, epoch}, err
+}
+
+func (backend *faultBackend) OpenRemoteCluster(clusterName string) (Cluster, error) {
+	epoch, err := backend.open(true)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := backend.memory.OpenRemoteCluster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return faultCluster{cluster, backend, epoch}, nil
+}
+
+func (cluster faultCluster) OpenResource(resourceName string) (Resource, error) {
+	if err := cluster.backend.check(cluster.epoch); err != nil {
+		return nil, err
+	}
+	resource, err := cluster.Cluster.OpenResource(resourceName)
+	if err != nil {
+		return nil, err
+	}
+	return faultResource{resource, cluster.backend, cluster.epoch}, nil
+}
+
+func (resource faultResource) GetKey(samDesired int) (Key, error) {
+	if err := resource.backend.check(resource.epoch); err != nil {
+		return nil, err
+	}
+	key, err := resource.Resource.GetKey(samDesired)
+	if err != nil {
+		return nil, err
+	}
+	return faultKey{key, resource.backend, resource.epoch}, nil
+}
+
+func (key faultKey) OpenKey(keyName string, samDesired int) (Key, error) {
+	if err := key.backend.check(key.epoch); err != nil {
+		return nil, err
+	}
+	subkey, err := key.Key.OpenKey(keyName, samDesired)
+	if err != nil {
+		return nil, err
+	}
+	return faultKey{subkey, key.backend, key.epoch}, nil
+}
+
+func (key faultKey) CreateKey(keyName string, samDesired int) (Key, bool, error) {
+	if err := key.backend.check(key.epoch); err != nil {
+		return nil, false, err
+	}
+	subkey, created, err := key.Key.CreateKey(keyName, samDesired)
+	if err != nil {
+		return nil, created, err
+	}
+	return faultKey{subkey, key.backend, key.epoch}, created, nil
+}
+
+func (key faultKey) QueryValue(valueName string) (uint32, []byte, error) {
+	if err := key.backend.check(key.epoch); err != nil {
+		return 0, nil, err
+	}
+	return key.Key.QueryValue(valueName)
+}
+
+func (key faultKey) SetValue(value string, dwType uint32, data []byte) error {
+	if err := key.backend.check(key.epoch); err != nil {
+		return err
+	}
+	return key.Key.SetValue(value, dwType, data)
+}
+
+func (key faultKey) DeleteValue(valueName string) error {
+	if err := key.backend.check(key.epoch); err != nil {
+		return err
+	}
+	return key.Key.DeleteValue(valueName)
+}
+
+func (key faultKey) EnumKeys() ([]string, error) {
+	if err := key.backend.check(key.epoch); err != nil {
+		return nil, err
+	}
+	return key.Key.EnumKeys()
+}
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond, Multiplier: 2}
+
+func openClientKey(t *testing.T, backend *faultBackend) (*Client, *ClientResource, *ClientKey) {
+	ctx := context.Background()
+	client, err := OpenClient(ctx, backend, testRetryPolicy)
+	assert.Nil(t, err)
+	resource, err := client.OpenResource(ctx, "R1")
+	assert.Nil(t, err)
+	key, err := resource.GetKey(ctx, KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	return client, resource, key
+}
+
+func closeClientKey(t *testing.T, client *Client, resource *ClientResource, key *ClientKey) {
+	assert.Nil(t, key.Close())
+	assert.Nil(t, resource.Close())
+	assert.Nil(t, client.Close())
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		waits  []time.Duration
+	}{
+		{"Default", DefaultRetryPolicy, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond}},
+		{"Bounded", RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 3 * time.Second, Multiplier: 2}, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}},
+		{"Constant", RetryPolicy{InitialBackoff: time.Second}, []time.Duration{time.Second, time.Second, time.Second}},
+		{"Unbounded", RetryPolicy{InitialBackoff: time.Second, Multiplier: 3}, []time.Duration{time.Second, 3 * time.Second, 9 * time.Second}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i, wait := range test.waits {
+				assert.Equal(t, wait, test.policy.backoff(i+1))
+			}
+		})
+	}
+}
+
+func TestClientReconnectsAfterRestart(t *testing.T) {
+	backend := newFaultBackend()
+	client, resource, key := openClientKey(t, backend)
+	defer closeClientKey(t, client, resource, key)
+	ctx := context.Background()
+
+	subkey, created, err := key.CreateKey(ctx, `Parameters\Inner`, KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	assert.True(t, created)
+	assert.Equal(t, `Parameters\Inner`, subkey.Path())
+	defer subkey.Close()
+	assert.Nil(t, subkey.SetValue(ctx, "v", REG_DWORD, []byte{1, 0, 0, 0}))
+
+	backend.restart()
+
+	dwType, data, err := subkey.QueryValue(ctx, "v")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(REG_DWORD), dwType)
+	assert.Equal(t, []byte{1, 0, 0, 0}, data)
+
+	names, err := key.EnumKeys(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Parameters"}, names)
+
+	opens, remoteOpens, _ := backend.counts()
+	assert.Equal(t, 2, opens)
+	assert.Equal(t, 0, remoteOpens)
+}
+
+func TestClientRemoteReconnect(t *testing.T) {
+	backend := newFaultBackend()
+	ctx := context.Background()
+	client, err := OpenRemoteClient(ctx, backend, "test1", testRetryPolicy)
+	assert.Nil(t, err)
+	defer client.Close()
+	resource, err := client.OpenResource(ctx, memResourceName)
+	assert.Nil(t, err)
+	defer resource.Close()
+	key, err := resource.GetKey(ctx, KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	defer key.Close()
+
+	backend.restart()
+	_, _, err = key.QueryValue(ctx, "missing")
+	assert.True(t, errors.Is(err, errors.ERROR_FILE_NOT_FOUND))
+
+	opens, remoteOpens, _ := backend.counts()
+	assert.Equal(t, 0, opens)
+	assert.Equal(t, 2, remoteOpens)
+
+	_, err = OpenRemoteClient(ctx, backend, "missing", RetryPolicy{MaxAttempts: 2})
+	assert.True(t, errors.Is(err, errors.RPC_S_SERVER_UNAVAILABLE))
+}
+
+func TestClientRetriesTransient(t *testing.T) {
+	backend := newFaultBackend()
+	client, resource, key := openClientKey(t, backend)
+	defer closeClientKey(t, client, resource, key)
+	ctx := context.Background()
+
+	backend.failNext(2, errors.ERROR_CLUSTER_GROUP_MOVING)
+	assert.Nil(t, key.SetValue(ctx, "v", REG_SZ, []byte{0, 0}))
+
+	backend.failNext(1, errors.EPT_S_NOT_REGISTERED)
+	_, data, err := key.QueryValue(ctx, "v")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0}, data)
+
+	opens, _, _ := backend.counts()
+	assert.Equal(t, 2, opens)
+}
+
+func TestClientGivesUp(t *testing.T) {
+	backend := newFaultBackend()
+	client, resource, key := openClientKey(t, backend)
+	defer closeClientKey(t, client, resource, key)
+	ctx := context.Background()
+
+	_, _, before := backend.counts()
+	backend.failNext(100, errors.ERROR_CLUSTER_GROUP_MOVING)
+	_, _, err := key.QueryValue(ctx, "v")
+	assert.True(t, errors.Is(err, errors.ERROR_CLUSTER_GROUP_MOVING))
+	_, _, after := backend.counts()
+	assert.Equal(t, testRetryPolicy.MaxAttempts, after-before)
+	backend.failNext(0, nil)
+}
+
+func TestClientDoesNotRetry(t *testing.T) {
+	backend := newFaultBackend()
+	client, resource, key := openClientKey(t, backend)
+	defer closeClientKey(t, client, resource, key)
+	ctx := context.Background()
+
+	_, _, before := backend.counts()
+	_, _, err := key.QueryValue(ctx, "missing")
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, err)
+	_, _, after := backend.counts()
+	assert.Equal(t, 1, after-before)
+
+	assert.Nil(t, key.SetValue(ctx, "v", REG_SZ, []byte{0, 0}))
+	backend.failNext(1, errors.RPC_S_SERVER_UNAVAILABLE)
+	err = key.DeleteValue(ctx, "v")
+	assert.Equal(t, errors.RPC_S_SERVER_UNAVAILABLE, err)
+
+	// the next call reconnects
+	assert.Nil(t, key.DeleteValue(ctx, "v"))
+	opens, _, _ := backend.counts()
+	assert.Equal(t, 2, opens)
+}
+
+func TestClientContextDeadline(t *testing.T) {
+	backend := newFaultBackend()
+	client, resource, key := openClientKey(t, backend)
+	defer closeClientKey(t, client, resource, key)
+
+	backend.stop(true)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client.policy = RetryPolicy{InitialBackoff: time.Millisecond}
+	start := time.Now()
+	_, err := key.EnumKeys(ctx)
+	assert.True(t, errors.Is(err, errors.EPT_S_NOT_REGISTERED))
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	cancel()
+	_, err = key.EnumKeys(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	backend.stop(false)
+	_, err = key.EnumKeys(context.Background())
+	assert.Nil(t, err)
+}
+
+func TestClientOpenWaitsForService(t *testing.T) {
+	backend := newFaultBackend()
+	backend.stop(true)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		backend.stop(false)
+	}()
+
+	client, err := OpenClient(context.Background(), backend, RetryPolicy{InitialBackoff: time.Millisecond})
+	assert.Nil(t, err)
+	assert.Nil(t, client.Close())
+}
+
+func TestClientClose(t *testing.T) {
+	backend := newFaultBackend()
+	client, resource, key := openClientKey(t, backend)
+	ctx := context.Background()
+
+	backend.restart()
+	closeClientKey(t, client, resource, key)
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, key.Close())
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, resource.Close())
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, client.Close())
+
+	_, err := key.EnumKeys(ctx)
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err)
+	_, err = client.OpenResource(ctx, memResourceName)
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err)
+}
+
+func TestClientConcurrentReconnect(t *testing.T) {
+	backend := newFaultBackend()
+	client, resource, key := openClientKey(t, backend)
+	defer closeClientKey(t, client, resource, key)
+	ctx := context.Background()
+	assert.Nil(t, key.SetValue(ctx, "v", REG_SZ, []byte{0, 0}))
+
+	backend.restart()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := key.QueryValue(ctx, "v")
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	opens, _, _ := backend.counts()
+	assert.Equal(t, 2, opens)
+}
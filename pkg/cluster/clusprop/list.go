@@ -0,0 +1,309 @@
+package clusprop
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+)
+
+// Property is a named entry of a property list, most properties have
+// a single value but the format allows a list of values
+type Property struct {
+	Name   string
+	Values []Value
+}
+
+// Value returns the first value of the property
+func (property Property) Value() Value {
+	if len(property.Values) == 0 {
+		return Value{}
+	}
+	return property.Values[0]
+}
+
+// PropertyList is a CLUSPROP property list
+type PropertyList []Property
+
+// ValueList is a CLUSPROP value list
+type ValueList []Value
+
+// align rounds length up to the CLUSPROP 4 byte alignment
+func align(length int) int {
+	return (length + 3) &^ 3
+}
+
+func invalidData(format string, args ...interface{}) error {
+	return fmt.Errorf("clusprop "+format+": %w", append(args, errors.ERROR_INVALID_DATA)...)
+}
+
+// Get returns the property name, names are case insensitive
+func (list PropertyList) Get(name string) (Property, bool) {
+	for _, property := range list {
+		if strings.EqualFold(property.Name, name) {
+			return property, true
+		}
+	}
+	return Property{}, false
+}
+
+// Set replaces the values of property name or appends the property
+func (list *PropertyList) Set(name string, values ...Value) {
+	for i, property := range *list {
+		if strings.EqualFold(property.Name, name) {
+			(*list)[i].Values = values
+			return
+		}
+	}
+	*list = append(*list, Property{Name: name, Values: values})
+}
+
+// Names returns the property names in order
+func (list PropertyList) Names() []string {
+	names := make([]string, 0, len(list))
+	for _, property := range list {
+		names = append(names, property.Name)
+	}
+	return names
+}
+
+func (list PropertyList) value(name string) (Value, error) {
+	property, ok := list.Get(name)
+	if !ok {
+		return Value{}, fmt.Errorf("clusprop property %q: %w", name, errors.ERROR_FILE_NOT_FOUND)
+	}
+	return property.Value(), nil
+}
+
+// DWord returns the DWORD property name
+func (list PropertyList) DWord(name string) (uint32, error) {
+	value, err := list.value(name)
+	if err != nil {
+		return 0, err
+	}
+	return value.DWord()
+}
+
+// Long returns the LONG property name
+func (list PropertyList) Long(name string) (int32, error) {
+	value, err := list.value(name)
+	if err != nil {
+		return 0, err
+	}
+	return value.Long()
+}
+
+// ULargeInteger returns the ULARGE_INTEGER property name
+func (list PropertyList) ULargeInteger(name string) (uint64, error) {
+	value, err := list.value(name)
+	if err != nil {
+		return 0, err
+	}
+	return value.ULargeInteger()
+}
+
+// Str returns the SZ, EXPAND_SZ or EXPANDED_SZ property name
+func (list PropertyList) Str(name string) (string, error) {
+	value, err := list.value(name)
+	if err != nil {
+		return "", err
+	}
+	return value.Str()
+}
+
+// Strings returns the MULTI_SZ property name
+func (list PropertyList) Strings(name string) ([]string, error) {
+	value, err := list.value(name)
+	if err != nil {
+		return nil, err
+	}
+	return value.Strings()
+}
+
+// Binary returns the BINARY or SECURITY_DESCRIPTOR property name
+func (list PropertyList) Binary(name string) ([]byte, error) {
+	value, err := list.value(name)
+	if err != nil {
+		return nil, err
+	}
+	return value.Binary()
+}
+
+// appendValue writes the syntax, length and padded data of value
+func appendValue(buffer []byte, value Value) []byte {
+	buffer = append(buffer, util.Uint32ToByte(uint32(value.Syntax))...)
+	buffer = append(buffer, util.Uint32ToByte(uint32(len(value.Data)))...)
+	buffer = append(buffer, value.Data...)
+	return append(buffer, make([]byte, align(len(value.Data))-len(value.Data))...)
+}
+
+func appendEndMark(buffer []byte) []byte {
+	return append(buffer, util.Uint32ToByte(uint32(CLUSPROP_SYNTAX_ENDMARK))...)
+}
+
+func appendValues(buffer []byte, values []Value) ([]byte, error) {
+	for _, value := range values {
+		if value.Syntax == CLUSPROP_SYNTAX_ENDMARK {
+			return nil, invalidData("value list contains an end mark")
+		}
+		if err := value.validate(); err != nil {
+			return nil, err
+		}
+		buffer = appendValue(buffer, value)
+	}
+	return appendEndMark(buffer), nil
+}
+
+// MarshalBinary encodes the property list, each property is its name
+// followed by its values and an end mark
+func (list PropertyList) MarshalBinary() ([]byte, error) {
+	buffer := util.Uint32ToByte(uint32(len(list)))
+	for _, property := range list {
+		if len(property.Values) == 0 {
+			return nil, invalidData("property %q has no value", property.Name)
+		}
+		name, err := util.StringToUTF16Bytes(property.Name)
+		if err != nil {
+			return nil, err
+		}
+		buffer = appendValue(buffer, Value{CLUSPROP_SYNTAX_NAME, name})
+		buffer, err = appendValues(buffer, property.Values)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", property.Name, err)
+		}
+	}
+	return buffer, nil
+}
+
+// MarshalBinary encodes the value list terminated by an end mark
+func (list ValueList) MarshalBinary() ([]byte, error) {
+	return appendValues(nil, list)
+}
+
+// reader walks a CLUSPROP buffer
+type reader struct {
+	data   []byte
+	offset int
+}
+
+func (r *reader) dword() (uint32, error) {
+	if len(r.data)-r.offset < 4 {
+		return 0, invalidData("buffer truncated at offset %d", r.offset)
+	}
+	v := util.ByteToUint32(r.data[r.offset:])
+	r.offset += 4
+	return v, nil
+}
+
+// value reads the next value, ok is false at an end mark
+func (r *reader) value() (value Value, ok bool, err error) {
+	start := r.offset
+	syntax, err := r.dword()
+	if err != nil {
+		return
+	}
+	if Syntax(syntax) == CLUSPROP_SYNTAX_ENDMARK {
+		return
+	}
+	length, err := r.dword()
+	if err != nil {
+		return
+	}
+	if (uint64(length)+3)&^3 > uint64(len(r.data)-r.offset) {
+		err = invalidData("value at offset %d has length %d past the end of the buffer", start, length)
+		return
+	}
+	value = Value{Syntax(syntax), append([]byte(nil), r.data[r.offset:r.offset+int(length)]...)}
+	r.offset += align(int(length))
+	if err = value.validate(); err != nil {
+		err = fmt.Errorf("value at offset %d: %w", start, err)
+		return
+	}
+	ok = true
+	return
+}
+
+// values reads values up to and including the end mark
+func (r *reader) values() ([]Value, error) {
+	var values []Value
+	for {
+		value, ok, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return values, nil
+		}
+		values = append(values, value)
+	}
+}
+
+func (r *reader) end() error {
+	if r.offset != len(r.data) {
+		return invalidData("%d trailing bytes", len(r.data)-r.offset)
+	}
+	return nil
+}
+
+func checkAlignment(data []byte) error {
+	if len(data)%4 != 0 {
+		return invalidData("buffer length %d is not 4 byte aligned", len(data))
+	}
+	return nil
+}
+
+// ParsePropertyList decodes a property list, the buffer must hold exactly
+// the number of properties in its count and be 4 byte aligned
+func ParsePropertyList(data []byte) (PropertyList, error) {
+	if err := checkAlignment(data); err != nil {
+		return nil, err
+	}
+	r := &reader{data: data}
+	count, err := r.dword()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(count) > uint64(len(data)/8) {
+		return nil, invalidData("property count %d does not fit the buffer", count)
+	}
+	list := make(PropertyList, 0, count)
+	for i := uint32(0); i < count; i++ {
+		name, ok, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || name.Syntax != CLUSPROP_SYNTAX_NAME {
+			return nil, invalidData("property %d does not start with a name", i)
+		}
+		values, err := r.values()
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return nil, invalidData("property %d has no value", i)
+		}
+		list = append(list, Property{Name: util.UTF16BytesToString(name.Data), Values: values})
+	}
+	if err = r.end(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ParseValueList decodes a value list, the buffer must end
+// with the end mark and be 4 byte aligned
+func ParseValueList(data []byte) (ValueList, error) {
+	if err := checkAlignment(data); err != nil {
+		return nil, err
+	}
+	r := &reader{data: data}
+	values, err := r.values()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.end(); err != nil {
+		return nil, err
+	}
+	return ValueList(values), nil
+}
@@ -0,0 +1,126 @@
+package clusprop
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixtureProperties is a property list as returned by CLUSCTL_RESOURCE_GET_PRIVATE_PROPERTIES
+// with the DWORD Timeout=30 and the SZ Path="c"
+var fixtureProperties = []byte{
+	2, 0, 0, 0, // count
+	0x03, 0, 0x04, 0, 16, 0, 0, 0, // CLUSPROP_SYNTAX_NAME, 16 bytes
+	'T', 0, 'i', 0, 'm', 0, 'e', 0, 'o', 0, 'u', 0, 't', 0, 0, 0,
+	0x02, 0, 0x01, 0, 4, 0, 0, 0, // CLUSPROP_SYNTAX_LIST_VALUE_DWORD
+	30, 0, 0, 0,
+	0, 0, 0, 0, // end mark
+	0x03, 0, 0x04, 0, 10, 0, 0, 0, // CLUSPROP_SYNTAX_NAME, 10 bytes padded to 12
+	'P', 0, 'a', 0, 't', 0, 'h', 0, 0, 0, 0, 0,
+	0x03, 0, 0x01, 0, 4, 0, 0, 0, // CLUSPROP_SYNTAX_LIST_VALUE_SZ
+	'c', 0, 0, 0,
+	0, 0, 0, 0, // end mark
+}
+
+func TestParsePropertyList(t *testing.T) {
+	list, err := ParsePropertyList(fixtureProperties)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Timeout", "Path"}, list.Names())
+
+	timeout, err := list.DWord("timeout")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(30), timeout)
+
+	path, err := list.Str("Path")
+	assert.Nil(t, err)
+	assert.Equal(t, "c", path)
+
+	_, err = list.Str("Timeout")
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+	_, err = list.DWord("Missing")
+	assert.True(t, goerrors.Is(err, errors.ERROR_FILE_NOT_FOUND))
+
+	data, err := list.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, fixtureProperties, data)
+}
+
+func TestPropertyListRoundTrip(t *testing.T) {
+	var list PropertyList
+	path, _ := ExpandStringValue(`%windir%\temp`)
+	owners, _ := MultiStringValue([]string{"node1", "node2"})
+	list.Set("Path", path)
+	list.Set("Owners", owners)
+	list.Set("Size", ULargeIntegerValue(1<<40))
+	list.Set("Offset", LongValue(-5))
+	list.Set("Port", WordValue(443))
+	list.Set("Blob", BinaryValue([]byte{1, 2, 3}))
+	list.Set("Path", path)
+
+	data, err := list.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Zero(t, len(data)%4)
+
+	parsed, err := ParsePropertyList(data)
+	assert.Nil(t, err)
+	assert.Equal(t, list, parsed)
+
+	strs, err := parsed.Strings("Owners")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"node1", "node2"}, strs)
+	size, err := parsed.ULargeInteger("Size")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1<<40), size)
+	blob, err := parsed.Binary("Blob")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, blob)
+}
+
+func TestParsePropertyListInvalid(t *testing.T) {
+	invalid := map[string][]byte{
+		"empty":         {},
+		"unaligned":     fixtureProperties[:len(fixtureProperties)-1],
+		"truncated":     fixtureProperties[:len(fixtureProperties)-4],
+		"trailing":      append(append([]byte(nil), fixtureProperties...), 0, 0, 0, 0),
+		"count too big": append([]byte{3, 0, 0, 0}, fixtureProperties[4:]...),
+		"no name":       {1, 0, 0, 0, 0x02, 0, 0x01, 0, 4, 0, 0, 0, 30, 0, 0, 0, 0, 0, 0, 0},
+		"no value":      {1, 0, 0, 0, 0x03, 0, 0x04, 0, 4, 0, 0, 0, 'a', 0, 0, 0, 0, 0, 0, 0},
+		"past end":      {1, 0, 0, 0, 0x03, 0, 0x04, 0, 0xff, 0, 0, 0, 'a', 0, 0, 0},
+		"bad dword":     {1, 0, 0, 0, 0x03, 0, 0x04, 0, 4, 0, 0, 0, 'a', 0, 0, 0, 0x02, 0, 0x01, 0, 2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+		"unterminated":  {1, 0, 0, 0, 0x03, 0, 0x04, 0, 4, 0, 0, 0, 'a', 0, 'b', 0, 0x02, 0, 0x01, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	for name, data := range invalid {
+		_, err := ParsePropertyList(data)
+		assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA), name)
+	}
+
+	_, err := PropertyList{{Name: "empty"}}.MarshalBinary()
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+	_, err = PropertyList{{Name: "bad", Values: []Value{{CLUSPROP_SYNTAX_LIST_VALUE_DWORD, []byte{1}}}}}.MarshalBinary()
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+}
+
+func TestValueList(t *testing.T) {
+	name, _ := StringValue("r1")
+	list := ValueList{DWordValue(7), name, {CLUSPROP_SYNTAX_DISK_SIZE, []byte{0, 0, 0, 0, 1, 0, 0, 0}}}
+
+	data, err := list.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{
+		0x02, 0, 0x01, 0, 4, 0, 0, 0, 7, 0, 0, 0,
+		0x03, 0, 0x01, 0, 6, 0, 0, 0, 'r', 0, '1', 0, 0, 0, 0, 0,
+		0x06, 0, 0x0c, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0,
+		0, 0, 0, 0,
+	}, data)
+
+	parsed, err := ParseValueList(data)
+	assert.Nil(t, err)
+	assert.Equal(t, list, parsed)
+
+	_, err = ParseValueList(data[:len(data)-4])
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+	_, err = ParseValueList(append(data, 0, 0, 0, 0))
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+}
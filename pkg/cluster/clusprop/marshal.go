@@ -0,0 +1,328 @@
+package clusprop
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// Marshal and Unmarshal map the exported fields of a struct to a property
+// list using the clusprop struct tag
+//
+//	type Parameters struct {
+//		ServiceName       string   `clusprop:"ServiceName"`
+//		StartupParameters string   `clusprop:",expand_sz,omitempty"`
+//		RestartThreshold  uint32   `clusprop:"RestartThreshold,dword"`
+//		Nodes             []string `clusprop:"Nodes"`
+//		Ignored           string   `clusprop:"-"`
+//	}
+//
+// The name defaults to the field name and the format is inferred from the
+// field when omitted. Supported formats are sz and expand_sz for string,
+// multi_sz for []string, binary and security_descriptor for []byte, dword for
+// bool and unsigned integers up to 32 bits, long for signed integers up to 32
+// bits, ularge_integer for uint64, large_integer for int64, word for uint16
+// and filetime for time.Time. omitempty leaves zero values out of Marshal so
+// only the properties being changed are sent.
+
+const structTag = "clusprop"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type propField struct {
+	index     int
+	name      string
+	syntax    Syntax
+	omitEmpty bool
+}
+
+var formatKinds = map[string]Syntax{
+	"sz":                  CLUSPROP_SYNTAX_LIST_VALUE_SZ,
+	"expand_sz":           CLUSPROP_SYNTAX_LIST_VALUE_EXPAND_SZ,
+	"multi_sz":            CLUSPROP_SYNTAX_LIST_VALUE_MULTI_SZ,
+	"binary":              CLUSPROP_SYNTAX_LIST_VALUE_BINARY,
+	"security_descriptor": CLUSPROP_SYNTAX_LIST_VALUE_SECURITY_DESCRIPTOR,
+	"dword":               CLUSPROP_SYNTAX_LIST_VALUE_DWORD,
+	"long":                CLUSPROP_SYNTAX_LIST_VALUE_LONG,
+	"word":                CLUSPROP_SYNTAX_LIST_VALUE_WORD,
+	"ularge_integer":      CLUSPROP_SYNTAX_LIST_VALUE_ULARGE_INTEGER,
+	"large_integer":       CLUSPROP_SYNTAX_LIST_VALUE_LARGE_INTEGER,
+	"filetime":            CLUSPROP_SYNTAX_LIST_VALUE_FILETIME,
+}
+
+// Marshal encodes the fields of the struct v as a property list
+func Marshal(v interface{}) (PropertyList, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("clusprop: Marshal of non struct %T: %w", v, errors.ERROR_INVALID_PARAMETER)
+	}
+	fields, err := structFields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+	list := make(PropertyList, 0, len(fields))
+	for _, field := range fields {
+		fv := value.Field(field.index)
+		if field.omitEmpty && isZero(fv) {
+			continue
+		}
+		encoded, err := encodeField(fv, field.syntax)
+		if err != nil {
+			return nil, fmt.Errorf("clusprop: field %s: %w", value.Type().Field(field.index).Name, err)
+		}
+		list = append(list, Property{Name: field.name, Values: []Value{encoded}})
+	}
+	return list, nil
+}
+
+// Unmarshal decodes list into the struct pointed to by v, fields
+// without a matching property are left unchanged
+func Unmarshal(list PropertyList, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("clusprop: Unmarshal requires a non nil struct pointer, got %T: %w", v, errors.ERROR_INVALID_PARAMETER)
+	}
+	value = value.Elem()
+	fields, err := structFields(value.Type())
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		property, ok := list.Get(field.name)
+		if !ok {
+			continue
+		}
+		if err := decodeField(value.Field(field.index), field.syntax, property.Value()); err != nil {
+			return fmt.Errorf("clusprop: property %s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// structFields parses the clusprop tags of t
+func structFields(t reflect.Type) ([]propField, error) {
+	var fields []propField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tag := sf.Tag.Get(structTag)
+		if tag == "-" {
+			continue
+		}
+		field := propField{index: i, name: sf.Name}
+		kind := ""
+		if tag != "" {
+			parts := strings.Split(tag, ",")
+			if parts[0] != "" {
+				field.name = parts[0]
+			}
+			for _, option := range parts[1:] {
+				if option == "omitempty" {
+					field.omitEmpty = true
+				} else if option != "" {
+					kind = option
+				}
+			}
+		}
+		syntax, err := fieldSyntax(sf.Type, kind)
+		if err != nil {
+			return nil, fmt.Errorf("clusprop: field %s: %w", sf.Name, err)
+		}
+		field.syntax = syntax
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// fieldSyntax returns the value syntax for a field of type t tagged with kind
+func fieldSyntax(t reflect.Type, kind string) (Syntax, error) {
+	var allowed []Syntax
+	switch {
+	case t == timeType:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_FILETIME}
+	case t.Kind() == reflect.String:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_SZ, CLUSPROP_SYNTAX_LIST_VALUE_EXPAND_SZ}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_MULTI_SZ}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_BINARY, CLUSPROP_SYNTAX_LIST_VALUE_SECURITY_DESCRIPTOR}
+	case t.Kind() == reflect.Bool:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_DWORD}
+	case t.Kind() == reflect.Uint64:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_ULARGE_INTEGER, CLUSPROP_SYNTAX_LIST_VALUE_DWORD}
+	case t.Kind() == reflect.Int64:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_LARGE_INTEGER, CLUSPROP_SYNTAX_LIST_VALUE_LONG}
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint32:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_DWORD, CLUSPROP_SYNTAX_LIST_VALUE_WORD, CLUSPROP_SYNTAX_LIST_VALUE_ULARGE_INTEGER}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int32:
+		allowed = []Syntax{CLUSPROP_SYNTAX_LIST_VALUE_LONG, CLUSPROP_SYNTAX_LIST_VALUE_DWORD, CLUSPROP_SYNTAX_LIST_VALUE_LARGE_INTEGER}
+	default:
+		return 0, fmt.Errorf("unsupported type %s: %w", t, errors.ERROR_INVALID_PARAMETER)
+	}
+	if kind == "" {
+		return allowed[0], nil
+	}
+	syntax, ok := formatKinds[kind]
+	if !ok {
+		return 0, fmt.Errorf("unknown property format %s: %w", kind, errors.ERROR_INVALID_PARAMETER)
+	}
+	for _, a := range allowed {
+		if a == syntax {
+			return syntax, nil
+		}
+	}
+	return 0, fmt.Errorf("type %s can not be stored as %s: %w", t, kind, errors.ERROR_INVALID_PARAMETER)
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Len() == 0
+	case reflect.Struct:
+		return v.Interface().(time.Time).IsZero()
+	}
+	return v.Interface() == reflect.Zero(v.Type()).Interface()
+}
+
+func encodeField(v reflect.Value, syntax Syntax) (Value, error) {
+	switch syntax.Format() {
+	case CLUSPROP_FORMAT_FILETIME:
+		return FileTimeValue(v.Interface().(time.Time)), nil
+	case CLUSPROP_FORMAT_SZ, CLUSPROP_FORMAT_EXPAND_SZ:
+		value, err := StringValue(v.String())
+		value.Syntax = syntax
+		return value, err
+	case CLUSPROP_FORMAT_MULTI_SZ:
+		return MultiStringValue(v.Convert(reflect.TypeOf([]string(nil))).Interface().([]string))
+	case CLUSPROP_FORMAT_BINARY, CLUSPROP_FORMAT_SECURITY_DESCRIPTOR:
+		return Value{syntax, append([]byte(nil), v.Bytes()...)}, nil
+	}
+
+	var number int64
+	var unsigned uint64
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			unsigned = 1
+		}
+		number = int64(unsigned)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = v.Int()
+		unsigned = uint64(number)
+	default:
+		unsigned = v.Uint()
+		number = int64(unsigned)
+	}
+
+	switch syntax.Format() {
+	case CLUSPROP_FORMAT_DWORD:
+		if unsigned > math.MaxUint32 {
+			return Value{}, fmt.Errorf("%d overflows a dword: %w", v.Interface(), errors.ERROR_INVALID_PARAMETER)
+		}
+		return DWordValue(uint32(unsigned)), nil
+	case CLUSPROP_FORMAT_WORD:
+		if unsigned > math.MaxUint16 {
+			return Value{}, fmt.Errorf("%d overflows a word: %w", v.Interface(), errors.ERROR_INVALID_PARAMETER)
+		}
+		return WordValue(uint16(unsigned)), nil
+	case CLUSPROP_FORMAT_LONG:
+		if number < math.MinInt32 || number > math.MaxInt32 {
+			return Value{}, fmt.Errorf("%d overflows a long: %w", v.Interface(), errors.ERROR_INVALID_PARAMETER)
+		}
+		return LongValue(int32(number)), nil
+	case CLUSPROP_FORMAT_LARGE_INTEGER:
+		return LargeIntegerValue(number), nil
+	}
+	return ULargeIntegerValue(unsigned), nil
+}
+
+// decodeField stores value into v, integers of any size are
+// accepted so a field can be widened later
+func decodeField(v reflect.Value, syntax Syntax, value Value) error {
+	switch syntax.Format() {
+	case CLUSPROP_FORMAT_FILETIME:
+		t, err := value.FileTime()
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case CLUSPROP_FORMAT_SZ, CLUSPROP_FORMAT_EXPAND_SZ:
+		str, err := value.Str()
+		if err != nil {
+			return err
+		}
+		v.SetString(str)
+		return nil
+	case CLUSPROP_FORMAT_MULTI_SZ:
+		strs, err := value.Strings()
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(strs).Convert(v.Type()))
+		return nil
+	case CLUSPROP_FORMAT_BINARY, CLUSPROP_FORMAT_SECURITY_DESCRIPTOR:
+		data, err := value.Binary()
+		if err != nil {
+			return err
+		}
+		v.SetBytes(data)
+		return nil
+	}
+
+	var number int64
+	var err error
+	signed := false
+	switch value.Syntax.Format() {
+	case CLUSPROP_FORMAT_DWORD:
+		var dword uint32
+		dword, err = value.DWord()
+		number = int64(dword)
+	case CLUSPROP_FORMAT_WORD:
+		var word uint16
+		word, err = value.Word()
+		number = int64(word)
+	case CLUSPROP_FORMAT_LONG:
+		var long int32
+		long, err = value.Long()
+		number = int64(long)
+		signed = true
+	case CLUSPROP_FORMAT_LARGE_INTEGER:
+		number, err = value.LargeInteger()
+		signed = true
+	case CLUSPROP_FORMAT_ULARGE_INTEGER:
+		var ularge uint64
+		ularge, err = value.ULargeInteger()
+		number = int64(ularge)
+	default:
+		return fmt.Errorf("%s is not an integer: %w", value.Syntax.Format(), errors.ERROR_INVALID_DATA)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(number != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.OverflowInt(number) || (!signed && number < 0) {
+			return fmt.Errorf("%d overflows %s: %w", uint64(number), v.Type(), errors.ERROR_INVALID_DATA)
+		}
+		v.SetInt(number)
+	default:
+		if (signed && number < 0) || v.OverflowUint(uint64(number)) {
+			return fmt.Errorf("%d overflows %s: %w", number, v.Type(), errors.ERROR_INVALID_DATA)
+		}
+		v.SetUint(uint64(number))
+	}
+	return nil
+}
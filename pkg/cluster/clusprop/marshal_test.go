@@ -0,0 +1,144 @@
+package clusprop
+
+import (
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type marshalParameters struct {
+	Timeout  uint32    `clusprop:"Timeout"`
+	Path     string    `clusprop:"Path,expand_sz"`
+	Name     string    `clusprop:",omitempty"`
+	Nodes    []string  `clusprop:"Nodes"`
+	Offset   int32     `clusprop:"Offset"`
+	Size     uint64    `clusprop:"Size"`
+	Enabled  bool      `clusprop:"Enabled"`
+	Port     uint16    `clusprop:"Port,word"`
+	Blob     []byte    `clusprop:"Blob"`
+	Modified time.Time `clusprop:"Modified"`
+	Ignored  string    `clusprop:"-"`
+	private  string
+}
+
+func TestMarshalStruct(t *testing.T) {
+	modified := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	list, err := Marshal(&marshalParameters{
+		Timeout:  30,
+		Path:     `%SystemRoot%\x`,
+		Nodes:    []string{"n1", "n2"},
+		Offset:   -5,
+		Size:     1 << 40,
+		Enabled:  true,
+		Port:     8080,
+		Blob:     []byte{1, 2},
+		Modified: modified,
+		Ignored:  "ignored",
+		private:  "private",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Timeout", "Path", "Nodes", "Offset", "Size", "Enabled", "Port", "Blob", "Modified"}, list.Names())
+
+	property, _ := list.Get("Path")
+	assert.Equal(t, CLUSPROP_SYNTAX_LIST_VALUE_EXPAND_SZ, property.Value().Syntax)
+	property, _ = list.Get("Offset")
+	assert.Equal(t, CLUSPROP_SYNTAX_LIST_VALUE_LONG, property.Value().Syntax)
+	property, _ = list.Get("Enabled")
+	assert.Equal(t, DWordValue(1), property.Value())
+
+	// the list has to survive the wire format
+	data, err := list.MarshalBinary()
+	assert.Nil(t, err)
+	parsed, err := ParsePropertyList(data)
+	assert.Nil(t, err)
+
+	var decoded marshalParameters
+	decoded.Name = "unchanged"
+	assert.Nil(t, Unmarshal(parsed, &decoded))
+	assert.Equal(t, uint32(30), decoded.Timeout)
+	assert.Equal(t, `%SystemRoot%\x`, decoded.Path)
+	assert.Equal(t, "unchanged", decoded.Name)
+	assert.Equal(t, []string{"n1", "n2"}, decoded.Nodes)
+	assert.Equal(t, int32(-5), decoded.Offset)
+	assert.Equal(t, uint64(1<<40), decoded.Size)
+	assert.True(t, decoded.Enabled)
+	assert.Equal(t, uint16(8080), decoded.Port)
+	assert.Equal(t, []byte{1, 2}, decoded.Blob)
+	assert.True(t, modified.Equal(decoded.Modified))
+}
+
+func TestUnmarshalFixture(t *testing.T) {
+	list, err := ParsePropertyList(fixtureProperties)
+	assert.Nil(t, err)
+
+	var params struct {
+		Timeout uint64 `clusprop:"timeout"`
+		Path    string
+		Missing uint32
+	}
+	params.Missing = 7
+	assert.Nil(t, Unmarshal(list, &params))
+	assert.Equal(t, uint64(30), params.Timeout)
+	assert.Equal(t, "c", params.Path)
+	assert.Equal(t, uint32(7), params.Missing)
+}
+
+func TestUnmarshalMismatch(t *testing.T) {
+	var list PropertyList
+	list.Set("Small", DWordValue(300))
+	list.Set("Negative", LongValue(-1))
+	list.Set("Text", DWordValue(1))
+
+	var small struct{ Small uint8 }
+	err := Unmarshal(list, &small)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+
+	var negative struct{ Negative uint32 }
+	err = Unmarshal(list, &negative)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+
+	var text struct{ Text string }
+	err = Unmarshal(list, &text)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+
+	assert.True(t, goerrors.Is(Unmarshal(list, small), errors.ERROR_INVALID_PARAMETER))
+}
+
+func TestMarshalErrors(t *testing.T) {
+	_, err := Marshal(3)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+
+	_, err = Marshal(struct {
+		Value string `clusprop:"Value,dword"`
+	}{})
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+
+	_, err = Marshal(struct{ Value float64 }{})
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+
+	_, err = Marshal(struct {
+		Value int64 `clusprop:"Value,long"`
+	}{1 << 40})
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+
+	_, err = Marshal(struct {
+		Value uint32 `clusprop:"Value,word"`
+	}{1 << 20})
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+	assert.Contains(t, err.Error(), "clusprop: field Value: ")
+}
+
+func TestMarshalOmitEmpty(t *testing.T) {
+	list, err := Marshal(struct {
+		Description string    `clusprop:",omitempty"`
+		Timeout     uint32    `clusprop:",omitempty"`
+		Nodes       []string  `clusprop:",omitempty"`
+		Modified    time.Time `clusprop:",omitempty"`
+		Restart     uint32    `clusprop:"RestartAction,dword,omitempty"`
+	}{Restart: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, PropertyList{{Name: "RestartAction", Values: []Value{DWordValue(2)}}}, list)
+}
@@ -0,0 +1,125 @@
+// Package clusprop encodes and decodes the CLUSPROP property lists and
+// value lists used as the input and output buffers of cluster control codes.
+// It is pure byte manipulation and does not call into clusapi.dll
+package clusprop
+
+import "fmt"
+
+// Format is CLUSTER_PROPERTY_FORMAT, the low word of a Syntax
+type Format uint16
+
+const (
+	CLUSPROP_FORMAT_UNKNOWN             Format = 0
+	CLUSPROP_FORMAT_BINARY              Format = 1
+	CLUSPROP_FORMAT_DWORD               Format = 2
+	CLUSPROP_FORMAT_SZ                  Format = 3
+	CLUSPROP_FORMAT_EXPAND_SZ           Format = 4
+	CLUSPROP_FORMAT_MULTI_SZ            Format = 5
+	CLUSPROP_FORMAT_ULARGE_INTEGER      Format = 6
+	CLUSPROP_FORMAT_LONG                Format = 7
+	CLUSPROP_FORMAT_EXPANDED_SZ         Format = 8
+	CLUSPROP_FORMAT_SECURITY_DESCRIPTOR Format = 9
+	CLUSPROP_FORMAT_LARGE_INTEGER       Format = 10
+	CLUSPROP_FORMAT_WORD                Format = 11
+	CLUSPROP_FORMAT_FILETIME            Format = 12
+	CLUSPROP_FORMAT_VALUE_LIST          Format = 13
+	CLUSPROP_FORMAT_PROPERTY_LIST       Format = 14
+	CLUSPROP_FORMAT_USER                Format = 0x8000
+)
+
+// Type is CLUSTER_PROPERTY_TYPE, the high word of a Syntax
+type Type uint16
+
+const (
+	CLUSPROP_TYPE_UNKNOWN           Type = 0xffff
+	CLUSPROP_TYPE_ENDMARK           Type = 0
+	CLUSPROP_TYPE_LIST_VALUE        Type = 1
+	CLUSPROP_TYPE_RESCLASS          Type = 2
+	CLUSPROP_TYPE_RESERVED1         Type = 3
+	CLUSPROP_TYPE_NAME              Type = 4
+	CLUSPROP_TYPE_SIGNATURE         Type = 5
+	CLUSPROP_TYPE_SCSI_ADDRESS      Type = 6
+	CLUSPROP_TYPE_DISK_NUMBER       Type = 7
+	CLUSPROP_TYPE_PARTITION_INFO    Type = 8
+	CLUSPROP_TYPE_FTSET_INFO        Type = 9
+	CLUSPROP_TYPE_DISK_SERIALNUMBER Type = 10
+	CLUSPROP_TYPE_DISK_GUID         Type = 11
+	CLUSPROP_TYPE_DISK_SIZE         Type = 12
+	CLUSPROP_TYPE_PARTITION_INFO_EX Type = 13
+	CLUSPROP_TYPE_USER              Type = 0x8000
+)
+
+// Syntax is CLUSPROP_SYNTAX, the format in the low word and the type in the high word
+type Syntax uint32
+
+// MakeSyntax combines format and type
+func MakeSyntax(format Format, typ Type) Syntax {
+	return Syntax(uint32(typ)<<16 | uint32(format))
+}
+
+const (
+	CLUSPROP_SYNTAX_ENDMARK                        Syntax = 0
+	CLUSPROP_SYNTAX_NAME                           Syntax = 0x00040003
+	CLUSPROP_SYNTAX_RESCLASS                       Syntax = 0x00020002
+	CLUSPROP_SYNTAX_LIST_VALUE_SZ                  Syntax = 0x00010003
+	CLUSPROP_SYNTAX_LIST_VALUE_EXPAND_SZ           Syntax = 0x00010004
+	CLUSPROP_SYNTAX_LIST_VALUE_DWORD               Syntax = 0x00010002
+	CLUSPROP_SYNTAX_LIST_VALUE_BINARY              Syntax = 0x00010001
+	CLUSPROP_SYNTAX_LIST_VALUE_MULTI_SZ            Syntax = 0x00010005
+	CLUSPROP_SYNTAX_LIST_VALUE_LONG                Syntax = 0x00010007
+	CLUSPROP_SYNTAX_LIST_VALUE_EXPANDED_SZ         Syntax = 0x00010008
+	CLUSPROP_SYNTAX_LIST_VALUE_SECURITY_DESCRIPTOR Syntax = 0x00010009
+	CLUSPROP_SYNTAX_LIST_VALUE_LARGE_INTEGER       Syntax = 0x0001000a
+	CLUSPROP_SYNTAX_LIST_VALUE_ULARGE_INTEGER      Syntax = 0x00010006
+	CLUSPROP_SYNTAX_LIST_VALUE_WORD                Syntax = 0x0001000b
+	CLUSPROP_SYNTAX_LIST_VALUE_FILETIME            Syntax = 0x0001000c
+	CLUSPROP_SYNTAX_LIST_VALUE_PROPERTY_LIST       Syntax = 0x0001000e
+	CLUSPROP_SYNTAX_DISK_SIGNATURE                 Syntax = 0x00050002
+	CLUSPROP_SYNTAX_SCSI_ADDRESS                   Syntax = 0x00060002
+	CLUSPROP_SYNTAX_DISK_NUMBER                    Syntax = 0x00070002
+	CLUSPROP_SYNTAX_PARTITION_INFO                 Syntax = 0x00080001
+	CLUSPROP_SYNTAX_DISK_SERIALNUMBER              Syntax = 0x000a0003
+	CLUSPROP_SYNTAX_DISK_GUID                      Syntax = 0x000b0003
+	CLUSPROP_SYNTAX_DISK_SIZE                      Syntax = 0x000c0006
+	CLUSPROP_SYNTAX_PARTITION_INFO_EX              Syntax = 0x000d0001
+)
+
+// Format returns the low word
+func (syntax Syntax) Format() Format {
+	return Format(syntax & 0xffff)
+}
+
+// Type returns the high word
+func (syntax Syntax) Type() Type {
+	return Type(syntax >> 16)
+}
+
+var formatNames = map[Format]string{
+	CLUSPROP_FORMAT_UNKNOWN:             "UNKNOWN",
+	CLUSPROP_FORMAT_BINARY:              "BINARY",
+	CLUSPROP_FORMAT_DWORD:               "DWORD",
+	CLUSPROP_FORMAT_SZ:                  "SZ",
+	CLUSPROP_FORMAT_EXPAND_SZ:           "EXPAND_SZ",
+	CLUSPROP_FORMAT_MULTI_SZ:            "MULTI_SZ",
+	CLUSPROP_FORMAT_ULARGE_INTEGER:      "ULARGE_INTEGER",
+	CLUSPROP_FORMAT_LONG:                "LONG",
+	CLUSPROP_FORMAT_EXPANDED_SZ:         "EXPANDED_SZ",
+	CLUSPROP_FORMAT_SECURITY_DESCRIPTOR: "SECURITY_DESCRIPTOR",
+	CLUSPROP_FORMAT_LARGE_INTEGER:       "LARGE_INTEGER",
+	CLUSPROP_FORMAT_WORD:                "WORD",
+	CLUSPROP_FORMAT_FILETIME:            "FILETIME",
+	CLUSPROP_FORMAT_VALUE_LIST:          "VALUE_LIST",
+	CLUSPROP_FORMAT_PROPERTY_LIST:       "PROPERTY_LIST",
+	CLUSPROP_FORMAT_USER:                "USER",
+}
+
+func (format Format) String() string {
+	if name, ok := formatNames[format]; ok {
+		return "CLUSPROP_FORMAT_" + name
+	}
+	return fmt.Sprintf("Format(%d)", uint16(format))
+}
+
+func (syntax Syntax) String() string {
+	return fmt.Sprintf("CLUSPROP_SYNTAX(type %d, %s)", uint16(syntax.Type()), syntax.Format())
+}
@@ -0,0 +1,195 @@
+package clusprop
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+)
+
+// Value is a CLUSPROP_VALUE, Data is the value without the alignment padding
+type Value struct {
+	Syntax Syntax
+	Data   []byte
+}
+
+// filetimeEpochDelta is the number of 100ns intervals between 1601 and 1970
+const filetimeEpochDelta = 116444736000000000
+
+// DWordValue returns a CLUSPROP_SYNTAX_LIST_VALUE_DWORD value
+func DWordValue(v uint32) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_DWORD, util.Uint32ToByte(v)}
+}
+
+// LongValue returns a CLUSPROP_SYNTAX_LIST_VALUE_LONG value
+func LongValue(v int32) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_LONG, util.Uint32ToByte(uint32(v))}
+}
+
+// WordValue returns a CLUSPROP_SYNTAX_LIST_VALUE_WORD value
+func WordValue(v uint16) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_WORD, []byte{byte(v), byte(v >> 8)}}
+}
+
+// ULargeIntegerValue returns a CLUSPROP_SYNTAX_LIST_VALUE_ULARGE_INTEGER value
+func ULargeIntegerValue(v uint64) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_ULARGE_INTEGER, util.Uint64ToByte(v)}
+}
+
+// LargeIntegerValue returns a CLUSPROP_SYNTAX_LIST_VALUE_LARGE_INTEGER value
+func LargeIntegerValue(v int64) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_LARGE_INTEGER, util.Uint64ToByte(uint64(v))}
+}
+
+// StringValue returns a CLUSPROP_SYNTAX_LIST_VALUE_SZ value
+func StringValue(v string) (Value, error) {
+	data, err := util.StringToUTF16Bytes(v)
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_SZ, data}, err
+}
+
+// ExpandStringValue returns a CLUSPROP_SYNTAX_LIST_VALUE_EXPAND_SZ value
+func ExpandStringValue(v string) (Value, error) {
+	data, err := util.StringToUTF16Bytes(v)
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_EXPAND_SZ, data}, err
+}
+
+// MultiStringValue returns a CLUSPROP_SYNTAX_LIST_VALUE_MULTI_SZ value
+func MultiStringValue(v []string) (Value, error) {
+	data, err := util.StringsToUTF16Bytes(v)
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_MULTI_SZ, data}, err
+}
+
+// BinaryValue returns a CLUSPROP_SYNTAX_LIST_VALUE_BINARY value
+func BinaryValue(v []byte) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_BINARY, append([]byte(nil), v...)}
+}
+
+// SecurityDescriptorValue returns a CLUSPROP_SYNTAX_LIST_VALUE_SECURITY_DESCRIPTOR
+// value from a self relative security descriptor
+func SecurityDescriptorValue(v []byte) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_SECURITY_DESCRIPTOR, append([]byte(nil), v...)}
+}
+
+// FileTimeValue returns a CLUSPROP_SYNTAX_LIST_VALUE_FILETIME value
+func FileTimeValue(v time.Time) Value {
+	return Value{CLUSPROP_SYNTAX_LIST_VALUE_FILETIME, util.Uint64ToByte(uint64(v.UnixNano()/100 + filetimeEpochDelta))}
+}
+
+// checkFormat checks the value has one of formats and a valid length
+func (value Value) checkFormat(formats ...Format) error {
+	for _, format := range formats {
+		if value.Syntax.Format() == format {
+			return value.validate()
+		}
+	}
+	return fmt.Errorf("clusprop value is %s not %s: %w", value.Syntax.Format(), formats[0], errors.ERROR_INVALID_DATA)
+}
+
+// DWord returns a DWORD value
+func (value Value) DWord() (uint32, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_DWORD); err != nil {
+		return 0, err
+	}
+	return util.ByteToUint32(value.Data), nil
+}
+
+// Long returns a LONG value
+func (value Value) Long() (int32, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_LONG); err != nil {
+		return 0, err
+	}
+	return int32(util.ByteToUint32(value.Data)), nil
+}
+
+// Word returns a WORD value
+func (value Value) Word() (uint16, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_WORD); err != nil {
+		return 0, err
+	}
+	return uint16(value.Data[0]) | uint16(value.Data[1])<<8, nil
+}
+
+// ULargeInteger returns a ULARGE_INTEGER value
+func (value Value) ULargeInteger() (uint64, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_ULARGE_INTEGER); err != nil {
+		return 0, err
+	}
+	return util.ByteToUint64(value.Data), nil
+}
+
+// LargeInteger returns a LARGE_INTEGER value
+func (value Value) LargeInteger() (int64, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_LARGE_INTEGER); err != nil {
+		return 0, err
+	}
+	return int64(util.ByteToUint64(value.Data)), nil
+}
+
+// Str returns a SZ, EXPAND_SZ or EXPANDED_SZ value
+func (value Value) Str() (string, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_SZ, CLUSPROP_FORMAT_EXPAND_SZ, CLUSPROP_FORMAT_EXPANDED_SZ); err != nil {
+		return "", err
+	}
+	return util.UTF16BytesToString(value.Data), nil
+}
+
+// Strings returns a MULTI_SZ value
+func (value Value) Strings() ([]string, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_MULTI_SZ); err != nil {
+		return nil, err
+	}
+	return util.UTF16BytesToStrings(value.Data), nil
+}
+
+// Binary returns a BINARY or SECURITY_DESCRIPTOR value
+func (value Value) Binary() ([]byte, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_BINARY, CLUSPROP_FORMAT_SECURITY_DESCRIPTOR); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), value.Data...), nil
+}
+
+// FileTime returns a FILETIME value
+func (value Value) FileTime() (time.Time, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_FILETIME); err != nil {
+		return time.Time{}, err
+	}
+	filetime := int64(util.ByteToUint64(value.Data))
+	return time.Unix(0, (filetime-filetimeEpochDelta)*100), nil
+}
+
+// PropertyList returns a nested PROPERTY_LIST value
+func (value Value) PropertyList() (PropertyList, error) {
+	if err := value.checkFormat(CLUSPROP_FORMAT_PROPERTY_LIST); err != nil {
+		return nil, err
+	}
+	return ParsePropertyList(value.Data)
+}
+
+// validate checks that the data length matches the format,
+// strings must be null terminated
+func (value Value) validate() error {
+	length := len(value.Data)
+	valid := true
+	switch value.Syntax.Format() {
+	case CLUSPROP_FORMAT_DWORD, CLUSPROP_FORMAT_LONG:
+		valid = length == 4
+	case CLUSPROP_FORMAT_WORD:
+		valid = length == 2
+	case CLUSPROP_FORMAT_ULARGE_INTEGER, CLUSPROP_FORMAT_LARGE_INTEGER, CLUSPROP_FORMAT_FILETIME:
+		valid = length == 8
+	case CLUSPROP_FORMAT_SZ, CLUSPROP_FORMAT_EXPAND_SZ, CLUSPROP_FORMAT_EXPANDED_SZ:
+		valid = length >= 2 && length%2 == 0 && value.Data[length-1] == 0 && value.Data[length-2] == 0
+	case CLUSPROP_FORMAT_MULTI_SZ:
+		valid = length >= 2 && length%2 == 0 && value.Data[length-1] == 0 && value.Data[length-2] == 0
+		if length > 2 {
+			// a non empty list ends with the terminator of the last string and the list terminator
+			valid = valid && length >= 4 && value.Data[length-3] == 0 && value.Data[length-4] == 0
+		}
+	}
+	if !valid {
+		return fmt.Errorf("clusprop %s value of length %d is malformed: %w", value.Syntax.Format(), length, errors.ERROR_INVALID_DATA)
+	}
+	return nil
+}
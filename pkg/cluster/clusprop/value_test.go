@@ -0,0 +1,67 @@
+package clusprop
+
+import (
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntax(t *testing.T) {
+	assert.Equal(t, CLUSPROP_SYNTAX_NAME, MakeSyntax(CLUSPROP_FORMAT_SZ, CLUSPROP_TYPE_NAME))
+	assert.Equal(t, CLUSPROP_SYNTAX_LIST_VALUE_FILETIME, MakeSyntax(CLUSPROP_FORMAT_FILETIME, CLUSPROP_TYPE_LIST_VALUE))
+	assert.Equal(t, CLUSPROP_FORMAT_ULARGE_INTEGER, CLUSPROP_SYNTAX_DISK_SIZE.Format())
+	assert.Equal(t, CLUSPROP_TYPE_DISK_SIZE, CLUSPROP_SYNTAX_DISK_SIZE.Type())
+	assert.Equal(t, "CLUSPROP_SYNTAX(type 1, CLUSPROP_FORMAT_DWORD)", CLUSPROP_SYNTAX_LIST_VALUE_DWORD.String())
+}
+
+func TestValueAccessors(t *testing.T) {
+	v, err := DWordValue(5).DWord()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(5), v)
+
+	l, err := LongValue(-1).Long()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(-1), l)
+
+	w, err := WordValue(0x1234).Word()
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(0x1234), w)
+
+	li, err := LargeIntegerValue(-2).LargeInteger()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), li)
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 600, time.UTC)
+	ft, err := FileTimeValue(now).FileTime()
+	assert.Nil(t, err)
+	assert.True(t, now.Equal(ft))
+
+	sd, err := SecurityDescriptorValue([]byte{1, 0, 4, 0x80}).Binary()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 0, 4, 0x80}, sd)
+
+	s, err := Value{CLUSPROP_SYNTAX_LIST_VALUE_EXPANDED_SZ, []byte{'a', 0, 0, 0}}.Str()
+	assert.Nil(t, err)
+	assert.Equal(t, "a", s)
+
+	_, err = StringValue("a\x00b")
+	assert.NotNil(t, err)
+}
+
+func TestValueInvalid(t *testing.T) {
+	_, err := DWordValue(5).Str()
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+	_, err = Value{CLUSPROP_SYNTAX_LIST_VALUE_DWORD, []byte{1, 2}}.DWord()
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+	_, err = Value{CLUSPROP_SYNTAX_LIST_VALUE_SZ, []byte{'a', 0}}.Str()
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+	_, err = Value{CLUSPROP_SYNTAX_LIST_VALUE_MULTI_SZ, []byte{'a', 0, 0, 0}}.Strings()
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+
+	empty, err := Value{CLUSPROP_SYNTAX_LIST_VALUE_MULTI_SZ, []byte{0, 0}}.Strings()
+	assert.Nil(t, err)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import "github.com/KnicKnic/go-windows/pkg/errors"
+
+func OpenCluster() (handle ClusterHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func OpenRemoteCluster(clusterName string) (handle ClusterHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle ClusterHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle ClusterHandle) Information() (clusterName string, info ClusterVersionInfo, err error) {
+	return "", ClusterVersionInfo{}, errors.ErrNotSupported
+}
+
+func (handle ClusterHandle) Name() (string, error) {
+	return "", errors.ErrNotSupported
+}
+
+func (handle ClusterHandle) QuorumResource() (ClusterQuorum, error) {
+	return ClusterQuorum{}, errors.ErrNotSupported
+}
+
+func (handle ClusterHandle) SetQuorumResource(resourceName string, deviceName string, maxLogSize uint32) error {
+	return errors.ErrNotSupported
+}
@@ -0,0 +1,161 @@
+package cluster
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procnativeOpenCluster  = clusapi_dll.NewProc("OpenCluster")
+	procnativeCloseCluster = clusapi_dll.NewProc("CloseCluster")
+
+	procnativeGetClusterInformation    = clusapi_dll.NewProc("GetClusterInformation")
+	procnativeGetClusterQuorumResource = clusapi_dll.NewProc("GetClusterQuorumResource")
+	procnativeSetClusterQuorumResource = clusapi_dll.NewProc("SetClusterQuorumResource")
+)
+
+func openCluster() (ClusterHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeOpenCluster.Addr(), 1, uintptr(0), 0, 0)
+	handle := ClusterHandle(r0)
+	return handle, handles.opened(clusterHandleKind, r0, errors.NotNill(r0, lastError))
+}
+func openRemoteCluster(clusterName *uint16) (ClusterHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeOpenCluster.Addr(), 1, uintptr(unsafe.Pointer(clusterName)), 0, 0)
+	handle := ClusterHandle(r0)
+	return handle, handles.opened(clusterHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func OpenCluster() (handle ClusterHandle, err error) {
+	handle, err = openCluster()
+	err = errors.Wrap(procnativeOpenCluster.Name, "", err)
+	return
+}
+func OpenRemoteCluster(clusterName string) (handle ClusterHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeOpenCluster.Name, clusterName, err) }()
+	cn, err := windows.UTF16PtrFromString(clusterName)
+	if err != nil {
+		return
+	}
+	handle, err = openRemoteCluster(cn)
+	return
+}
+
+func closeCluster(handle ClusterHandle) error {
+	r0, _, lastError := syscall.Syscall(procnativeCloseCluster.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotNill(r0, lastError)
+}
+
+// Close closes the handle, wrap it with Safe to close it at most once
+func (handle ClusterHandle) Close() error {
+	err := handles.closed(clusterHandleKind, uintptr(handle), func() error { return closeCluster(handle) })
+	return errors.Wrap(procnativeCloseCluster.Name, "", err)
+}
+
+func getClusterInformation(handle ClusterHandle) (clusterName string, info ClusterVersionInfo, err error) {
+	nameCCh := uint32(50)
+
+	var nameArr []uint16
+	var versionInfo []uint32
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nameCCh += 2
+		nameArr = make([]uint16, nameCCh)
+		versionInfo = newClusterVersionInfoBuffer()
+		r0, _, _ := syscall.Syscall6(procnativeGetClusterInformation.Addr(),
+			4,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&nameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			uintptr(unsafe.Pointer(&versionInfo[0])),
+			0,
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	clusterName = syscall.UTF16ToString(nameArr)
+	info, err = parseClusterVersionInfo((*[clusterVersionInfoSize]byte)(unsafe.Pointer(&versionInfo[0]))[:])
+	return
+}
+
+// Information returns the name of the cluster and the versions
+// of the cluster and of the node that answered
+func (handle ClusterHandle) Information() (clusterName string, info ClusterVersionInfo, err error) {
+	clusterName, info, err = getClusterInformation(handle)
+	err = errors.Wrap(procnativeGetClusterInformation.Name, "", err)
+	return
+}
+
+// Name returns the name of the cluster
+func (handle ClusterHandle) Name() (string, error) {
+	clusterName, _, err := handle.Information()
+	return clusterName, err
+}
+
+func getClusterQuorumResource(handle ClusterHandle) (quorum ClusterQuorum, err error) {
+	resourceCCh := uint32(50)
+	deviceCCh := uint32(50)
+
+	var resourceArr []uint16
+	var deviceArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		resourceCCh += 2
+		deviceCCh += 2
+		resourceArr = make([]uint16, resourceCCh)
+		deviceArr = make([]uint16, deviceCCh)
+		r0, _, _ := syscall.Syscall6(procnativeGetClusterQuorumResource.Addr(),
+			6,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&resourceArr[0])),
+			uintptr(unsafe.Pointer(&resourceCCh)),
+			uintptr(unsafe.Pointer(&deviceArr[0])),
+			uintptr(unsafe.Pointer(&deviceCCh)),
+			uintptr(unsafe.Pointer(&quorum.MaxLogSize)))
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	quorum.ResourceName = syscall.UTF16ToString(resourceArr)
+	quorum.DeviceName = syscall.UTF16ToString(deviceArr)
+	return
+}
+
+// QuorumResource returns the quorum configuration of the cluster
+func (handle ClusterHandle) QuorumResource() (ClusterQuorum, error) {
+	quorum, err := getClusterQuorumResource(handle)
+	return quorum, errors.Wrap(procnativeGetClusterQuorumResource.Name, "", err)
+}
+
+func setClusterQuorumResource(resource ResourceHandle, deviceName *uint16, maxLogSize uint32) error {
+	r0, _, _ := syscall.Syscall(procnativeSetClusterQuorumResource.Addr(), 3, uintptr(resource), uintptr(unsafe.Pointer(deviceName)), uintptr(maxLogSize))
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// SetQuorumResource makes resourceName the quorum resource of the cluster with the quorum
+// log at deviceName, an empty deviceName lets the cluster pick the default path
+func (handle ClusterHandle) SetQuorumResource(resourceName string, deviceName string, maxLogSize uint32) error {
+	resource, err := handle.OpenResource(resourceName)
+	if err != nil {
+		return err
+	}
+	defer resource.Close()
+
+	var dn *uint16
+	if deviceName != "" {
+		dn, err = windows.UTF16PtrFromString(deviceName)
+	}
+	if err == nil {
+		err = setClusterQuorumResource(resource, dn, maxLogSize)
+	}
+	return errors.Wrap(procnativeSetClusterQuorumResource.Name, resourceName, err)
+}
@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	zeroUintptr      uintptr = 0
+	validClusterName string  = "localhost"
+)
+
+func TestOpenCluster(t *testing.T) {
+	handle, err := OpenCluster()
+	defer handle.Close()
+
+	if err != nil {
+		t.Log(err.Error())
+	}
+
+	assert.NotZero(t, handle, "handle should not be zero")
+	assert.Nil(t, err, "error should be null")
+}
+
+func TestSafeClusterHandle(t *testing.T) {
+	handle, err := OpenCluster()
+	if !assert.Nil(t, err, "error should be null") {
+		return
+	}
+	cluster := handle.Safe()
+
+	handle, err = cluster.Handle()
+	assert.Nil(t, err, "error should be null")
+	_, err = handle.QuorumResource()
+	assert.Nil(t, err, "error should be null")
+
+	assert.Nil(t, cluster.Close(), "successful close should not return an error")
+	assert.True(t, errors.Is(cluster.Close(), errors.ERROR_INVALID_HANDLE))
+	_, err = cluster.Handle()
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_HANDLE))
+}
+
+func TestOpenRemoteCluster(t *testing.T) {
+	handle, err := OpenRemoteCluster(validClusterName)
+	defer handle.Close()
+
+	if err != nil {
+		t.Log(err.Error())
+	}
+
+	assert.NotZero(t, handle, "handle should not be zero")
+	assert.Nil(t, err, "error should be null")
+}
+func TestOpenRemoteCluster_2(t *testing.T) {
+	handle, err := OpenRemoteCluster(validClusterName)
+	defer handle.Close()
+	if err != nil {
+		t.Log(err.Error())
+	}
+
+	assert.NotZero(t, handle, "handle should not be zero")
+	assert.Nil(t, err, "error should be null")
+}
+
+func TestEnumerateResources(t *testing.T) {
+	handle, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer handle.Close()
+
+	it, err := handle.Resources()
+	assert.Nil(t, err, "error should be null")
+	resources, err := it.All()
+	assert.Nil(t, err, "error should be null")
+
+	var names []string
+	for _, resource := range resources {
+		assert.Equal(t, CLUSTER_ENUM_RESOURCE, resource.Type)
+		assert.NotEmpty(t, resource.ID)
+		names = append(names, resource.Name)
+	}
+	assert.Contains(t, names, "r1")
+
+	it, err = handle.Nodes()
+	assert.Nil(t, err, "error should be null")
+	nodes, err := it.All()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEmpty(t, nodes)
+}
+
+func TestOpenEnum(t *testing.T) {
+	handle, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer handle.Close()
+
+	enum, err := handle.OpenEnum(CLUSTER_ENUM_GROUP | CLUSTER_ENUM_RESOURCE)
+	assert.Nil(t, err, "error should be null")
+	defer enum.Close()
+
+	count := enum.Count()
+	assert.NotZero(t, count)
+	for index := uint32(0); index < count; index++ {
+		item, err := enum.Enum(index)
+		assert.Nil(t, err, "error should be null")
+		assert.NotEmpty(t, item.Name)
+	}
+	_, err = enum.Enum(count)
+	assert.Equal(t, ERROR_NO_MORE_ITEMS, err)
+}
+
+func TestClusterInformation(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	name, info, err := cluster.Information()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEmpty(t, name)
+	assert.NotZero(t, info.MajorVersion)
+	assert.NotZero(t, info.BuildNumber)
+	assert.True(t, info.ClusterLowestVersion <= info.ClusterHighestVersion)
+
+	clusterName, err := cluster.Name()
+	assert.Nil(t, err, "error should be null")
+	assert.Equal(t, name, clusterName)
+}
+
+func TestClusterQuorumResource(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	_, err = cluster.QuorumResource()
+	assert.Nil(t, err, "error should be null")
+}
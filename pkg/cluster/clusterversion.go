@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+)
+
+// ClusterVersion is a cluster version made of a major version
+// in the high word and a minor version in the low word
+type ClusterVersion uint32
+
+// CLUSTER_VERSION_UNKNOWN is reported by clusters too old to have a cluster version
+const CLUSTER_VERSION_UNKNOWN ClusterVersion = 0xffffffff
+
+// CLUSTERVERSIONINFO flags
+const (
+	CLUSTER_VERSION_FLAG_MIXED_MODE uint32 = 0x00000001
+)
+
+// MakeClusterVersion is CLUSTER_MAKE_VERSION
+func MakeClusterVersion(major uint16, minor uint16) ClusterVersion {
+	return ClusterVersion(uint32(major)<<16 | uint32(minor))
+}
+
+// Major is CLUSTER_GET_MAJOR_VERSION
+func (version ClusterVersion) Major() uint16 {
+	return uint16(version >> 16)
+}
+
+// Minor is CLUSTER_GET_MINOR_VERSION
+func (version ClusterVersion) Minor() uint16 {
+	return uint16(version)
+}
+
+func (version ClusterVersion) String() string {
+	if version == CLUSTER_VERSION_UNKNOWN {
+		return "CLUSTER_VERSION_UNKNOWN"
+	}
+	return fmt.Sprintf("%d.%d", version.Major(), version.Minor())
+}
+
+// ClusterVersionInfo is a decoded CLUSTERVERSIONINFO, MajorVersion, MinorVersion
+// and BuildNumber are the operating system version of the node that answered
+type ClusterVersionInfo struct {
+	MajorVersion          uint16
+	MinorVersion          uint16
+	BuildNumber           uint16
+	VendorID              string
+	CSDVersion            string
+	ClusterHighestVersion ClusterVersion
+	ClusterLowestVersion  ClusterVersion
+	Flags                 uint32
+}
+
+// MixedMode is true while the nodes of the cluster run different versions,
+// such as during a rolling upgrade
+func (info ClusterVersionInfo) MixedMode() bool {
+	return info.Flags&CLUSTER_VERSION_FLAG_MIXED_MODE != 0
+}
+
+func (info ClusterVersionInfo) String() string {
+	return fmt.Sprintf("%d.%d.%d %s cluster %s-%s", info.MajorVersion, info.MinorVersion, info.BuildNumber,
+		info.CSDVersion, info.ClusterLowestVersion, info.ClusterHighestVersion)
+}
+
+// CLUSTERVERSIONINFO layout, the WCHAR arrays are 64 characters
+const (
+	clusterVersionInfoMajorOffset   = 4
+	clusterVersionInfoMinorOffset   = 6
+	clusterVersionInfoBuildOffset   = 8
+	clusterVersionInfoVendorOffset  = 10
+	clusterVersionInfoCSDOffset     = 138
+	clusterVersionInfoHighestOffset = 268
+	clusterVersionInfoLowestOffset  = 272
+	clusterVersionInfoFlagsOffset   = 276
+	clusterVersionInfoSize          = 284
+)
+
+func uint16At(data []byte, offset int) uint16 {
+	return uint16(data[offset]) | uint16(data[offset+1])<<8
+}
+
+// newClusterVersionInfoBuffer returns a CLUSTERVERSIONINFO buffer with dwVersionInfoSize set,
+// it is a []uint32 so the buffer is aligned for the call
+func newClusterVersionInfoBuffer() []uint32 {
+	buffer := make([]uint32, clusterVersionInfoSize/4)
+	buffer[0] = clusterVersionInfoSize
+	return buffer
+}
+
+// parseClusterVersionInfo decodes a CLUSTERVERSIONINFO
+func parseClusterVersionInfo(data []byte) (info ClusterVersionInfo, err error) {
+	if len(data) < clusterVersionInfoSize {
+		err = fmt.Errorf("CLUSTERVERSIONINFO of %d bytes: %w", len(data), errors.ERROR_INVALID_DATA)
+		return
+	}
+	info.MajorVersion = uint16At(data, clusterVersionInfoMajorOffset)
+	info.MinorVersion = uint16At(data, clusterVersionInfoMinorOffset)
+	info.BuildNumber = uint16At(data, clusterVersionInfoBuildOffset)
+	info.VendorID = util.UTF16BytesToString(data[clusterVersionInfoVendorOffset:clusterVersionInfoCSDOffset])
+	info.CSDVersion = util.UTF16BytesToString(data[clusterVersionInfoCSDOffset : clusterVersionInfoCSDOffset+128])
+	info.ClusterHighestVersion = ClusterVersion(util.ByteToUint32(data[clusterVersionInfoHighestOffset:]))
+	info.ClusterLowestVersion = ClusterVersion(util.ByteToUint32(data[clusterVersionInfoLowestOffset:]))
+	info.Flags = util.ByteToUint32(data[clusterVersionInfoFlagsOffset:])
+	return
+}
+
+// ClusterQuorum is the quorum configuration returned by GetClusterQuorumResource
+type ClusterQuorum struct {
+	// ResourceName is the quorum resource, empty when the cluster has no witness
+	ResourceName string
+	// DeviceName is the path of the quorum log on the resource
+	DeviceName string
+	// MaxLogSize is the maximum size of the quorum log in bytes
+	MaxLogSize uint32
+}
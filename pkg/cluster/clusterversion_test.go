@@ -0,0 +1,56 @@
+package cluster
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterVersion(t *testing.T) {
+	version := MakeClusterVersion(10, 3)
+	assert.Equal(t, ClusterVersion(0x000a0003), version)
+	assert.Equal(t, uint16(10), version.Major())
+	assert.Equal(t, uint16(3), version.Minor())
+	assert.Equal(t, "10.3", version.String())
+	assert.Equal(t, "CLUSTER_VERSION_UNKNOWN", CLUSTER_VERSION_UNKNOWN.String())
+}
+
+func TestParseClusterVersionInfo(t *testing.T) {
+	data := make([]byte, clusterVersionInfoSize)
+	copy(data, util.Uint32ToByte(clusterVersionInfoSize))
+	copy(data[clusterVersionInfoMajorOffset:], []byte{10, 0, 0, 0, 0x63, 0x45})
+	vendor, _ := util.StringToUTF16Bytes("Microsoft(R) Cluster Service")
+	copy(data[clusterVersionInfoVendorOffset:], vendor)
+	csd, _ := util.StringToUTF16Bytes("Service Pack 1")
+	copy(data[clusterVersionInfoCSDOffset:], csd)
+	copy(data[clusterVersionInfoHighestOffset:], util.Uint32ToByte(0x000b0000))
+	copy(data[clusterVersionInfoLowestOffset:], util.Uint32ToByte(0x000a0002))
+	copy(data[clusterVersionInfoFlagsOffset:], util.Uint32ToByte(CLUSTER_VERSION_FLAG_MIXED_MODE))
+
+	info, err := parseClusterVersionInfo(data)
+	assert.Nil(t, err)
+	assert.Equal(t, ClusterVersionInfo{
+		MajorVersion:          10,
+		MinorVersion:          0,
+		BuildNumber:           17763,
+		VendorID:              "Microsoft(R) Cluster Service",
+		CSDVersion:            "Service Pack 1",
+		ClusterHighestVersion: MakeClusterVersion(11, 0),
+		ClusterLowestVersion:  MakeClusterVersion(10, 2),
+		Flags:                 CLUSTER_VERSION_FLAG_MIXED_MODE,
+	}, info)
+	assert.True(t, info.MixedMode())
+	assert.Equal(t, "10.0.17763 Service Pack 1 cluster 10.2-11.0", info.String())
+
+	_, err = parseClusterVersionInfo(data[:clusterVersionInfoSize-1])
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA))
+}
+
+func TestClusterVersionInfoBuffer(t *testing.T) {
+	buffer := newClusterVersionInfoBuffer()
+	assert.Equal(t, clusterVersionInfoSize, len(buffer)*4)
+	assert.Equal(t, uint32(clusterVersionInfoSize), buffer[0])
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import "github.com/KnicKnic/go-windows/pkg/errors"
+
+func (cluster ClusterHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle NetworkHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle NetInterfaceHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) ResourceTypeControl(resourceType string, hostNode NodeHandle, code ControlCode, in []byte) (out []byte, err error) {
+	return nil, errors.ErrNotSupported
+}
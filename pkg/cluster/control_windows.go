@@ -0,0 +1,122 @@
+package cluster
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procnativeClusterControl             = clusapi_dll.NewProc("ClusterControl")
+	procnativeClusterResourceControl     = clusapi_dll.NewProc("ClusterResourceControl")
+	procnativeClusterResourceTypeControl = clusapi_dll.NewProc("ClusterResourceTypeControl")
+	procnativeClusterGroupControl        = clusapi_dll.NewProc("ClusterGroupControl")
+	procnativeClusterNodeControl         = clusapi_dll.NewProc("ClusterNodeControl")
+	procnativeClusterNetworkControl      = clusapi_dll.NewProc("ClusterNetworkControl")
+	procnativeClusterNetInterfaceControl = clusapi_dll.NewProc("ClusterNetInterfaceControl")
+)
+
+// controlOutBufferSize is the first output buffer size, it grows on ERROR_MORE_DATA
+const controlOutBufferSize = 512
+
+func inBufferPtr(in []byte) uintptr {
+	if len(in) == 0 {
+		return 0
+	}
+	return uintptr(unsafe.Pointer(&in[0]))
+}
+
+// objectControl calls one of the Cluster<Object>Control functions, they
+// all take the object handle and the host node followed by the same arguments.
+// code is checked against object first
+func objectControl(proc *windows.LazyProc, object ControlObject, handle uintptr, hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	code, err := controlCodeFor(code, object)
+	if err != nil {
+		return nil, errors.Wrap(proc.Name, "", err)
+	}
+	out, err := retryOutBuffer(controlOutBufferSize, func(out []byte, bytesReturned *uint32) syscall.Errno {
+		r0, _, _ := syscall.Syscall9(proc.Addr(),
+			8,
+			handle,
+			uintptr(hostNode),
+			uintptr(code),
+			inBufferPtr(in),
+			uintptr(len(in)),
+			uintptr(unsafe.Pointer(&out[0])),
+			uintptr(len(out)),
+			uintptr(unsafe.Pointer(bytesReturned)),
+			0)
+		return syscall.Errno(r0)
+	})
+	return out, errors.Wrap(proc.Name, "", err)
+}
+
+// Control sends code to the cluster and returns the output buffer, CLCTL_ codes
+// are targeted at CLUS_OBJECT_CLUSTER. Pass 0 for hostNode to let the cluster pick the node
+func (cluster ClusterHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return objectControl(procnativeClusterControl, CLUS_OBJECT_CLUSTER, uintptr(cluster), hostNode, code, in)
+}
+
+// Control sends code to the resource and returns the output buffer, CLCTL_ codes
+// are targeted at CLUS_OBJECT_RESOURCE. Pass 0 for hostNode to use the owner node
+func (handle ResourceHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return objectControl(procnativeClusterResourceControl, CLUS_OBJECT_RESOURCE, uintptr(handle), hostNode, code, in)
+}
+
+// Control sends code to the group and returns the output buffer, CLCTL_ codes
+// are targeted at CLUS_OBJECT_GROUP. Pass 0 for hostNode to use the owner node
+func (handle GroupHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return objectControl(procnativeClusterGroupControl, CLUS_OBJECT_GROUP, uintptr(handle), hostNode, code, in)
+}
+
+// Control sends code to the node and returns the output buffer, CLCTL_ codes
+// are targeted at CLUS_OBJECT_NODE. Pass 0 for hostNode to use the node itself
+func (handle NodeHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return objectControl(procnativeClusterNodeControl, CLUS_OBJECT_NODE, uintptr(handle), hostNode, code, in)
+}
+
+// Control sends code to the network and returns the output buffer, CLCTL_ codes
+// are targeted at CLUS_OBJECT_NETWORK. Pass 0 for hostNode to let the cluster pick the node
+func (handle NetworkHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return objectControl(procnativeClusterNetworkControl, CLUS_OBJECT_NETWORK, uintptr(handle), hostNode, code, in)
+}
+
+// Control sends code to the network interface and returns the output buffer, CLCTL_ codes
+// are targeted at CLUS_OBJECT_NETINTERFACE. Pass 0 for hostNode to let the cluster pick the node
+func (handle NetInterfaceHandle) Control(hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return objectControl(procnativeClusterNetInterfaceControl, CLUS_OBJECT_NETINTERFACE, uintptr(handle), hostNode, code, in)
+}
+
+func clusterResourceTypeControl(cluster ClusterHandle, resourceType *uint16, hostNode NodeHandle, code ControlCode, in []byte) ([]byte, error) {
+	return retryOutBuffer(controlOutBufferSize, func(out []byte, bytesReturned *uint32) syscall.Errno {
+		r0, _, _ := syscall.Syscall9(procnativeClusterResourceTypeControl.Addr(),
+			9,
+			uintptr(cluster),
+			uintptr(unsafe.Pointer(resourceType)),
+			uintptr(hostNode),
+			uintptr(code),
+			inBufferPtr(in),
+			uintptr(len(in)),
+			uintptr(unsafe.Pointer(&out[0])),
+			uintptr(len(out)),
+			uintptr(unsafe.Pointer(bytesReturned)))
+		return syscall.Errno(r0)
+	})
+}
+
+// ResourceTypeControl sends code to the resource type and returns the output buffer, CLCTL_ codes
+// are targeted at CLUS_OBJECT_RESOURCE_TYPE. Pass 0 for hostNode to let the cluster pick the node
+func (cluster ClusterHandle) ResourceTypeControl(resourceType string, hostNode NodeHandle, code ControlCode, in []byte) (out []byte, err error) {
+	defer func() { err = errors.Wrap(procnativeClusterResourceTypeControl.Name, resourceType, err) }()
+	code, err = controlCodeFor(code, CLUS_OBJECT_RESOURCE_TYPE)
+	if err != nil {
+		return
+	}
+	rt, err := windows.UTF16PtrFromString(resourceType)
+	if err != nil {
+		return
+	}
+	return clusterResourceTypeControl(cluster, rt, hostNode, code, in)
+}
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceControlGetName(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	resource, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	defer resource.Close()
+
+	out, err := resource.Control(0, CLCTL_GET_NAME, nil)
+	assert.Nil(t, err, "error should be null")
+	assert.Equal(t, validResourceName, util.UTF16BytesToString(out))
+}
+
+func TestClusterControlGetFQDN(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	out, err := cluster.Control(0, CLCTL_GET_FQDN, nil)
+	assert.Nil(t, err, "error should be null")
+	assert.NotEmpty(t, util.UTF16BytesToString(out))
+
+	_, err = cluster.Control(0, CLCTL_GET_FQDN.For(CLUS_OBJECT_RESOURCE), nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,174 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// ControlCode is a CLUSCTL control code. The bits are laid out as
+// access(0-1) function(2-19) internal(20) user(21) modify(22) global(23) object(24-31).
+// The CLCTL_ codes have no object, use For to target an object type
+type ControlCode uint32
+
+// ControlObject is CLUS_OBJECT_*, the object type a control code applies to
+type ControlObject uint8
+
+const (
+	CLUS_OBJECT_INVALID       ControlObject = 0
+	CLUS_OBJECT_RESOURCE      ControlObject = 1
+	CLUS_OBJECT_RESOURCE_TYPE ControlObject = 2
+	CLUS_OBJECT_GROUP         ControlObject = 3
+	CLUS_OBJECT_NODE          ControlObject = 4
+	CLUS_OBJECT_NETWORK       ControlObject = 5
+	CLUS_OBJECT_NETINTERFACE  ControlObject = 6
+	CLUS_OBJECT_CLUSTER       ControlObject = 7
+)
+
+// ControlAccess is CLUS_ACCESS_*, the access a control code needs
+type ControlAccess uint8
+
+const (
+	CLUS_ACCESS_ANY   ControlAccess = 0
+	CLUS_ACCESS_READ  ControlAccess = 1
+	CLUS_ACCESS_WRITE ControlAccess = 2
+)
+
+const (
+	clusctlAccessMask    = 0x3
+	clusctlFunctionShift = 2
+	clusctlFunctionMask  = 0x3ffff
+	clctlInternalMask    = 1 << 20
+	clctlUserMask        = 1 << 21
+	clctlModifyMask      = 1 << 22
+	clctlGlobalMask      = 1 << 23
+	clusctlObjectShift   = 24
+	clusctlObjectMask    = 0xff
+)
+
+// ExternalControlCode builds a CLCTL_EXTERNAL_CODE, modify marks
+// codes that change the object
+func ExternalControlCode(function uint32, access ControlAccess, modify bool) ControlCode {
+	code := ControlCode(uint32(access)&clusctlAccessMask | (function&clusctlFunctionMask)<<clusctlFunctionShift)
+	if modify {
+		code |= clctlModifyMask
+	}
+	return code
+}
+
+// InternalControlCode builds a CLCTL_INTERNAL_CODE
+func InternalControlCode(function uint32, access ControlAccess, modify bool) ControlCode {
+	return ExternalControlCode(function, access, modify) | clctlInternalMask
+}
+
+// UserControlCode builds a CLCTL_USER_CODE for resource DLL defined controls
+func UserControlCode(function uint32, access ControlAccess, modify bool) ControlCode {
+	return ExternalControlCode(function, access, modify) | clctlUserMask
+}
+
+// For returns the code targeting object, the CLUSCTL_<object>_CODE of the C headers
+func (code ControlCode) For(object ControlObject) ControlCode {
+	return code&^(clusctlObjectMask<<clusctlObjectShift) | ControlCode(object)<<clusctlObjectShift
+}
+
+// Object returns the object type, CLUS_OBJECT_INVALID for CLCTL_ codes
+func (code ControlCode) Object() ControlObject {
+	return ControlObject(code >> clusctlObjectShift & clusctlObjectMask)
+}
+
+// Access returns the access mode
+func (code ControlCode) Access() ControlAccess {
+	return ControlAccess(code & clusctlAccessMask)
+}
+
+// Function returns the function number
+func (code ControlCode) Function() uint32 {
+	return uint32(code) >> clusctlFunctionShift & clusctlFunctionMask
+}
+
+// Internal is true for codes only the cluster service may send
+func (code ControlCode) Internal() bool {
+	return code&clctlInternalMask != 0
+}
+
+// User is true for resource DLL defined codes
+func (code ControlCode) User() bool {
+	return code&clctlUserMask != 0
+}
+
+// Modify is true for codes that change the object
+func (code ControlCode) Modify() bool {
+	return code&clctlModifyMask != 0
+}
+
+// Global is true for codes sent to every node
+func (code ControlCode) Global() bool {
+	return code&clctlGlobalMask != 0
+}
+
+func (code ControlCode) String() string {
+	kind := "external"
+	switch {
+	case code.Internal():
+		kind = "internal"
+	case code.User():
+		kind = "user"
+	}
+	return fmt.Sprintf("ControlCode(0x%08x object %d function %d access %d %s modify %t)",
+		uint32(code), code.Object(), code.Function(), code.Access(), kind, code.Modify())
+}
+
+// controlCodeFor targets code at object, codes already targeting
+// another object are rejected with ERROR_INVALID_PARAMETER
+func controlCodeFor(code ControlCode, object ControlObject) (ControlCode, error) {
+	switch code.Object() {
+	case CLUS_OBJECT_INVALID:
+		return code.For(object), nil
+	case object:
+		return code, nil
+	}
+	return code, fmt.Errorf("control code for object %d sent to object %d: %w", code.Object(), object, errors.ERROR_INVALID_PARAMETER)
+}
+
+// CLCTL_ codes, send them to any handle with Control or combine with For
+const (
+	CLCTL_UNKNOWN                            ControlCode = 0x00000000
+	CLCTL_GET_CHARACTERISTICS                ControlCode = 0x00000005
+	CLCTL_GET_FLAGS                          ControlCode = 0x00000009
+	CLCTL_GET_CLASS_INFO                     ControlCode = 0x0000000d
+	CLCTL_GET_REQUIRED_DEPENDENCIES          ControlCode = 0x00000011
+	CLCTL_GET_ARB_TIMEOUT                    ControlCode = 0x00000015
+	CLCTL_GET_FAILURE_INFO                   ControlCode = 0x00000019
+	CLCTL_GET_NAME                           ControlCode = 0x00000029
+	CLCTL_GET_RESOURCE_TYPE                  ControlCode = 0x0000002d
+	CLCTL_GET_NODE                           ControlCode = 0x00000031
+	CLCTL_GET_NETWORK                        ControlCode = 0x00000035
+	CLCTL_GET_ID                             ControlCode = 0x00000039
+	CLCTL_GET_FQDN                           ControlCode = 0x0000003d
+	CLCTL_GET_CLUSTER_SERVICE_ACCOUNT_NAME   ControlCode = 0x00000041
+	CLCTL_ENUM_COMMON_PROPERTIES             ControlCode = 0x00000051
+	CLCTL_GET_RO_COMMON_PROPERTIES           ControlCode = 0x00000055
+	CLCTL_GET_COMMON_PROPERTIES              ControlCode = 0x00000059
+	CLCTL_SET_COMMON_PROPERTIES              ControlCode = 0x0040005e
+	CLCTL_VALIDATE_COMMON_PROPERTIES         ControlCode = 0x00000061
+	CLCTL_GET_COMMON_PROPERTY_FMTS           ControlCode = 0x00000065
+	CLCTL_GET_COMMON_RESOURCE_PROPERTY_FMTS  ControlCode = 0x00000069
+	CLCTL_ENUM_PRIVATE_PROPERTIES            ControlCode = 0x00000079
+	CLCTL_GET_RO_PRIVATE_PROPERTIES          ControlCode = 0x0000007d
+	CLCTL_GET_PRIVATE_PROPERTIES             ControlCode = 0x00000081
+	CLCTL_SET_PRIVATE_PROPERTIES             ControlCode = 0x00400086
+	CLCTL_VALIDATE_PRIVATE_PROPERTIES        ControlCode = 0x00000089
+	CLCTL_GET_PRIVATE_PROPERTY_FMTS          ControlCode = 0x0000008d
+	CLCTL_GET_PRIVATE_RESOURCE_PROPERTY_FMTS ControlCode = 0x00000091
+	CLCTL_ADD_REGISTRY_CHECKPOINT            ControlCode = 0x004000a2
+	CLCTL_DELETE_REGISTRY_CHECKPOINT         ControlCode = 0x004000a6
+	CLCTL_GET_REGISTRY_CHECKPOINTS           ControlCode = 0x000000a9
+	CLCTL_ADD_CRYPTO_CHECKPOINT              ControlCode = 0x004000ae
+	CLCTL_DELETE_CRYPTO_CHECKPOINT           ControlCode = 0x004000b2
+	CLCTL_GET_CRYPTO_CHECKPOINTS             ControlCode = 0x000000b5
+	CLCTL_GET_LOADBAL_PROCESS_LIST           ControlCode = 0x000000c9
+	CLCTL_GET_NETWORK_NAME                   ControlCode = 0x00000169
+	CLCTL_STORAGE_GET_DISK_INFO              ControlCode = 0x00000191
+	CLCTL_STORAGE_IS_PATH_VALID              ControlCode = 0x00000199
+	CLCTL_QUERY_DELETE                       ControlCode = 0x000001b9
+)
@@ -0,0 +1,51 @@
+package cluster
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControlCodeBuilders(t *testing.T) {
+	assert.Equal(t, CLCTL_GET_PRIVATE_PROPERTIES, ExternalControlCode(32, CLUS_ACCESS_READ, false))
+	assert.Equal(t, CLCTL_SET_PRIVATE_PROPERTIES, ExternalControlCode(33, CLUS_ACCESS_WRITE, true))
+	assert.Equal(t, CLCTL_VALIDATE_PRIVATE_PROPERTIES, ExternalControlCode(34, CLUS_ACCESS_READ, false))
+	assert.Equal(t, CLCTL_GET_FQDN, ExternalControlCode(15, CLUS_ACCESS_READ, false))
+	assert.Equal(t, ControlCode(0x00100081), InternalControlCode(32, CLUS_ACCESS_READ, false))
+	assert.Equal(t, ControlCode(0x00200081), UserControlCode(32, CLUS_ACCESS_READ, false))
+}
+
+func TestControlCodeFor(t *testing.T) {
+	assert.Equal(t, ControlCode(0x01000081), CLCTL_GET_PRIVATE_PROPERTIES.For(CLUS_OBJECT_RESOURCE))
+	assert.Equal(t, ControlCode(0x01400086), CLCTL_SET_PRIVATE_PROPERTIES.For(CLUS_OBJECT_RESOURCE))
+	assert.Equal(t, ControlCode(0x0700003d), CLCTL_GET_FQDN.For(CLUS_OBJECT_CLUSTER))
+	assert.Equal(t, ControlCode(0x03000059), CLCTL_GET_COMMON_PROPERTIES.For(CLUS_OBJECT_NODE).For(CLUS_OBJECT_GROUP))
+}
+
+func TestControlCodeFields(t *testing.T) {
+	code := CLCTL_SET_COMMON_PROPERTIES.For(CLUS_OBJECT_GROUP)
+	assert.Equal(t, CLUS_OBJECT_GROUP, code.Object())
+	assert.Equal(t, CLUS_ACCESS_WRITE, code.Access())
+	assert.Equal(t, uint32(23), code.Function())
+	assert.True(t, code.Modify())
+	assert.False(t, code.Internal())
+	assert.False(t, code.User())
+	assert.False(t, code.Global())
+	assert.Equal(t, CLUS_OBJECT_INVALID, CLCTL_GET_NAME.Object())
+	assert.Equal(t, "ControlCode(0x0340005e object 3 function 23 access 2 external modify true)", code.String())
+}
+
+func TestControlCodeForObject(t *testing.T) {
+	code, err := controlCodeFor(CLCTL_GET_NAME, CLUS_OBJECT_NODE)
+	assert.Nil(t, err)
+	assert.Equal(t, ControlCode(0x04000029), code)
+
+	code, err = controlCodeFor(CLCTL_GET_NAME.For(CLUS_OBJECT_NODE), CLUS_OBJECT_NODE)
+	assert.Nil(t, err)
+	assert.Equal(t, ControlCode(0x04000029), code)
+
+	_, err = controlCodeFor(CLCTL_GET_NAME.For(CLUS_OBJECT_RESOURCE), CLUS_OBJECT_NODE)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER))
+}
@@ -1,13 +1,10 @@
 package cluster
 
 import (
-	"syscall"
-	"unsafe"
+	"fmt"
+	"strings"
 
 	"github.com/KnicKnic/go-windows/pkg/errors"
-	"github.com/KnicKnic/go-windows/pkg/kernel32"
-	"github.com/KnicKnic/go-windows/pkg/ntdll"
-	"golang.org/x/sys/windows"
 )
 
 const (
@@ -30,7 +27,18 @@ const (
 	PROV_REPLACE_OWF   CryptographicServiceProviderType = 23
 	PROV_RSA_AES       CryptographicServiceProviderType = 24
 
-	MS_ENH_RSA_AES_PROV string = "Microsoft Enhanced RSA and AES Cryptographic Provider"
+	MS_DEF_PROV              string = "Microsoft Base Cryptographic Provider v1.0"
+	MS_ENHANCED_PROV         string = "Microsoft Enhanced Cryptographic Provider v1.0"
+	MS_STRONG_PROV           string = "Microsoft Strong Cryptographic Provider"
+	MS_DEF_RSA_SIG_PROV      string = "Microsoft RSA Signature Cryptographic Provider"
+	MS_DEF_RSA_SCHANNEL_PROV string = "Microsoft RSA SChannel Cryptographic Provider"
+	MS_DEF_DSS_PROV          string = "Microsoft Base DSS Cryptographic Provider"
+	MS_DEF_DSS_DH_PROV       string = "Microsoft Base DSS and Diffie-Hellman Cryptographic Provider"
+	MS_ENH_DSS_DH_PROV       string = "Microsoft Enhanced DSS and Diffie-Hellman Cryptographic Provider"
+	MS_DEF_DH_SCHANNEL_PROV  string = "Microsoft DH SChannel Cryptographic Provider"
+	MS_SCARD_PROV            string = "Microsoft Base Smart Card Crypto Provider"
+	MS_ENH_RSA_AES_PROV      string = "Microsoft Enhanced RSA and AES Cryptographic Provider"
+	MS_ENH_RSA_AES_PROV_XP   string = "Microsoft Enhanced RSA and AES Cryptographic Provider (Prototype)"
 
 	CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND OpenClusterCryptProviderFlags = 1
 	CLUS_CREATE_CRYPT_NONE                OpenClusterCryptProviderFlags = 0
@@ -42,82 +50,143 @@ type (
 	OpenClusterCryptProviderFlags    uint32
 )
 
-var (
-	procOpenClusterCryptProvider   = resapi_dll.NewProc("OpenClusterCryptProvider")
-	procCloseClusterCryptProvider  = resapi_dll.NewProc("CloseClusterCryptProvider")
-	procClusterEncrypt             = resapi_dll.NewProc("ClusterEncrypt")
-	procClusterDecrypt             = resapi_dll.NewProc("ClusterDecrypt")
-	procFreeClusterCrypt           = resapi_dll.NewProc("FreeClusterCrypt")
-	procOpenClusterCryptProviderEx = resapi_dll.NewProc("OpenClusterCryptProviderEx")
-)
-
-func openClusterCryptProvider(lpszResource *uint16, lpszProvider *uint16, dwType CryptographicServiceProviderType, dwFlags OpenClusterCryptProviderFlags) (HCLUSCRYPTPROVIDER, error) {
-	r0, _, lastError := syscall.Syscall6(procOpenClusterCryptProvider.Addr(), 4, uintptr(unsafe.Pointer(lpszResource)), uintptr(unsafe.Pointer(lpszProvider)), uintptr(dwType), uintptr(dwFlags), 0, 0)
-	handle := HCLUSCRYPTPROVIDER(r0)
-	return handle, errors.NotNill(r0, lastError)
+var cryptographicServiceProviderTypeNames = map[CryptographicServiceProviderType]string{
+	PROV_RSA_FULL:      "PROV_RSA_FULL",
+	PROV_RSA_SIG:       "PROV_RSA_SIG",
+	PROV_DSS:           "PROV_DSS",
+	PROV_FORTEZZA:      "PROV_FORTEZZA",
+	PROV_MS_EXCHANGE:   "PROV_MS_EXCHANGE",
+	PROV_SSL:           "PROV_SSL",
+	PROV_RSA_SCHANNEL:  "PROV_RSA_SCHANNEL",
+	PROV_DSS_DH:        "PROV_DSS_DH",
+	PROV_EC_ECDSA_SIG:  "PROV_EC_ECDSA_SIG",
+	PROV_EC_ECNRA_SIG:  "PROV_EC_ECNRA_SIG",
+	PROV_EC_ECDSA_FULL: "PROV_EC_ECDSA_FULL",
+	PROV_EC_ECNRA_FULL: "PROV_EC_ECNRA_FULL",
+	PROV_DH_SCHANNEL:   "PROV_DH_SCHANNEL",
+	PROV_SPYRUS_LYNKS:  "PROV_SPYRUS_LYNKS",
+	PROV_RNG:           "PROV_RNG",
+	PROV_INTEL_SEC:     "PROV_INTEL_SEC",
+	PROV_REPLACE_OWF:   "PROV_REPLACE_OWF",
+	PROV_RSA_AES:       "PROV_RSA_AES",
 }
 
-func openClusterCryptProviderEx(lpszResource *uint16, lpszKeyName *uint16, lpszProvider *uint16, dwType CryptographicServiceProviderType, dwFlags OpenClusterCryptProviderFlags) (HCLUSCRYPTPROVIDER, error) {
-	r0, _, lastError := syscall.Syscall6(procOpenClusterCryptProviderEx.Addr(), 5, uintptr(unsafe.Pointer(lpszResource)), uintptr(unsafe.Pointer(lpszKeyName)), uintptr(unsafe.Pointer(lpszProvider)), uintptr(dwType), uintptr(dwFlags), 0)
-	handle := HCLUSCRYPTPROVIDER(r0)
-	return handle, errors.NotNill(r0, lastError)
-}
-
-func OpenClusterCryptProvider(Resource string, Provider string, dwType CryptographicServiceProviderType, dwFlags OpenClusterCryptProviderFlags) (handle HCLUSCRYPTPROVIDER, err error) {
-	resource, err := windows.UTF16PtrFromString(Resource)
-	if err != nil {
-		return
-	}
-	provider, err := windows.UTF16PtrFromString(Provider)
-	if err != nil {
-		return
+func (providerType CryptographicServiceProviderType) String() string {
+	if name, ok := cryptographicServiceProviderTypeNames[providerType]; ok {
+		return name
 	}
-
-	handle, err = openClusterCryptProvider(resource, provider, dwType, dwFlags)
-	return
+	return fmt.Sprintf("CryptographicServiceProviderType(%d)", uint32(providerType))
 }
 
-func (handle HCLUSCRYPTPROVIDER) CloseClusterCryptProvider() {
-	syscall.Syscall(procCloseClusterCryptProvider.Addr(), 1, uintptr(handle), 0, 0)
-	return
+// knownCryptProviders is the type of the providers that ship with Windows,
+// keyed by lower case name as provider names are case insensitive
+var knownCryptProviders = map[string]CryptographicServiceProviderType{
+	strings.ToLower(MS_DEF_PROV):              PROV_RSA_FULL,
+	strings.ToLower(MS_ENHANCED_PROV):         PROV_RSA_FULL,
+	strings.ToLower(MS_STRONG_PROV):           PROV_RSA_FULL,
+	strings.ToLower(MS_SCARD_PROV):            PROV_RSA_FULL,
+	strings.ToLower(MS_DEF_RSA_SIG_PROV):      PROV_RSA_SIG,
+	strings.ToLower(MS_DEF_RSA_SCHANNEL_PROV): PROV_RSA_SCHANNEL,
+	strings.ToLower(MS_DEF_DSS_PROV):          PROV_DSS,
+	strings.ToLower(MS_DEF_DSS_DH_PROV):       PROV_DSS_DH,
+	strings.ToLower(MS_ENH_DSS_DH_PROV):       PROV_DSS_DH,
+	strings.ToLower(MS_DEF_DH_SCHANNEL_PROV):  PROV_DH_SCHANNEL,
+	strings.ToLower(MS_ENH_RSA_AES_PROV):      PROV_RSA_AES,
+	strings.ToLower(MS_ENH_RSA_AES_PROV_XP):   PROV_RSA_AES,
 }
 
-func encryptDecrypt(encryptDecryptFunc *windows.LazyProc, handle HCLUSCRYPTPROVIDER, data []byte) (encrypted []byte, err error) {
+// CryptProvider is a provider installed on the machine, see EnumCryptProviders
+type CryptProvider struct {
+	Name string
+	Type CryptographicServiceProviderType
+}
 
-	// doing this as api is not clear if I need a valid pointer for 0 sized memory
-	cData, err := ntdll.MemcpyLocalAlloc(data)
-	if err != nil {
-		return
+// ValidateCryptProvider returns ERROR_INVALID_PARAMETER when providerType is
+// not a PROV_* type or provider is a Windows provider of another type.
+// An empty provider is the default provider of providerType, and providers
+// that do not ship with Windows can have any type
+func ValidateCryptProvider(provider string, providerType CryptographicServiceProviderType) error {
+	if _, ok := cryptographicServiceProviderTypeNames[providerType]; !ok {
+		return fmt.Errorf("provider type %s: %w", providerType, errors.ERROR_INVALID_PARAMETER)
+	}
+	if known, ok := knownCryptProviders[strings.ToLower(provider)]; ok && known != providerType {
+		return fmt.Errorf("provider %q is %s, not %s: %w", provider, known, providerType, errors.ERROR_INVALID_PARAMETER)
 	}
-	defer kernel32.LocalFree(cData)
+	return nil
+}
 
-	dataSize := uint32(len(data))
+// CryptProviderOption sets a parameter of OpenClusterCryptProviderEx
+type CryptProviderOption func(*cryptProviderOptions)
 
-	var cDest uintptr
-	var destSize uint32
+type cryptProviderOptions struct {
+	resource     string
+	keyName      string
+	provider     string
+	providerType CryptographicServiceProviderType
+	flags        OpenClusterCryptProviderFlags
 
-	r0, _, _ := syscall.Syscall6(encryptDecryptFunc.Addr(), 5, uintptr(handle), cData, uintptr(dataSize), uintptr(unsafe.Pointer(&cDest)), uintptr(unsafe.Pointer(&destSize)), 0)
-	err = errors.NotZero(syscall.Errno(r0))
-	if err != nil {
-		return
-	}
-	defer freeClusterCrypt(cDest)
+	providerSet     bool
+	providerTypeSet bool
+}
 
-	encrypted = make([]byte, destSize)
-	ntdll.MemcpySrcC(encrypted, cDest, uint64(destSize))
+// WithCryptResource is the resource whose checkpoint stores the key, it is required
+func WithCryptResource(resource string) CryptProviderOption {
+	return func(options *cryptProviderOptions) { options.resource = resource }
+}
 
-	return
+// WithCryptKeyName is the name of the key container, resources that share a
+// provider use different names to get separate keys. Empty is the resource's
+// default container
+func WithCryptKeyName(keyName string) CryptProviderOption {
+	return func(options *cryptProviderOptions) { options.keyName = keyName }
 }
 
-func (handle HCLUSCRYPTPROVIDER) ClusterEncrypt(data []byte) ([]byte, error) {
+// WithCryptProviderName is the provider, empty is the default provider of the
+// provider type. Without it and WithCryptProviderType the provider is
+// MS_ENH_RSA_AES_PROV, with only WithCryptProviderType it is empty
+func WithCryptProviderName(provider string) CryptProviderOption {
+	return func(options *cryptProviderOptions) {
+		options.provider = provider
+		options.providerSet = true
+	}
+}
 
-	return encryptDecrypt(procClusterEncrypt, handle, data)
+// WithCryptProviderType is the type of the provider. Without it the type of a
+// Windows provider set by WithCryptProviderName is used, else PROV_RSA_AES
+func WithCryptProviderType(providerType CryptographicServiceProviderType) CryptProviderOption {
+	return func(options *cryptProviderOptions) {
+		options.providerType = providerType
+		options.providerTypeSet = true
+	}
 }
-func (handle HCLUSCRYPTPROVIDER) ClusterDecrypt(data []byte) ([]byte, error) {
 
-	return encryptDecrypt(procClusterDecrypt, handle, data)
+// WithCryptFlags is CLUS_CREATE_CRYPT_NONE by default,
+// CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND creates a missing key container
+func WithCryptFlags(flags OpenClusterCryptProviderFlags) CryptProviderOption {
+	return func(options *cryptProviderOptions) { options.flags = flags }
 }
 
-func freeClusterCrypt(ptr uintptr) {
-	_, _, _ = syscall.Syscall(procFreeClusterCrypt.Addr(), 1, uintptr(ptr), 0, 0)
+// newCryptProviderOptions applies options over the defaults and validates them
+func newCryptProviderOptions(options []CryptProviderOption) (cryptProviderOptions, error) {
+	result := cryptProviderOptions{flags: CLUS_CREATE_CRYPT_NONE}
+	for _, option := range options {
+		option(&result)
+	}
+	switch {
+	case !result.providerSet && !result.providerTypeSet:
+		result.provider = MS_ENH_RSA_AES_PROV
+		result.providerType = PROV_RSA_AES
+	case !result.providerTypeSet:
+		result.providerType = PROV_RSA_AES
+		if known, ok := knownCryptProviders[strings.ToLower(result.provider)]; ok {
+			result.providerType = known
+		}
+	}
+	if result.resource == "" {
+		return result, fmt.Errorf("no resource: %w", errors.ERROR_INVALID_PARAMETER)
+	}
+	if result.flags&^CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND != 0 {
+		return result, fmt.Errorf("flags 0x%x: %w", uint32(result.flags), errors.ERROR_INVALID_PARAMETER)
+	}
+	return result, ValidateCryptProvider(result.provider, result.providerType)
 }
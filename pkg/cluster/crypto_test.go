@@ -1,83 +1,106 @@
 package cluster
 
 import (
-	"fmt"
-	"syscall"
 	"testing"
 
+	"github.com/KnicKnic/go-windows/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
-const (
-	validResourceName string = "r1"
-)
-
-func TestCrypto(t *testing.T) {
-	var data = []byte{1, 2, 3, 4, 5}
-	fmt.Println("Unencrypted: ", data)
-	handle, err := OpenClusterCryptProvider(validResourceName, MS_ENH_RSA_AES_PROV, PROV_RSA_AES, CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND)
-	defer handle.CloseClusterCryptProvider()
+func TestCryptographicServiceProviderTypeString(t *testing.T) {
+	assert.Equal(t, "PROV_RSA_AES", PROV_RSA_AES.String())
+	assert.Equal(t, "PROV_DSS_DH", PROV_DSS_DH.String())
+	assert.Equal(t, "CryptographicServiceProviderType(99)", CryptographicServiceProviderType(99).String())
+}
 
-	if err != nil {
-		t.Log(err.Error())
+func TestValidateCryptProvider(t *testing.T) {
+	tests := []struct {
+		provider     string
+		providerType CryptographicServiceProviderType
+		valid        bool
+	}{
+		{MS_ENH_RSA_AES_PROV, PROV_RSA_AES, true},
+		{MS_ENH_RSA_AES_PROV_XP, PROV_RSA_AES, true},
+		{MS_ENHANCED_PROV, PROV_RSA_FULL, true},
+		{MS_DEF_DSS_DH_PROV, PROV_DSS_DH, true},
+		{"microsoft enhanced rsa and aes cryptographic provider", PROV_RSA_AES, true},
+		{"", PROV_RSA_FULL, true},
+		{"Contoso HSM Provider", PROV_RSA_AES, true},
+		{MS_ENH_RSA_AES_PROV, PROV_RSA_FULL, false},
+		{MS_DEF_DSS_PROV, PROV_RSA_AES, false},
+		{"", CryptographicServiceProviderType(0), false},
+		{MS_ENH_RSA_AES_PROV, CryptographicServiceProviderType(99), false},
 	}
-
-	assert.NotZero(t, handle, "handle should not be null")
-
-	encrypted, err := handle.ClusterEncrypt(data)
-	if err != nil {
-		t.Log(err.Error())
+	for _, test := range tests {
+		t.Run(test.provider+"/"+test.providerType.String(), func(t *testing.T) {
+			err := ValidateCryptProvider(test.provider, test.providerType)
+			if test.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+			}
+		})
 	}
-	assert.Nil(t, err)
-	fmt.Println("Encrypted: ", encrypted)
+}
 
-	decrypted, err := handle.ClusterDecrypt(encrypted)
-	if err != nil {
-		t.Log(err.Error())
-	}
+func TestCryptProviderOptions(t *testing.T) {
+	options, err := newCryptProviderOptions([]CryptProviderOption{WithCryptResource("R1")})
 	assert.Nil(t, err)
-	fmt.Println("Decrypted: ", decrypted)
+	assert.Equal(t, cryptProviderOptions{
+		resource:     "R1",
+		provider:     MS_ENH_RSA_AES_PROV,
+		providerType: PROV_RSA_AES,
+		flags:        CLUS_CREATE_CRYPT_NONE,
+	}, options)
 
-	clus, err := OpenCluster()
-	if err != nil {
-		t.Log(err.Error())
-	}
+	options, err = newCryptProviderOptions([]CryptProviderOption{
+		WithCryptResource("R1"),
+		WithCryptKeyName("tenant1"),
+		WithCryptProviderName(MS_STRONG_PROV),
+		WithCryptProviderType(PROV_RSA_FULL),
+		WithCryptFlags(CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND),
+	})
 	assert.Nil(t, err)
-	fmt.Println("clus:", clus)
-	defer clus.Close()
+	assert.Equal(t, cryptProviderOptions{
+		resource:     "R1",
+		keyName:      "tenant1",
+		provider:     MS_STRONG_PROV,
+		providerType: PROV_RSA_FULL,
+		flags:        CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND,
 
-	res, err := clus.OpenResource(validResourceName)
-	if err != nil {
-		t.Log(err.Error())
-	}
-	assert.Nil(t, err)
-	fmt.Println("res:", res)
-	defer res.Close()
+		providerSet:     true,
+		providerTypeSet: true,
+	}, options)
 
-	key, err := res.GetKey(syscall.KEY_ALL_ACCESS)
-	if err != nil {
-		t.Log(err.Error())
-	}
-	fmt.Println("key:", key)
-	assert.Nil(t, err)
-	defer key.Close()
+	_, err = newCryptProviderOptions(nil)
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
 
-	err = key.SetValue("BeforeEncrypt", syscall.REG_BINARY, data)
-	if err != nil {
-		t.Log(err.Error())
-	}
-	assert.Nil(t, err)
+	_, err = newCryptProviderOptions([]CryptProviderOption{WithCryptResource("R1"), WithCryptProviderName(MS_ENH_RSA_AES_PROV), WithCryptProviderType(PROV_RSA_FULL)})
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
 
-	err = key.SetValue("AfterEncrypt", syscall.REG_BINARY, encrypted)
-	if err != nil {
-		t.Log(err.Error())
-	}
-	assert.Nil(t, err)
+	_, err = newCryptProviderOptions([]CryptProviderOption{WithCryptResource("R1"), WithCryptFlags(4)})
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+}
 
-	err = key.SetValue("Decrypted", syscall.REG_BINARY, decrypted)
-	if err != nil {
-		t.Log(err.Error())
+func TestCryptProviderOptionsDefaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      []CryptProviderOption
+		provider     string
+		providerType CryptographicServiceProviderType
+	}{
+		{"neither", nil, MS_ENH_RSA_AES_PROV, PROV_RSA_AES},
+		{"type only", []CryptProviderOption{WithCryptProviderType(PROV_RSA_FULL)}, "", PROV_RSA_FULL},
+		{"Windows name only", []CryptProviderOption{WithCryptProviderName(MS_DEF_DSS_PROV)}, MS_DEF_DSS_PROV, PROV_DSS},
+		{"other name only", []CryptProviderOption{WithCryptProviderName("Contoso HSM Provider")}, "Contoso HSM Provider", PROV_RSA_AES},
+		{"empty name only", []CryptProviderOption{WithCryptProviderName("")}, "", PROV_RSA_AES},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := newCryptProviderOptions(append([]CryptProviderOption{WithCryptResource("R1")}, test.options...))
+			assert.Nil(t, err)
+			assert.Equal(t, test.provider, options.provider)
+			assert.Equal(t, test.providerType, options.providerType)
+		})
 	}
-	assert.Nil(t, err)
-
 }
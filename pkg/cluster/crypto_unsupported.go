@@ -0,0 +1,34 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import "github.com/KnicKnic/go-windows/pkg/errors"
+
+func OpenClusterCryptProvider(Resource string, Provider string, dwType CryptographicServiceProviderType, dwFlags OpenClusterCryptProviderFlags) (handle HCLUSCRYPTPROVIDER, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle HCLUSCRYPTPROVIDER) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle HCLUSCRYPTPROVIDER) CloseClusterCryptProvider() error {
+	return errors.ErrNotSupported
+}
+
+func (handle HCLUSCRYPTPROVIDER) ClusterEncrypt(data []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle HCLUSCRYPTPROVIDER) ClusterDecrypt(data []byte) ([]byte, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func OpenClusterCryptProviderEx(options ...CryptProviderOption) (handle HCLUSCRYPTPROVIDER, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func EnumCryptProviders() (providers []CryptProvider, err error) {
+	return nil, errors.ErrNotSupported
+}
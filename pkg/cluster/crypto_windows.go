@@ -0,0 +1,170 @@
+package cluster
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/kernel32"
+	"github.com/KnicKnic/go-windows/pkg/ntdll"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procOpenClusterCryptProvider   = resapi_dll.NewProc("OpenClusterCryptProvider")
+	procCloseClusterCryptProvider  = resapi_dll.NewProc("CloseClusterCryptProvider")
+	procClusterEncrypt             = resapi_dll.NewProc("ClusterEncrypt")
+	procClusterDecrypt             = resapi_dll.NewProc("ClusterDecrypt")
+	procFreeClusterCrypt           = resapi_dll.NewProc("FreeClusterCrypt")
+	procOpenClusterCryptProviderEx = resapi_dll.NewProc("OpenClusterCryptProviderEx")
+	procCryptEnumProviders         = advapi32_dll.NewProc("CryptEnumProvidersW")
+)
+
+func openClusterCryptProvider(lpszResource *uint16, lpszProvider *uint16, dwType CryptographicServiceProviderType, dwFlags OpenClusterCryptProviderFlags) (HCLUSCRYPTPROVIDER, error) {
+	r0, _, lastError := syscall.Syscall6(procOpenClusterCryptProvider.Addr(), 4, uintptr(unsafe.Pointer(lpszResource)), uintptr(unsafe.Pointer(lpszProvider)), uintptr(dwType), uintptr(dwFlags), 0, 0)
+	handle := HCLUSCRYPTPROVIDER(r0)
+	return handle, handles.opened(cryptProviderHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func openClusterCryptProviderEx(lpszResource *uint16, lpszKeyName *uint16, lpszProvider *uint16, dwType CryptographicServiceProviderType, dwFlags OpenClusterCryptProviderFlags) (HCLUSCRYPTPROVIDER, error) {
+	r0, _, lastError := syscall.Syscall6(procOpenClusterCryptProviderEx.Addr(), 5, uintptr(unsafe.Pointer(lpszResource)), uintptr(unsafe.Pointer(lpszKeyName)), uintptr(unsafe.Pointer(lpszProvider)), uintptr(dwType), uintptr(dwFlags), 0)
+	handle := HCLUSCRYPTPROVIDER(r0)
+	return handle, handles.opened(cryptProviderHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func OpenClusterCryptProvider(Resource string, Provider string, dwType CryptographicServiceProviderType, dwFlags OpenClusterCryptProviderFlags) (handle HCLUSCRYPTPROVIDER, err error) {
+	defer func() { err = errors.Wrap(procOpenClusterCryptProvider.Name, Resource, err) }()
+	resource, err := windows.UTF16PtrFromString(Resource)
+	if err != nil {
+		return
+	}
+	provider, err := windows.UTF16PtrFromString(Provider)
+	if err != nil {
+		return
+	}
+
+	handle, err = openClusterCryptProvider(resource, provider, dwType, dwFlags)
+	return
+}
+
+// OpenClusterCryptProviderEx is OpenClusterCryptProvider with a key name,
+// resources that share a provider each get their own key container from
+// WithCryptKeyName. WithCryptResource is required, without a provider name
+// or type the provider is MS_ENH_RSA_AES_PROV of type PROV_RSA_AES
+func OpenClusterCryptProviderEx(options ...CryptProviderOption) (handle HCLUSCRYPTPROVIDER, err error) {
+	config, err := newCryptProviderOptions(options)
+	defer func() { err = errors.Wrap(procOpenClusterCryptProviderEx.Name, config.resource, err) }()
+	if err != nil {
+		return
+	}
+	resource, err := windows.UTF16PtrFromString(config.resource)
+	if err != nil {
+		return
+	}
+	keyName, err := utf16PtrOrNil(config.keyName)
+	if err != nil {
+		return
+	}
+	provider, err := utf16PtrOrNil(config.provider)
+	if err != nil {
+		return
+	}
+
+	handle, err = openClusterCryptProviderEx(resource, keyName, provider, config.providerType, config.flags)
+	return
+}
+
+// utf16PtrOrNil is windows.UTF16PtrFromString except an empty s is a NULL pointer
+func utf16PtrOrNil(s string) (*uint16, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return windows.UTF16PtrFromString(s)
+}
+
+func cryptEnumProviders(dwIndex uint32, pdwProvType *CryptographicServiceProviderType, szProvName *uint16, pcbProvName *uint32) syscall.Errno {
+	r0, _, lastError := syscall.Syscall6(procCryptEnumProviders.Addr(), 6, uintptr(dwIndex), 0, 0, uintptr(unsafe.Pointer(pdwProvType)), uintptr(unsafe.Pointer(szProvName)), uintptr(unsafe.Pointer(pcbProvName)))
+	if r0 != 0 {
+		return 0
+	}
+	return lastError
+}
+
+// EnumCryptProviders returns the providers installed on the machine,
+// the names and types to pass to OpenClusterCryptProviderEx
+func EnumCryptProviders() (providers []CryptProvider, err error) {
+	defer func() { err = errors.Wrap(procCryptEnumProviders.Name, "", err) }()
+	for index := uint32(0); ; index++ {
+		var providerType CryptographicServiceProviderType
+		var name []uint16
+		var nameBytes uint32
+		err = retryMoreData(func() syscall.Errno {
+			name = make([]uint16, nameBytes/2+1)
+			nameBytes = uint32(len(name) * 2)
+			return cryptEnumProviders(index, &providerType, &name[0], &nameBytes)
+		})
+		if err == ERROR_NO_MORE_ITEMS {
+			return providers, nil
+		}
+		if err != nil {
+			return
+		}
+		providers = append(providers, CryptProvider{Name: windows.UTF16ToString(name), Type: providerType})
+	}
+}
+
+func closeClusterCryptProvider(handle HCLUSCRYPTPROVIDER) error {
+	r0, _, _ := syscall.Syscall(procCloseClusterCryptProvider.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the provider, wrap it with Safe to close it at most once
+func (handle HCLUSCRYPTPROVIDER) Close() error {
+	err := handles.closed(cryptProviderHandleKind, uintptr(handle), func() error { return closeClusterCryptProvider(handle) })
+	return errors.Wrap(procCloseClusterCryptProvider.Name, "", err)
+}
+
+// CloseClusterCryptProvider is Close
+func (handle HCLUSCRYPTPROVIDER) CloseClusterCryptProvider() error {
+	return handle.Close()
+}
+
+func encryptDecrypt(encryptDecryptFunc *windows.LazyProc, handle HCLUSCRYPTPROVIDER, data []byte) (encrypted []byte, err error) {
+
+	// doing this as api is not clear if I need a valid pointer for 0 sized memory
+	cData, err := ntdll.MemcpyLocalAlloc(data)
+	if err != nil {
+		return
+	}
+	defer kernel32.LocalFree(cData)
+
+	dataSize := uint32(len(data))
+
+	var cDest uintptr
+	var destSize uint32
+
+	r0, _, _ := syscall.Syscall6(encryptDecryptFunc.Addr(), 5, uintptr(handle), cData, uintptr(dataSize), uintptr(unsafe.Pointer(&cDest)), uintptr(unsafe.Pointer(&destSize)), 0)
+	err = errors.NotZero(syscall.Errno(r0))
+	if err != nil {
+		return
+	}
+	defer freeClusterCrypt(cDest)
+
+	encrypted = make([]byte, destSize)
+	ntdll.MemcpySrcC(encrypted, cDest, uint64(destSize))
+
+	return
+}
+
+func (handle HCLUSCRYPTPROVIDER) ClusterEncrypt(data []byte) ([]byte, error) {
+	encrypted, err := encryptDecrypt(procClusterEncrypt, handle, data)
+	return encrypted, errors.Wrap(procClusterEncrypt.Name, "", err)
+}
+func (handle HCLUSCRYPTPROVIDER) ClusterDecrypt(data []byte) ([]byte, error) {
+	decrypted, err := encryptDecrypt(procClusterDecrypt, handle, data)
+	return decrypted, errors.Wrap(procClusterDecrypt.Name, "", err)
+}
+
+func freeClusterCrypt(ptr uintptr) {
+	_, _, _ = syscall.Syscall(procFreeClusterCrypt.Addr(), 1, uintptr(ptr), 0, 0)
+}
@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	validResourceName string = "r1"
+)
+
+func TestCrypto(t *testing.T) {
+	var data = []byte{1, 2, 3, 4, 5}
+	fmt.Println("Unencrypted: ", data)
+	handle, err := OpenClusterCryptProvider(validResourceName, MS_ENH_RSA_AES_PROV, PROV_RSA_AES, CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND)
+	defer handle.CloseClusterCryptProvider()
+
+	if err != nil {
+		t.Log(err.Error())
+	}
+
+	assert.NotZero(t, handle, "handle should not be null")
+
+	encrypted, err := handle.ClusterEncrypt(data)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	assert.Nil(t, err)
+	fmt.Println("Encrypted: ", encrypted)
+
+	decrypted, err := handle.ClusterDecrypt(encrypted)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	assert.Nil(t, err)
+	fmt.Println("Decrypted: ", decrypted)
+
+	clus, err := OpenCluster()
+	if err != nil {
+		t.Log(err.Error())
+	}
+	assert.Nil(t, err)
+	fmt.Println("clus:", clus)
+	defer clus.Close()
+
+	res, err := clus.OpenResource(validResourceName)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	assert.Nil(t, err)
+	fmt.Println("res:", res)
+	defer res.Close()
+
+	key, err := res.GetKey(syscall.KEY_ALL_ACCESS)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	fmt.Println("key:", key)
+	assert.Nil(t, err)
+	defer key.Close()
+
+	err = key.SetValue("BeforeEncrypt", syscall.REG_BINARY, data)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	assert.Nil(t, err)
+
+	err = key.SetValue("AfterEncrypt", syscall.REG_BINARY, encrypted)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	assert.Nil(t, err)
+
+	err = key.SetValue("Decrypted", syscall.REG_BINARY, decrypted)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	assert.Nil(t, err)
+
+}
+
+func TestCryptoKeyName(t *testing.T) {
+	var data = []byte{1, 2, 3, 4, 5}
+	tenant1, err := OpenClusterCryptProviderEx(WithCryptResource(validResourceName), WithCryptKeyName("tenant1"), WithCryptFlags(CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tenant1.Close()
+
+	tenant2, err := OpenClusterCryptProviderEx(WithCryptResource(validResourceName), WithCryptKeyName("tenant2"), WithCryptFlags(CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tenant2.Close()
+
+	encrypted, err := tenant1.ClusterEncrypt(data)
+	assert.Nil(t, err)
+
+	decrypted, err := tenant1.ClusterDecrypt(encrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, data, decrypted)
+
+	_, err = tenant2.ClusterDecrypt(encrypted)
+	assert.NotNil(t, err, "another key name should not decrypt")
+}
+
+func TestEnumCryptProviders(t *testing.T) {
+	providers, err := EnumCryptProviders()
+	assert.Nil(t, err)
+	assert.Contains(t, providers, CryptProvider{Name: MS_ENH_RSA_AES_PROV, Type: PROV_RSA_AES})
+	for _, provider := range providers {
+		assert.Nil(t, ValidateCryptProvider(provider.Name, provider.Type), provider.Name)
+	}
+}
+
+func TestCryptStream(t *testing.T) {
+	handle, err := OpenClusterCryptProviderEx(WithCryptResource(validResourceName), WithCryptFlags(CLUS_CREATE_CRYPT_CONTAINER_NOT_FOUND))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer handle.Close()
+
+	data := bytes.Repeat([]byte{1, 2, 3, 4, 5}, CryptChunkSize)
+	var stream bytes.Buffer
+	writer := NewEncryptWriter(handle, &stream)
+	_, err = writer.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	decrypted, err := ioutil.ReadAll(NewDecryptReader(handle, &stream))
+	assert.Nil(t, err)
+	assert.Equal(t, data, decrypted)
+}
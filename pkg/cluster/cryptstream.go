@@ -0,0 +1,259 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// The streams of EncryptWriter and DecryptReader are framed, so large payloads
+// never have to be encrypted or held in memory as one buffer.
+//
+// A stream is a header followed by frames, integers are little endian:
+//
+//	header: magic "CLCS" | version uint8 (1)
+//	frame:  kind uint8 | length uint32 | ciphertext [length]byte
+//
+// Data frames (kind 1) hold the ciphertext of up to CryptChunkSize bytes of
+// plaintext. The stream ends with a single end frame (kind 2) whose ciphertext
+// is the encrypted SHA-256 of the header and every data frame in order, so
+// modified, reordered, dropped or appended chunks fail the check. Every chunk
+// is encrypted on its own by the provider, the framing does not depend on it.
+const (
+	// CryptChunkSize is the most plaintext a data frame holds
+	CryptChunkSize = 64 * 1024
+
+	cryptStreamMagic   = "CLCS"
+	cryptStreamVersion = 1
+
+	cryptFrameData = 1
+	cryptFrameEnd  = 2
+
+	cryptHeaderLength      = len(cryptStreamMagic) + 1
+	cryptFrameHeaderLength = 1 + 4
+
+	// maxCryptFrameLength bounds the allocation for a frame of a corrupt stream
+	maxCryptFrameLength = 16 * 1024 * 1024
+)
+
+// Crypter encrypts and decrypts a chunk, HCLUSCRYPTPROVIDER implements it
+type Crypter interface {
+	ClusterEncrypt(data []byte) ([]byte, error)
+	ClusterDecrypt(data []byte) ([]byte, error)
+}
+
+func cryptStreamHeader() []byte {
+	return append([]byte(cryptStreamMagic), cryptStreamVersion)
+}
+
+// EncryptWriter encrypts what is written to it in chunks of CryptChunkSize,
+// see NewEncryptWriter
+type EncryptWriter struct {
+	provider Crypter
+	w        io.Writer
+	digest   hash.Hash
+	chunk    []byte
+	started  bool
+	closed   bool
+	err      error
+}
+
+// NewEncryptWriter returns a writer that encrypts to w with provider.
+// Close must be called to write the last chunk and the end frame,
+// it does not close w
+func NewEncryptWriter(provider Crypter, w io.Writer) *EncryptWriter {
+	return &EncryptWriter{
+		provider: provider,
+		w:        w,
+		digest:   sha256.New(),
+		chunk:    make([]byte, 0, CryptChunkSize),
+	}
+}
+
+func (writer *EncryptWriter) Write(p []byte) (n int, err error) {
+	if writer.closed {
+		return 0, fmt.Errorf("write to closed EncryptWriter: %w", errors.ERROR_INVALID_STATE)
+	}
+	for len(p) > 0 && writer.err == nil {
+		copied := copy(writer.chunk[len(writer.chunk):cap(writer.chunk)], p)
+		writer.chunk = writer.chunk[:len(writer.chunk)+copied]
+		p = p[copied:]
+		n += copied
+		if len(writer.chunk) == cap(writer.chunk) {
+			writer.flush()
+		}
+	}
+	return n, writer.err
+}
+
+// Close writes the buffered chunk and the end frame
+func (writer *EncryptWriter) Close() error {
+	if writer.closed {
+		return writer.err
+	}
+	writer.closed = true
+	if len(writer.chunk) > 0 {
+		writer.flush()
+	}
+	if writer.err == nil {
+		writer.writeHeader()
+	}
+	if writer.err == nil {
+		writer.writeFrame(cryptFrameEnd, writer.digest.Sum(nil))
+	}
+	return writer.err
+}
+
+// flush encrypts and writes the buffered chunk
+func (writer *EncryptWriter) flush() {
+	writer.writeHeader()
+	if writer.err == nil {
+		writer.writeFrame(cryptFrameData, writer.chunk)
+	}
+	writer.chunk = writer.chunk[:0]
+}
+
+func (writer *EncryptWriter) writeHeader() {
+	if writer.started {
+		return
+	}
+	writer.started = true
+	header := cryptStreamHeader()
+	writer.digest.Write(header)
+	_, writer.err = writer.w.Write(header)
+}
+
+func (writer *EncryptWriter) writeFrame(kind byte, plaintext []byte) {
+	ciphertext, err := writer.provider.ClusterEncrypt(plaintext)
+	if err != nil {
+		writer.err = err
+		return
+	}
+	if len(ciphertext) > maxCryptFrameLength {
+		writer.err = fmt.Errorf("encrypted chunk of %d bytes: %w", len(ciphertext), errors.ERROR_INVALID_DATA)
+		return
+	}
+	frame := make([]byte, cryptFrameHeaderLength, cryptFrameHeaderLength+len(ciphertext))
+	frame[0] = kind
+	binary.LittleEndian.PutUint32(frame[1:], uint32(len(ciphertext)))
+	frame = append(frame, ciphertext...)
+	if kind == cryptFrameData {
+		writer.digest.Write(frame)
+	}
+	_, writer.err = writer.w.Write(frame)
+}
+
+// DecryptReader decrypts a stream written by EncryptWriter, see NewDecryptReader
+type DecryptReader struct {
+	provider   Crypter
+	r          io.Reader
+	digest     hash.Hash
+	plaintext  []byte
+	ciphertext []byte
+	started    bool
+	err        error
+}
+
+// NewDecryptReader returns a reader that decrypts r with provider.
+// Chunks are returned as they are decrypted, the integrity of the stream is
+// only known when Read returns io.EOF, any error means the data read so far
+// must be discarded. Reading stops after the end frame, r is not read past it
+func NewDecryptReader(provider Crypter, r io.Reader) *DecryptReader {
+	return &DecryptReader{
+		provider: provider,
+		r:        r,
+		digest:   sha256.New(),
+	}
+}
+
+func (reader *DecryptReader) Read(p []byte) (n int, err error) {
+	for len(reader.plaintext) == 0 && reader.err == nil {
+		reader.err = reader.readFrame()
+	}
+	n = copy(p, reader.plaintext)
+	reader.plaintext = reader.plaintext[n:]
+	if len(reader.plaintext) > 0 {
+		return n, nil
+	}
+	return n, reader.err
+}
+
+// readFull is io.ReadFull except a stream that ends early is io.ErrUnexpectedEOF
+func (reader *DecryptReader) readFull(buffer []byte) error {
+	_, err := io.ReadFull(reader.r, buffer)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("encrypted stream without end frame: %w", err)
+	}
+	return err
+}
+
+func (reader *DecryptReader) readHeader() error {
+	header := make([]byte, cryptHeaderLength)
+	if err := reader.readFull(header); err != nil {
+		return err
+	}
+	if string(header[:len(cryptStreamMagic)]) != cryptStreamMagic {
+		return fmt.Errorf("encrypted stream header %q: %w", header[:len(cryptStreamMagic)], errors.ERROR_INVALID_DATA)
+	}
+	if version := header[len(cryptStreamMagic)]; version != cryptStreamVersion {
+		return fmt.Errorf("encrypted stream version %d: %w", version, errors.ERROR_INVALID_DATA)
+	}
+	reader.digest.Write(header)
+	return nil
+}
+
+// readFrame reads and decrypts the next frame,
+// it returns io.EOF after the end frame matched the digest
+func (reader *DecryptReader) readFrame() error {
+	if !reader.started {
+		reader.started = true
+		if err := reader.readHeader(); err != nil {
+			return err
+		}
+	}
+
+	var frameHeader [cryptFrameHeaderLength]byte
+	if err := reader.readFull(frameHeader[:]); err != nil {
+		return err
+	}
+	kind := frameHeader[0]
+	length := binary.LittleEndian.Uint32(frameHeader[1:])
+	if kind != cryptFrameData && kind != cryptFrameEnd {
+		return fmt.Errorf("encrypted stream frame kind %d: %w", kind, errors.ERROR_INVALID_DATA)
+	}
+	if length > maxCryptFrameLength {
+		return fmt.Errorf("encrypted stream frame of %d bytes: %w", length, errors.ERROR_INVALID_DATA)
+	}
+	if uint32(cap(reader.ciphertext)) < length {
+		reader.ciphertext = make([]byte, length)
+	}
+	ciphertext := reader.ciphertext[:length]
+	if err := reader.readFull(ciphertext); err != nil {
+		return err
+	}
+
+	plaintext, err := reader.provider.ClusterDecrypt(ciphertext)
+	if err != nil {
+		return err
+	}
+	if kind == cryptFrameData {
+		reader.digest.Write(frameHeader[:])
+		reader.digest.Write(ciphertext)
+		reader.plaintext = plaintext
+		return nil
+	}
+	if subtle.ConstantTimeCompare(plaintext, reader.digest.Sum(nil)) != 1 {
+		return fmt.Errorf("encrypted stream integrity check failed: %w", errors.ERROR_INVALID_DATA)
+	}
+	return io.EOF
+}
+
+var _ Crypter = HCLUSCRYPTPROVIDER(0)
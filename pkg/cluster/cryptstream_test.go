@@ -0,0 +1,197 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCrypter xors with key behind a two byte tag, so ciphertext is longer
+// than plaintext and a chunk decrypted by another key is rejected
+type fakeCrypter struct {
+	key      byte
+	encrypts int
+	fail     error
+}
+
+func (crypter *fakeCrypter) ClusterEncrypt(data []byte) ([]byte, error) {
+	if crypter.fail != nil {
+		return nil, crypter.fail
+	}
+	crypter.encrypts++
+	encrypted := []byte{'f', crypter.key}
+	for _, b := range data {
+		encrypted = append(encrypted, b^crypter.key)
+	}
+	return encrypted, nil
+}
+
+func (crypter *fakeCrypter) ClusterDecrypt(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 'f' || data[1] != crypter.key {
+		return nil, errors.ERROR_INVALID_DATA
+	}
+	decrypted := make([]byte, 0, len(data)-2)
+	for _, b := range data[2:] {
+		decrypted = append(decrypted, b^crypter.key)
+	}
+	return decrypted, nil
+}
+
+func testPayload(size int) []byte {
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = byte(i*7 + i/CryptChunkSize)
+	}
+	return payload
+}
+
+func encryptStream(t *testing.T, crypter Crypter, payload []byte) []byte {
+	var stream bytes.Buffer
+	writer := NewEncryptWriter(crypter, &stream)
+	_, err := io.Copy(writer, bytes.NewReader(payload))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	return stream.Bytes()
+}
+
+// splitFrames returns the header and the frames of stream
+func splitFrames(stream []byte) (header []byte, frames [][]byte) {
+	header, stream = stream[:cryptHeaderLength], stream[cryptHeaderLength:]
+	for len(stream) > 0 {
+		length := cryptFrameHeaderLength + int(binary.LittleEndian.Uint32(stream[1:]))
+		frames = append(frames, stream[:length])
+		stream = stream[length:]
+	}
+	return
+}
+
+func joinFrames(header []byte, frames ...[]byte) []byte {
+	return bytes.Join(append([][]byte{header}, frames...), nil)
+}
+
+func TestCryptStreamRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, CryptChunkSize - 1, CryptChunkSize, CryptChunkSize + 1, 3*CryptChunkSize + 5} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			crypter := &fakeCrypter{key: 0x5a}
+			payload := testPayload(size)
+			stream := encryptStream(t, crypter, payload)
+
+			chunks := (size + CryptChunkSize - 1) / CryptChunkSize
+			assert.Equal(t, chunks+1, crypter.encrypts)
+			_, frames := splitFrames(stream)
+			assert.Equal(t, chunks+1, len(frames))
+
+			decrypted, err := ioutil.ReadAll(NewDecryptReader(crypter, bytes.NewReader(stream)))
+			assert.Nil(t, err)
+			assert.Equal(t, payload, append([]byte{}, decrypted...))
+		})
+	}
+}
+
+func TestCryptStreamFormat(t *testing.T) {
+	stream := encryptStream(t, &fakeCrypter{key: 1}, []byte{1, 2, 3})
+
+	header, frames := splitFrames(stream)
+	assert.Equal(t, []byte{'C', 'L', 'C', 'S', 1}, header)
+	if assert.Equal(t, 2, len(frames)) {
+		assert.Equal(t, []byte{cryptFrameData, 5, 0, 0, 0, 'f', 1, 0, 3, 2}, frames[0])
+		assert.Equal(t, byte(cryptFrameEnd), frames[1][0])
+		assert.Equal(t, cryptFrameHeaderLength+2+32, len(frames[1]))
+	}
+}
+
+func TestEncryptWriterStreams(t *testing.T) {
+	var stream bytes.Buffer
+	writer := NewEncryptWriter(&fakeCrypter{key: 3}, &stream)
+	payload := testPayload(2*CryptChunkSize + 10)
+	for i := 0; i < len(payload); i += 1000 {
+		end := i + 1000
+		if end > len(payload) {
+			end = len(payload)
+		}
+		n, err := writer.Write(payload[i:end])
+		assert.Nil(t, err)
+		assert.Equal(t, end-i, n)
+	}
+
+	_, frames := splitFrames(stream.Bytes())
+	assert.Equal(t, 2, len(frames), "full chunks are written before Close")
+
+	assert.Nil(t, writer.Close())
+	assert.Nil(t, writer.Close())
+	_, err := writer.Write([]byte{1})
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_STATE), err)
+}
+
+func TestEncryptWriterProviderError(t *testing.T) {
+	writer := NewEncryptWriter(&fakeCrypter{fail: errors.ERROR_ACCESS_DENIED}, ioutil.Discard)
+
+	_, err := writer.Write(testPayload(CryptChunkSize))
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, err)
+	_, err = writer.Write([]byte{1})
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, err)
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, writer.Close())
+}
+
+func TestDecryptReaderRejects(t *testing.T) {
+	crypter := &fakeCrypter{key: 9}
+	stream := encryptStream(t, crypter, testPayload(3*CryptChunkSize))
+	header, frames := splitFrames(stream)
+	end := frames[len(frames)-1]
+
+	flipped := append([]byte{}, stream...)
+	flipped[cryptHeaderLength+cryptFrameHeaderLength+10] ^= 1
+
+	oversized := append([]byte{}, header...)
+	oversized = append(oversized, cryptFrameData, 0xff, 0xff, 0xff, 0xff)
+
+	otherEnd := encryptStream(t, crypter, testPayload(10))
+	_, otherFrames := splitFrames(otherEnd)
+
+	tests := []struct {
+		name   string
+		stream []byte
+		err    error
+	}{
+		{"modified", flipped, errors.ERROR_INVALID_DATA},
+		{"reordered", joinFrames(header, frames[1], frames[0], frames[2], end), errors.ERROR_INVALID_DATA},
+		{"dropped", joinFrames(header, frames[0], frames[2], end), errors.ERROR_INVALID_DATA},
+		{"appended", joinFrames(header, frames[0], frames[1], frames[2], frames[2], end), errors.ERROR_INVALID_DATA},
+		{"other end frame", joinFrames(header, frames[0], frames[1], frames[2], otherFrames[1]), errors.ERROR_INVALID_DATA},
+		{"truncated", joinFrames(header, frames[0], frames[1], frames[2]), io.ErrUnexpectedEOF},
+		{"truncated frame", stream[:len(stream)-1], io.ErrUnexpectedEOF},
+		{"empty", nil, io.ErrUnexpectedEOF},
+		{"magic", joinFrames([]byte("XXXX\x01"), frames...), errors.ERROR_INVALID_DATA},
+		{"version", joinFrames([]byte("CLCS\x02"), frames...), errors.ERROR_INVALID_DATA},
+		{"kind", joinFrames(header, []byte{7, 0, 0, 0, 0}), errors.ERROR_INVALID_DATA},
+		{"oversized", oversized, errors.ERROR_INVALID_DATA},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ioutil.ReadAll(NewDecryptReader(crypter, bytes.NewReader(test.stream)))
+			assert.True(t, errors.Is(err, test.err), err)
+		})
+	}
+
+	_, err := ioutil.ReadAll(NewDecryptReader(&fakeCrypter{key: 10}, bytes.NewReader(stream)))
+	assert.Equal(t, errors.ERROR_INVALID_DATA, err, "another key")
+}
+
+func TestDecryptReaderStopsAtEndFrame(t *testing.T) {
+	payload := testPayload(100)
+	stream := encryptStream(t, &fakeCrypter{key: 4}, payload)
+	input := bytes.NewReader(append(append([]byte{}, stream...), "trailer"...))
+
+	decrypted, err := ioutil.ReadAll(NewDecryptReader(&fakeCrypter{key: 4}, input))
+	assert.Nil(t, err)
+	assert.Equal(t, payload, decrypted)
+
+	rest, _ := ioutil.ReadAll(input)
+	assert.Equal(t, "trailer", string(rest))
+}
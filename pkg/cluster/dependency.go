@@ -0,0 +1,274 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// DependencyExpression is a parsed resource dependency expression such as
+//
+//	([IP A] or [IP B]) and [Disk]
+//
+// It is one of DependencyResource, DependencyAnd or DependencyOr. A nil
+// DependencyExpression is a resource without dependencies
+type DependencyExpression interface {
+	// String returns the canonical form, nested operators are always parenthesized
+	String() string
+	// Resources returns the resource names in the order they appear
+	Resources() []string
+}
+
+// DependencyResource is a single resource, written [name]
+type DependencyResource string
+
+// DependencyAnd requires every operand
+type DependencyAnd []DependencyExpression
+
+// DependencyOr requires any operand
+type DependencyOr []DependencyExpression
+
+func (resource DependencyResource) String() string {
+	return "[" + string(resource) + "]"
+}
+
+// Resources returns the resource name
+func (resource DependencyResource) Resources() []string {
+	return []string{string(resource)}
+}
+
+func (and DependencyAnd) String() string {
+	return joinOperands(and, " and ")
+}
+
+// Resources returns the resource names of every operand
+func (and DependencyAnd) Resources() []string {
+	return operandResources(and)
+}
+
+func (or DependencyOr) String() string {
+	return joinOperands(or, " or ")
+}
+
+// Resources returns the resource names of every operand
+func (or DependencyOr) Resources() []string {
+	return operandResources(or)
+}
+
+func joinOperands(operands []DependencyExpression, separator string) string {
+	parts := make([]string, 0, len(operands))
+	for _, operand := range operands {
+		if _, ok := operand.(DependencyResource); ok {
+			parts = append(parts, operand.String())
+		} else {
+			parts = append(parts, "("+operand.String()+")")
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+func operandResources(operands []DependencyExpression) []string {
+	var names []string
+	for _, operand := range operands {
+		names = append(names, operand.Resources()...)
+	}
+	return names
+}
+
+// FormatDependencyExpression returns the canonical form of expression,
+// the empty string for nil
+func FormatDependencyExpression(expression DependencyExpression) string {
+	if expression == nil {
+		return ""
+	}
+	return expression.String()
+}
+
+// SimplifyDependencyExpression removes empty operators, unwraps operators with
+// a single operand and merges operands that use the same operator as their
+// parent, the result is nil when nothing is left
+func SimplifyDependencyExpression(expression DependencyExpression) DependencyExpression {
+	switch e := expression.(type) {
+	case DependencyAnd:
+		return simplifyOperands(e, func(operands []DependencyExpression) DependencyExpression { return DependencyAnd(operands) })
+	case DependencyOr:
+		return simplifyOperands(e, func(operands []DependencyExpression) DependencyExpression { return DependencyOr(operands) })
+	}
+	return expression
+}
+
+func simplifyOperands(operands []DependencyExpression, build func([]DependencyExpression) DependencyExpression) DependencyExpression {
+	var simplified []DependencyExpression
+	parent := build(nil)
+	for _, operand := range operands {
+		operand = SimplifyDependencyExpression(operand)
+		if operand == nil {
+			continue
+		}
+		switch o := operand.(type) {
+		case DependencyAnd:
+			if _, ok := parent.(DependencyAnd); ok {
+				simplified = append(simplified, o...)
+				continue
+			}
+		case DependencyOr:
+			if _, ok := parent.(DependencyOr); ok {
+				simplified = append(simplified, o...)
+				continue
+			}
+		}
+		simplified = append(simplified, operand)
+	}
+	switch len(simplified) {
+	case 0:
+		return nil
+	case 1:
+		return simplified[0]
+	}
+	return build(simplified)
+}
+
+// RemoveDependencyResource returns expression without the resource name,
+// names are case insensitive. The result is simplified
+func RemoveDependencyResource(expression DependencyExpression, name string) DependencyExpression {
+	switch e := expression.(type) {
+	case DependencyResource:
+		if strings.EqualFold(string(e), name) {
+			return nil
+		}
+	case DependencyAnd:
+		operands := make(DependencyAnd, 0, len(e))
+		for _, operand := range e {
+			operands = append(operands, RemoveDependencyResource(operand, name))
+		}
+		expression = operands
+	case DependencyOr:
+		operands := make(DependencyOr, 0, len(e))
+		for _, operand := range e {
+			operands = append(operands, RemoveDependencyResource(operand, name))
+		}
+		expression = operands
+	}
+	return SimplifyDependencyExpression(expression)
+}
+
+// dependencyParser is a recursive descent parser for
+//
+//	or       = and { "or" and }
+//	and      = operand { "and" operand }
+//	operand  = "[" name "]" | "(" or ")"
+//
+// keywords are case insensitive and and binds tighter than or
+type dependencyParser struct {
+	input string
+	pos   int
+}
+
+// ParseDependencyExpression parses expression, the empty string parses to nil.
+// The result is simplified so parentheses that do not change the meaning are dropped
+func ParseDependencyExpression(expression string) (DependencyExpression, error) {
+	p := &dependencyParser{input: expression}
+	p.skipSpace()
+	if p.pos == len(p.input) {
+		return nil, nil
+	}
+	parsed, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return SimplifyDependencyExpression(parsed), nil
+}
+
+func (p *dependencyParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("dependency expression %q at offset %d: %s: %w", p.input, p.pos, fmt.Sprintf(format, args...), errors.ERROR_INVALID_PARAMETER)
+}
+
+func (p *dependencyParser) skipSpace() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// keyword consumes word if it is next and followed by a delimiter
+func (p *dependencyParser) keyword(word string) bool {
+	p.skipSpace()
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(p.input[p.pos:end], word) {
+		return false
+	}
+	if end < len(p.input) && !strings.ContainsRune(" \t\r\n[(", rune(p.input[end])) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *dependencyParser) or() (DependencyExpression, error) {
+	first, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	operands := DependencyOr{first}
+	for p.keyword("or") {
+		next, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	return operands, nil
+}
+
+func (p *dependencyParser) and() (DependencyExpression, error) {
+	first, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	operands := DependencyAnd{first}
+	for p.keyword("and") {
+		next, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	return operands, nil
+}
+
+func (p *dependencyParser) operand() (DependencyExpression, error) {
+	p.skipSpace()
+	if p.pos == len(p.input) {
+		return nil, p.errorf("expected [ or (")
+	}
+	switch p.input[p.pos] {
+	case '[':
+		end := strings.IndexByte(p.input[p.pos:], ']')
+		if end < 0 {
+			return nil, p.errorf("unterminated resource name")
+		}
+		name := p.input[p.pos+1 : p.pos+end]
+		if name == "" {
+			return nil, p.errorf("empty resource name")
+		}
+		p.pos += end + 1
+		return DependencyResource(name), nil
+	case '(':
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos == len(p.input) || p.input[p.pos] != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		return inner, nil
+	}
+	return nil, p.errorf("expected [ or ( but found %q", p.input[p.pos])
+}
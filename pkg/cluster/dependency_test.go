@@ -0,0 +1,85 @@
+package cluster
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependencyExpression(t *testing.T) {
+	expression, err := ParseDependencyExpression("([IP A] or [IP B]) and [Disk]")
+	assert.Nil(t, err)
+	assert.Equal(t, DependencyAnd{DependencyOr{DependencyResource("IP A"), DependencyResource("IP B")}, DependencyResource("Disk")}, expression)
+	assert.Equal(t, "([IP A] or [IP B]) and [Disk]", expression.String())
+	assert.Equal(t, []string{"IP A", "IP B", "Disk"}, expression.Resources())
+}
+
+func TestParseDependencyExpressionCanonical(t *testing.T) {
+	tests := map[string]string{
+		"":                                  "",
+		"  ":                                "",
+		"[Disk]":                            "[Disk]",
+		"(([Disk]))":                        "[Disk]",
+		"[A] AND [B] and([C])":              "[A] and [B] and [C]",
+		"[A] or [B] and [C]":                "[A] or ([B] and [C])",
+		"([A] or [B]) or [C]":               "[A] or [B] or [C]",
+		"[A]and([B]Or[C])":                  "[A] and ([B] or [C])",
+		"[Name (with) parens] or [x and y]": "[Name (with) parens] or [x and y]",
+	}
+	for input, canonical := range tests {
+		expression, err := ParseDependencyExpression(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, canonical, FormatDependencyExpression(expression), input)
+
+		// the canonical form parses to the same expression
+		again, err := ParseDependencyExpression(canonical)
+		assert.Nil(t, err, canonical)
+		assert.Equal(t, expression, again, canonical)
+	}
+}
+
+func TestParseDependencyExpressionErrors(t *testing.T) {
+	for _, input := range []string{
+		"Disk",
+		"[Disk",
+		"[]",
+		"[A] and",
+		"[A] or or [B]",
+		"([A] or [B]",
+		"[A] [B]",
+		"[A] andor [B]",
+		"[A])",
+	} {
+		_, err := ParseDependencyExpression(input)
+		assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER), input)
+	}
+}
+
+func TestRemoveDependencyResource(t *testing.T) {
+	expression, err := ParseDependencyExpression("([IP A] or [IP B]) and [Disk]")
+	assert.Nil(t, err)
+
+	removed := RemoveDependencyResource(expression, "ip b")
+	assert.Equal(t, "[IP A] and [Disk]", FormatDependencyExpression(removed))
+	removed = RemoveDependencyResource(removed, "Disk")
+	assert.Equal(t, DependencyResource("IP A"), removed)
+	assert.Nil(t, RemoveDependencyResource(removed, "IP A"))
+
+	// the input is not modified
+	assert.Equal(t, "([IP A] or [IP B]) and [Disk]", expression.String())
+}
+
+func TestBuildDependencyExpression(t *testing.T) {
+	expression := DependencyAnd{
+		DependencyResource("Disk"),
+		DependencyAnd{DependencyResource("Share")},
+		DependencyOr{},
+		DependencyOr{DependencyResource("IP A"), DependencyOr{DependencyResource("IP B")}},
+	}
+	simplified := SimplifyDependencyExpression(expression)
+	assert.Equal(t, "[Disk] and [Share] and ([IP A] or [IP B])", FormatDependencyExpression(simplified))
+	assert.Nil(t, SimplifyDependencyExpression(DependencyOr{DependencyAnd{}}))
+	assert.Equal(t, "", FormatDependencyExpression(nil))
+}
@@ -0,0 +1,6 @@
+package cluster
+
+type (
+	ClusterEnumHandle   uintptr
+	ClusterEnumExHandle uintptr
+)
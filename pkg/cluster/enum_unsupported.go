@@ -0,0 +1,66 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import "github.com/KnicKnic/go-windows/pkg/errors"
+
+func (cluster ClusterHandle) OpenEnum(enumType ClusterEnumType) (ClusterEnumHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle ClusterEnumHandle) Count() uint32 {
+	return 0
+}
+
+func (handle ClusterEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	return ClusterEnumItem{}, errors.ErrNotSupported
+}
+
+func (handle ClusterEnumHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) OpenEnumEx(enumType ClusterEnumType) (ClusterEnumExHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle ClusterEnumExHandle) Count() uint32 {
+	return 0
+}
+
+func (handle ClusterEnumExHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	return ClusterEnumItem{}, errors.ErrNotSupported
+}
+
+func (handle ClusterEnumExHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) Enumerate(enumType ClusterEnumType) (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) Nodes() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) Groups() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) Resources() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) Networks() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) NetInterfaces() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) ResourceTypes() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
@@ -0,0 +1,210 @@
+package cluster
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+var (
+	procnativeClusterOpenEnum       = clusapi_dll.NewProc("ClusterOpenEnum")
+	procnativeClusterGetEnumCount   = clusapi_dll.NewProc("ClusterGetEnumCount")
+	procnativeClusterEnum           = clusapi_dll.NewProc("ClusterEnum")
+	procnativeClusterCloseEnum      = clusapi_dll.NewProc("ClusterCloseEnum")
+	procnativeClusterOpenEnumEx     = clusapi_dll.NewProc("ClusterOpenEnumEx")
+	procnativeClusterGetEnumCountEx = clusapi_dll.NewProc("ClusterGetEnumCountEx")
+	procnativeClusterEnumEx         = clusapi_dll.NewProc("ClusterEnumEx")
+	procnativeClusterCloseEnumEx    = clusapi_dll.NewProc("ClusterCloseEnumEx")
+)
+
+// clusterEnumItem is CLUSTER_ENUM_ITEM
+type clusterEnumItem struct {
+	dwVersion uint32
+	dwType    uint32
+	cbId      uint32
+	lpszId    *uint16
+	cbName    uint32
+	lpszName  *uint16
+}
+
+func clusterOpenEnum(cluster ClusterHandle, enumType ClusterEnumType) (ClusterEnumHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeClusterOpenEnum.Addr(), 2, uintptr(cluster), uintptr(enumType), 0)
+	handle := ClusterEnumHandle(r0)
+	return handle, handles.opened(clusterEnumHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// OpenEnum opens an enumeration of the objects of enumType
+func (cluster ClusterHandle) OpenEnum(enumType ClusterEnumType) (ClusterEnumHandle, error) {
+	enum, err := clusterOpenEnum(cluster, enumType)
+	return enum, errors.Wrap(procnativeClusterOpenEnum.Name, "", err)
+}
+
+// Count returns the number of objects in the enumeration
+func (handle ClusterEnumHandle) Count() uint32 {
+	r0, _, _ := syscall.Syscall(procnativeClusterGetEnumCount.Addr(), 1, uintptr(handle), 0, 0)
+	return uint32(r0)
+}
+
+func clusterEnum(handle ClusterEnumHandle, index uint32) (item ClusterEnumItem, err error) {
+	nameCCh := uint32(50)
+
+	var dwType uint32
+	var nameArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nameCCh += 2
+		nameArr = make([]uint16, nameCCh)
+		r0, _, _ := syscall.Syscall6(procnativeClusterEnum.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&dwType)),
+			uintptr(unsafe.Pointer(&nameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	item.Type = ClusterEnumType(dwType)
+	item.Name = syscall.UTF16ToString(nameArr[:nameCCh])
+	return
+}
+
+// Enum returns the object at index, ID is not set
+// returns ERROR_NO_MORE_ITEMS after the last object
+func (handle ClusterEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	item, err := clusterEnum(handle, index)
+	return item, wrapEnumError(procnativeClusterEnum.Name, err)
+}
+
+func (handle ClusterEnumHandle) item(index uint32) (ClusterEnumItem, error) {
+	return handle.Enum(index)
+}
+
+func clusterCloseEnum(handle ClusterEnumHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterCloseEnum.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle ClusterEnumHandle) Close() error {
+	err := handles.closed(clusterEnumHandleKind, uintptr(handle), func() error { return clusterCloseEnum(handle) })
+	return errors.Wrap(procnativeClusterCloseEnum.Name, "", err)
+}
+
+func clusterOpenEnumEx(cluster ClusterHandle, enumType ClusterEnumType) (ClusterEnumExHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeClusterOpenEnumEx.Addr(), 3, uintptr(cluster), uintptr(enumType), 0)
+	handle := ClusterEnumExHandle(r0)
+	return handle, handles.opened(clusterEnumExHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// OpenEnumEx opens an enumeration of the objects of enumType
+// that also returns the object ids
+func (cluster ClusterHandle) OpenEnumEx(enumType ClusterEnumType) (ClusterEnumExHandle, error) {
+	enum, err := clusterOpenEnumEx(cluster, enumType)
+	return enum, errors.Wrap(procnativeClusterOpenEnumEx.Name, "", err)
+}
+
+// Count returns the number of objects in the enumeration
+func (handle ClusterEnumExHandle) Count() uint32 {
+	r0, _, _ := syscall.Syscall(procnativeClusterGetEnumCountEx.Addr(), 1, uintptr(handle), 0, 0)
+	return uint32(r0)
+}
+
+func clusterEnumEx(handle ClusterEnumExHandle, index uint32) (item ClusterEnumItem, err error) {
+	itemCB := uint32(unsafe.Sizeof(clusterEnumItem{}) + 256)
+
+	// the strings are returned in the same buffer after the struct,
+	// allocate as uint64 to keep the struct aligned
+	var buffer []uint64
+
+	err = retryMoreData(func() syscall.Errno {
+		buffer = make([]uint64, (itemCB+7)/8)
+		itemCB = uint32(len(buffer) * 8)
+		r0, _, _ := syscall.Syscall6(procnativeClusterEnumEx.Addr(),
+			4,
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&buffer[0])),
+			uintptr(unsafe.Pointer(&itemCB)),
+			0,
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	native := (*clusterEnumItem)(unsafe.Pointer(&buffer[0]))
+	item.Version = native.dwVersion
+	item.Type = ClusterEnumType(native.dwType)
+	item.ID = utf16PtrToString(native.lpszId)
+	item.Name = utf16PtrToString(native.lpszName)
+	return
+}
+
+// Enum returns the object at index
+// returns ERROR_NO_MORE_ITEMS after the last object
+func (handle ClusterEnumExHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	item, err := clusterEnumEx(handle, index)
+	return item, wrapEnumError(procnativeClusterEnumEx.Name, err)
+}
+
+func (handle ClusterEnumExHandle) item(index uint32) (ClusterEnumItem, error) {
+	return handle.Enum(index)
+}
+
+func clusterCloseEnumEx(handle ClusterEnumExHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterCloseEnumEx.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle ClusterEnumExHandle) Close() error {
+	err := handles.closed(clusterEnumExHandleKind, uintptr(handle), func() error { return clusterCloseEnumEx(handle) })
+	return errors.Wrap(procnativeClusterCloseEnumEx.Name, "", err)
+}
+
+// Enumerate iterates the objects of enumType with their ids
+func (cluster ClusterHandle) Enumerate(enumType ClusterEnumType) (*ClusterIterator, error) {
+	handle, err := cluster.OpenEnumEx(enumType)
+	if err != nil {
+		return nil, err
+	}
+	return newClusterIterator(handle), nil
+}
+
+// Nodes iterates the nodes of the cluster
+func (cluster ClusterHandle) Nodes() (*ClusterIterator, error) {
+	return cluster.Enumerate(CLUSTER_ENUM_NODE)
+}
+
+// Groups iterates the groups of the cluster
+func (cluster ClusterHandle) Groups() (*ClusterIterator, error) {
+	return cluster.Enumerate(CLUSTER_ENUM_GROUP)
+}
+
+// Resources iterates the resources of the cluster
+func (cluster ClusterHandle) Resources() (*ClusterIterator, error) {
+	return cluster.Enumerate(CLUSTER_ENUM_RESOURCE)
+}
+
+// Networks iterates the networks of the cluster
+func (cluster ClusterHandle) Networks() (*ClusterIterator, error) {
+	return cluster.Enumerate(CLUSTER_ENUM_NETWORK)
+}
+
+// NetInterfaces iterates the network interfaces of the cluster
+func (cluster ClusterHandle) NetInterfaces() (*ClusterIterator, error) {
+	return cluster.Enumerate(CLUSTER_ENUM_NETINTERFACE)
+}
+
+// ResourceTypes iterates the resource types of the cluster
+func (cluster ClusterHandle) ResourceTypes() (*ClusterIterator, error) {
+	return cluster.Enumerate(CLUSTER_ENUM_RESTYPE)
+}
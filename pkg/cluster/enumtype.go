@@ -0,0 +1,154 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// ClusterEnumType selects the objects enumerated by OpenEnum and OpenEnumEx
+// the values can be or'ed together
+type ClusterEnumType uint32
+
+const (
+	CLUSTER_ENUM_NODE                   ClusterEnumType = 0x00000001
+	CLUSTER_ENUM_RESTYPE                ClusterEnumType = 0x00000002
+	CLUSTER_ENUM_RESOURCE               ClusterEnumType = 0x00000004
+	CLUSTER_ENUM_GROUP                  ClusterEnumType = 0x00000008
+	CLUSTER_ENUM_NETWORK                ClusterEnumType = 0x00000010
+	CLUSTER_ENUM_NETINTERFACE           ClusterEnumType = 0x00000020
+	CLUSTER_ENUM_SHARED_VOLUME_GROUP    ClusterEnumType = 0x20000000
+	CLUSTER_ENUM_SHARED_VOLUME_RESOURCE ClusterEnumType = 0x40000000
+	CLUSTER_ENUM_INTERNAL_NETWORK       ClusterEnumType = 0x80000000
+	CLUSTER_ENUM_ALL                    ClusterEnumType = CLUSTER_ENUM_NODE | CLUSTER_ENUM_RESTYPE | CLUSTER_ENUM_RESOURCE | CLUSTER_ENUM_GROUP | CLUSTER_ENUM_NETWORK | CLUSTER_ENUM_NETINTERFACE
+)
+
+var clusterEnumTypeNames = []struct {
+	enumType ClusterEnumType
+	name     string
+}{
+	{CLUSTER_ENUM_NODE, "CLUSTER_ENUM_NODE"},
+	{CLUSTER_ENUM_RESTYPE, "CLUSTER_ENUM_RESTYPE"},
+	{CLUSTER_ENUM_RESOURCE, "CLUSTER_ENUM_RESOURCE"},
+	{CLUSTER_ENUM_GROUP, "CLUSTER_ENUM_GROUP"},
+	{CLUSTER_ENUM_NETWORK, "CLUSTER_ENUM_NETWORK"},
+	{CLUSTER_ENUM_NETINTERFACE, "CLUSTER_ENUM_NETINTERFACE"},
+	{CLUSTER_ENUM_SHARED_VOLUME_GROUP, "CLUSTER_ENUM_SHARED_VOLUME_GROUP"},
+	{CLUSTER_ENUM_SHARED_VOLUME_RESOURCE, "CLUSTER_ENUM_SHARED_VOLUME_RESOURCE"},
+	{CLUSTER_ENUM_INTERNAL_NETWORK, "CLUSTER_ENUM_INTERNAL_NETWORK"},
+}
+
+func (enumType ClusterEnumType) String() string {
+	if enumType == 0 {
+		return "0"
+	}
+	var names []string
+	rest := enumType
+	for _, n := range clusterEnumTypeNames {
+		if enumType&n.enumType != 0 {
+			names = append(names, n.name)
+			rest &^= n.enumType
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// ClusterEnumItem is an object returned by a cluster enumeration
+// ID is only set by the Ex enumeration
+type ClusterEnumItem struct {
+	Version uint32
+	Type    ClusterEnumType
+	ID      string
+	Name    string
+	// ObjectEnumType is the type a group, node or resource enumeration
+	// returned, a ClusterGroupEnumType, ClusterNodeEnumType or
+	// ClusterResourceEnumType. It tells apart the types Type maps together,
+	// such as the dependencies and dependents of a resource, and is 0 for
+	// cluster enumerations
+	ObjectEnumType uint32
+}
+
+// clusterEnumerator is implemented by the enumeration handles
+type clusterEnumerator interface {
+	item(index uint32) (ClusterEnumItem, error)
+	Close() error
+}
+
+// ClusterIterator walks a cluster enumeration
+//
+//	for it.Next() {
+//		fmt.Println(it.Item().Name)
+//	}
+//	err = it.Err()
+type ClusterIterator struct {
+	enum    clusterEnumerator
+	index   uint32
+	current ClusterEnumItem
+	err     error
+	done    bool
+}
+
+func newClusterIterator(enum clusterEnumerator) *ClusterIterator {
+	return &ClusterIterator{enum: enum}
+}
+
+// Next advances to the next item, it returns false at the end or on error
+// the enumeration is closed when Next returns false
+func (it *ClusterIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	item, err := it.enum.item(it.index)
+	if err != nil {
+		if err != ERROR_NO_MORE_ITEMS {
+			it.err = err
+		}
+		it.Close()
+		return false
+	}
+	it.index++
+	it.current = item
+	return true
+}
+
+// Item returns the current item
+func (it *ClusterIterator) Item() ClusterEnumItem {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration
+func (it *ClusterIterator) Err() error {
+	return it.err
+}
+
+// Close closes the enumeration, it is safe to call more than once
+// and only the first call returns the error of closing the handle
+func (it *ClusterIterator) Close() error {
+	if it.done {
+		return nil
+	}
+	it.done = true
+	return it.enum.Close()
+}
+
+// All reads the remaining items and closes the enumeration
+func (it *ClusterIterator) All() ([]ClusterEnumItem, error) {
+	items := []ClusterEnumItem{}
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// wrapEnumError is errors.Wrap except for ERROR_NO_MORE_ITEMS, it ends an
+// enumeration like io.EOF ends a read and is returned as is
+func wrapEnumError(api string, err error) error {
+	if err == ERROR_NO_MORE_ITEMS {
+		return err
+	}
+	return errors.Wrap(api, "", err)
+}
@@ -0,0 +1,85 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// enumRecorder is a clusterEnumerator over a fixed list of items
+type enumRecorder struct {
+	items  []ClusterEnumItem
+	failAt int
+	closed int
+}
+
+func (enum *enumRecorder) item(index uint32) (ClusterEnumItem, error) {
+	if int(index) == enum.failAt {
+		return ClusterEnumItem{}, errors.ERROR_INVALID_HANDLE
+	}
+	if int(index) >= len(enum.items) {
+		return ClusterEnumItem{}, ERROR_NO_MORE_ITEMS
+	}
+	return enum.items[index], nil
+}
+
+func (enum *enumRecorder) Close() error {
+	enum.closed++
+	return nil
+}
+
+func TestClusterEnumTypeString(t *testing.T) {
+	assert.Equal(t, "CLUSTER_ENUM_NODE", CLUSTER_ENUM_NODE.String())
+	assert.Equal(t, "CLUSTER_ENUM_RESOURCE|CLUSTER_ENUM_GROUP", (CLUSTER_ENUM_GROUP | CLUSTER_ENUM_RESOURCE).String())
+	assert.Equal(t, "CLUSTER_ENUM_NETINTERFACE|0x100", (CLUSTER_ENUM_NETINTERFACE | 0x100).String())
+	assert.Equal(t, "0", ClusterEnumType(0).String())
+}
+
+func TestClusterIterator(t *testing.T) {
+	enum := &enumRecorder{
+		items: []ClusterEnumItem{
+			{Version: 1, Type: CLUSTER_ENUM_GROUP, ID: "id-1", Name: "g1"},
+			{Version: 1, Type: CLUSTER_ENUM_GROUP, ID: "id-2", Name: "g2"},
+		},
+		failAt: -1,
+	}
+	it := newClusterIterator(enum)
+	items, err := it.All()
+	assert.Nil(t, err)
+	assert.Equal(t, enum.items, items)
+	assert.Equal(t, 1, enum.closed)
+
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Close())
+	assert.Equal(t, 1, enum.closed)
+}
+
+func TestClusterIteratorError(t *testing.T) {
+	enum := &enumRecorder{
+		items:  []ClusterEnumItem{{Name: "n1"}, {Name: "n2"}},
+		failAt: 1,
+	}
+	it := newClusterIterator(enum)
+	assert.True(t, it.Next())
+	assert.Equal(t, "n1", it.Item().Name)
+	assert.False(t, it.Next())
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, it.Err())
+	assert.Equal(t, 1, enum.closed)
+
+	empty := &enumRecorder{failAt: -1}
+	items, err := newClusterIterator(empty).All()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
+
+func TestWrapEnumError(t *testing.T) {
+	assert.Nil(t, wrapEnumError("ClusterEnum", nil))
+	assert.Equal(t, ERROR_NO_MORE_ITEMS, wrapEnumError("ClusterEnum", ERROR_NO_MORE_ITEMS))
+
+	err := wrapEnumError("ClusterEnum", errors.ERROR_INVALID_HANDLE)
+	var clusterErr *errors.ClusterError
+	assert.True(t, errors.As(err, &clusterErr))
+	assert.Equal(t, "ClusterEnum", clusterErr.API)
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_HANDLE))
+}
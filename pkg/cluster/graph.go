@@ -0,0 +1,420 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// GraphResource is a resource of a DependencyGraph
+type GraphResource struct {
+	Name       string
+	Group      string
+	State      ClusterResourceState
+	Dependency DependencyExpression
+}
+
+// GraphEdge is a dependency of From on To. Required is false when To is
+// one of the alternatives of an or and From can stay online without it
+type GraphEdge struct {
+	From     string
+	To       string
+	Required bool
+}
+
+// DependencyGraph is an in memory graph of the resources of a cluster, their
+// groups and dependencies. Resource and group names are case insensitive
+type DependencyGraph struct {
+	groups    []string
+	resources []GraphResource
+	index     map[string]int
+	edges     []GraphEdge
+}
+
+// DependencyCycleError is returned when the dependencies of the graph form a
+// cycle, Cycle lists the resources in the cycle starting and ending with the same one
+type DependencyCycleError struct {
+	Cycle []string
+}
+
+func (err *DependencyCycleError) Error() string {
+	return "dependency cycle " + strings.Join(err.Cycle, " -> ")
+}
+
+// Unwrap returns ERROR_CIRCULAR_DEPENDENCY
+func (err *DependencyCycleError) Unwrap() error {
+	return errors.ERROR_CIRCULAR_DEPENDENCY
+}
+
+func graphKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// NewDependencyGraph builds a graph of groups and resources, groups of resources
+// missing from groups are added. Resources must be unique and every dependency
+// must be one of resources
+func NewDependencyGraph(groups []string, resources []GraphResource) (*DependencyGraph, error) {
+	graph := &DependencyGraph{index: make(map[string]int, len(resources))}
+	for _, group := range groups {
+		graph.addGroup(group)
+	}
+	for i, resource := range resources {
+		key := graphKey(resource.Name)
+		if _, ok := graph.index[key]; ok {
+			return nil, fmt.Errorf("resource %s: %w", resource.Name, errors.ERROR_ALREADY_EXISTS)
+		}
+		graph.index[key] = i
+		graph.resources = append(graph.resources, resource)
+		if resource.Group != "" {
+			graph.addGroup(resource.Group)
+		}
+	}
+	for _, resource := range graph.resources {
+		for _, dependency := range uniqueNames(dependencyNames(resource.Dependency)) {
+			to, ok := graph.lookup(dependency)
+			if !ok {
+				return nil, fmt.Errorf("resource %s depends on %s: %w", resource.Name, dependency, errors.ERROR_RESOURCE_NOT_FOUND)
+			}
+			required := !evaluateDependency(resource.Dependency, func(name string) bool {
+				return !strings.EqualFold(name, dependency)
+			})
+			graph.edges = append(graph.edges, GraphEdge{From: resource.Name, To: to.Name, Required: required})
+		}
+	}
+	return graph, nil
+}
+
+func dependencyNames(expression DependencyExpression) []string {
+	if expression == nil {
+		return nil
+	}
+	return expression.Resources()
+}
+
+// evaluateDependency returns true when expression is satisfied by the
+// resources for which online returns true, nil is always satisfied
+func evaluateDependency(expression DependencyExpression, online func(string) bool) bool {
+	switch e := expression.(type) {
+	case DependencyResource:
+		return online(string(e))
+	case DependencyAnd:
+		for _, operand := range e {
+			if !evaluateDependency(operand, online) {
+				return false
+			}
+		}
+		return true
+	case DependencyOr:
+		for _, operand := range e {
+			if evaluateDependency(operand, online) {
+				return true
+			}
+		}
+		return len(e) == 0
+	}
+	return true
+}
+
+func (graph *DependencyGraph) addGroup(group string) {
+	if !containsName(graph.groups, group) {
+		graph.groups = append(graph.groups, group)
+	}
+}
+
+func (graph *DependencyGraph) lookup(name string) (GraphResource, bool) {
+	i, ok := graph.index[graphKey(name)]
+	if !ok {
+		return GraphResource{}, false
+	}
+	return graph.resources[i], true
+}
+
+// Resource returns the resource called name
+func (graph *DependencyGraph) Resource(name string) (GraphResource, bool) {
+	return graph.lookup(name)
+}
+
+// Resources returns the resources in the order they were added
+func (graph *DependencyGraph) Resources() []GraphResource {
+	return append([]GraphResource(nil), graph.resources...)
+}
+
+// Groups returns the groups in the order they were added
+func (graph *DependencyGraph) Groups() []string {
+	return append([]string(nil), graph.groups...)
+}
+
+// GroupResources returns the names of the resources in group
+func (graph *DependencyGraph) GroupResources(group string) []string {
+	var names []string
+	for _, resource := range graph.resources {
+		if strings.EqualFold(resource.Group, group) {
+			names = append(names, resource.Name)
+		}
+	}
+	return names
+}
+
+// Edges returns every dependency of the graph
+func (graph *DependencyGraph) Edges() []GraphEdge {
+	return append([]GraphEdge(nil), graph.edges...)
+}
+
+// DependsOn returns the names of the resources name directly depends on
+func (graph *DependencyGraph) DependsOn(name string) []string {
+	var names []string
+	for _, edge := range graph.edges {
+		if strings.EqualFold(edge.From, name) {
+			names = append(names, edge.To)
+		}
+	}
+	return names
+}
+
+// Dependents returns the names of the resources that directly depend on name
+func (graph *DependencyGraph) Dependents(name string) []string {
+	var names []string
+	for _, edge := range graph.edges {
+		if strings.EqualFold(edge.To, name) {
+			names = append(names, edge.From)
+		}
+	}
+	return names
+}
+
+// FindCycle returns a dependency cycle starting and ending with the
+// same resource, nil when the graph has no cycle
+func (graph *DependencyGraph) FindCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make([]int, len(graph.resources))
+	var stack []string
+	var cycle []string
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		marks[i] = visiting
+		stack = append(stack, graph.resources[i].Name)
+		for _, dependency := range graph.DependsOn(graph.resources[i].Name) {
+			j := graph.index[graphKey(dependency)]
+			switch marks[j] {
+			case visiting:
+				for k, name := range stack {
+					if strings.EqualFold(name, dependency) {
+						cycle = append(append([]string(nil), stack[k:]...), name)
+						return true
+					}
+				}
+			case unvisited:
+				if visit(j) {
+					return true
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		marks[i] = visited
+		return false
+	}
+	for i := range graph.resources {
+		if marks[i] == unvisited && visit(i) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// StartOrder returns the resource names ordered so every resource comes after
+// the resources it depends on, resources without an order between them keep
+// the order they were added. It returns a *DependencyCycleError on a cycle
+func (graph *DependencyGraph) StartOrder() ([]string, error) {
+	if cycle := graph.FindCycle(); cycle != nil {
+		return nil, &DependencyCycleError{Cycle: cycle}
+	}
+	pending := make([]int, len(graph.resources))
+	for _, edge := range graph.edges {
+		pending[graph.index[graphKey(edge.From)]]++
+	}
+	order := make([]string, 0, len(graph.resources))
+	started := make([]bool, len(graph.resources))
+	for len(order) < len(graph.resources) {
+		for i, resource := range graph.resources {
+			if started[i] || pending[i] != 0 {
+				continue
+			}
+			started[i] = true
+			order = append(order, resource.Name)
+			for _, dependent := range graph.Dependents(resource.Name) {
+				pending[graph.index[graphKey(dependent)]]--
+			}
+			break
+		}
+	}
+	return order, nil
+}
+
+// StopOrder returns the start order reversed, dependents stop before their dependencies
+func (graph *DependencyGraph) StopOrder() ([]string, error) {
+	order, err := graph.StartOrder()
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// Impact returns the resources that go offline if name fails, in the order they
+// were added. A resource with an or dependency stays online while one of the
+// alternatives does. Only dependencies are followed, failover policies are not
+func (graph *DependencyGraph) Impact(name string) ([]string, error) {
+	if _, ok := graph.lookup(name); !ok {
+		return nil, fmt.Errorf("resource %s: %w", name, errors.ERROR_RESOURCE_NOT_FOUND)
+	}
+	failed := map[string]bool{graphKey(name): true}
+	online := func(n string) bool { return !failed[graphKey(n)] }
+	for changed := true; changed; {
+		changed = false
+		for _, resource := range graph.resources {
+			key := graphKey(resource.Name)
+			if failed[key] || evaluateDependency(resource.Dependency, online) {
+				continue
+			}
+			failed[key] = true
+			changed = true
+		}
+	}
+	var impact []string
+	for _, resource := range graph.resources {
+		if failed[graphKey(resource.Name)] && !strings.EqualFold(resource.Name, name) {
+			impact = append(impact, resource.Name)
+		}
+	}
+	return impact, nil
+}
+
+// resourceID returns a stable identifier for the export
+// formats that do not allow arbitrary names
+func (graph *DependencyGraph) resourceID(name string) string {
+	return fmt.Sprintf("r%d", graph.index[graphKey(name)])
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// DOT returns the graph in Graphviz DOT, groups are clusters and edges point from a
+// resource to its dependency. Dependencies that are alternatives of an or are dashed
+func (graph *DependencyGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph cluster {\n")
+	for i, group := range graph.groups {
+		fmt.Fprintf(&b, "\tsubgraph cluster_%d {\n\t\tlabel=%s;\n", i, dotQuote(group))
+		for _, name := range graph.GroupResources(group) {
+			fmt.Fprintf(&b, "\t\t%s;\n", dotQuote(name))
+		}
+		b.WriteString("\t}\n")
+	}
+	for _, resource := range graph.resources {
+		if resource.Group == "" {
+			fmt.Fprintf(&b, "\t%s;\n", dotQuote(resource.Name))
+		}
+	}
+	for _, edge := range graph.edges {
+		style := ""
+		if !edge.Required {
+			style = " [style=dashed]"
+		}
+		fmt.Fprintf(&b, "\t%s -> %s%s;\n", dotQuote(edge.From), dotQuote(edge.To), style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func mermaidLabel(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+}
+
+// Mermaid returns the graph as a Mermaid flowchart, groups are subgraphs
+// and dependencies that are alternatives of an or are dotted
+func (graph *DependencyGraph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+	for i, group := range graph.groups {
+		fmt.Fprintf(&b, "\tsubgraph g%d[%s]\n", i, mermaidLabel(group))
+		for _, name := range graph.GroupResources(group) {
+			fmt.Fprintf(&b, "\t\t%s[%s]\n", graph.resourceID(name), mermaidLabel(name))
+		}
+		b.WriteString("\tend\n")
+	}
+	for _, resource := range graph.resources {
+		if resource.Group == "" {
+			fmt.Fprintf(&b, "\t%s[%s]\n", graph.resourceID(resource.Name), mermaidLabel(resource.Name))
+		}
+	}
+	for _, edge := range graph.edges {
+		arrow := "-->"
+		if !edge.Required {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "\t%s %s %s\n", graph.resourceID(edge.From), arrow, graph.resourceID(edge.To))
+	}
+	return b.String()
+}
+
+type graphJSON struct {
+	Groups    []graphGroupJSON    `json:"groups"`
+	Resources []graphResourceJSON `json:"resources"`
+	Edges     []graphEdgeJSON     `json:"edges"`
+}
+
+type graphGroupJSON struct {
+	Name      string   `json:"name"`
+	Resources []string `json:"resources"`
+}
+
+type graphResourceJSON struct {
+	Name       string `json:"name"`
+	Group      string `json:"group,omitempty"`
+	State      string `json:"state"`
+	Dependency string `json:"dependency,omitempty"`
+}
+
+type graphEdgeJSON struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Required bool   `json:"required"`
+}
+
+// MarshalJSON encodes the groups, resources and edges of the graph,
+// dependency expressions are in their canonical form
+func (graph *DependencyGraph) MarshalJSON() ([]byte, error) {
+	out := graphJSON{
+		Groups:    make([]graphGroupJSON, 0, len(graph.groups)),
+		Resources: make([]graphResourceJSON, 0, len(graph.resources)),
+		Edges:     make([]graphEdgeJSON, 0, len(graph.edges)),
+	}
+	for _, group := range graph.groups {
+		resources := graph.GroupResources(group)
+		if resources == nil {
+			resources = []string{}
+		}
+		out.Groups = append(out.Groups, graphGroupJSON{Name: group, Resources: resources})
+	}
+	for _, resource := range graph.resources {
+		out.Resources = append(out.Resources, graphResourceJSON{
+			Name:       resource.Name,
+			Group:      resource.Group,
+			State:      resource.State.String(),
+			Dependency: FormatDependencyExpression(resource.Dependency),
+		})
+	}
+	for _, edge := range graph.edges {
+		out.Edges = append(out.Edges, graphEdgeJSON(edge))
+	}
+	return json.Marshal(out)
+}
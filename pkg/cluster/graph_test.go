@@ -0,0 +1,180 @@
+package cluster
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseDependency(t *testing.T, expression string) DependencyExpression {
+	parsed, err := ParseDependencyExpression(expression)
+	assert.Nil(t, err, expression)
+	return parsed
+}
+
+// testGraph is a file server with a network name that can use either subnet
+func testGraph(t *testing.T) *DependencyGraph {
+	graph, err := NewDependencyGraph([]string{"Cluster Group", "Available Storage"}, []GraphResource{
+		{Name: "Share", Group: "FS", State: ClusterResourceOnline, Dependency: mustParseDependency(t, "[Name] and [Disk]")},
+		{Name: "Name", Group: "FS", State: ClusterResourceOnline, Dependency: mustParseDependency(t, "[IP A] or [IP B]")},
+		{Name: "IP A", Group: "FS", State: ClusterResourceOnline},
+		{Name: "IP B", Group: "FS", State: ClusterResourceOffline},
+		{Name: "Disk", Group: "FS", State: ClusterResourceOnline},
+		{Name: "Witness", Group: "Cluster Group", State: ClusterResourceOnline},
+	})
+	assert.Nil(t, err)
+	return graph
+}
+
+func TestDependencyGraph(t *testing.T) {
+	graph := testGraph(t)
+	assert.Equal(t, []string{"Cluster Group", "Available Storage", "FS"}, graph.Groups())
+	assert.Equal(t, []string{"Witness"}, graph.GroupResources("cluster group"))
+	assert.Nil(t, graph.GroupResources("Available Storage"))
+	assert.Equal(t, []string{"Name", "Disk"}, graph.DependsOn("share"))
+	assert.Equal(t, []string{"Share"}, graph.Dependents("Disk"))
+	assert.Equal(t, []GraphEdge{
+		{From: "Share", To: "Name", Required: true},
+		{From: "Share", To: "Disk", Required: true},
+		{From: "Name", To: "IP A", Required: false},
+		{From: "Name", To: "IP B", Required: false},
+	}, graph.Edges())
+
+	resource, ok := graph.Resource("ip b")
+	assert.True(t, ok)
+	assert.Equal(t, ClusterResourceOffline, resource.State)
+}
+
+func TestDependencyGraphErrors(t *testing.T) {
+	_, err := NewDependencyGraph(nil, []GraphResource{{Name: "A"}, {Name: "a"}})
+	assert.True(t, goerrors.Is(err, errors.ERROR_ALREADY_EXISTS))
+
+	_, err = NewDependencyGraph(nil, []GraphResource{{Name: "A", Dependency: DependencyResource("B")}})
+	assert.True(t, goerrors.Is(err, errors.ERROR_RESOURCE_NOT_FOUND))
+}
+
+func TestDependencyGraphStartOrder(t *testing.T) {
+	graph := testGraph(t)
+	order, err := graph.StartOrder()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"IP A", "IP B", "Name", "Disk", "Share", "Witness"}, order)
+
+	order, err = graph.StopOrder()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Witness", "Share", "Disk", "Name", "IP B", "IP A"}, order)
+	assert.Nil(t, graph.FindCycle())
+}
+
+func TestDependencyGraphCycle(t *testing.T) {
+	graph, err := NewDependencyGraph(nil, []GraphResource{
+		{Name: "A", Dependency: DependencyResource("B")},
+		{Name: "B", Dependency: mustParseDependency(t, "[C] or [D]")},
+		{Name: "C", Dependency: DependencyResource("A")},
+		{Name: "D"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"A", "B", "C", "A"}, graph.FindCycle())
+
+	_, err = graph.StartOrder()
+	var cycleErr *DependencyCycleError
+	assert.True(t, goerrors.As(err, &cycleErr))
+	assert.Equal(t, []string{"A", "B", "C", "A"}, cycleErr.Cycle)
+	assert.True(t, goerrors.Is(err, errors.ERROR_CIRCULAR_DEPENDENCY))
+	assert.Equal(t, "dependency cycle A -> B -> C -> A", err.Error())
+
+	self, err := NewDependencyGraph(nil, []GraphResource{{Name: "A", Dependency: DependencyResource("A")}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"A", "A"}, self.FindCycle())
+}
+
+func TestDependencyGraphImpact(t *testing.T) {
+	graph := testGraph(t)
+
+	impact, err := graph.Impact("Disk")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Share"}, impact)
+
+	// the name stays online on the other subnet
+	impact, err = graph.Impact("IP A")
+	assert.Nil(t, err)
+	assert.Nil(t, impact)
+
+	impact, err = graph.Impact("Name")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Share"}, impact)
+
+	_, err = graph.Impact("Missing")
+	assert.True(t, goerrors.Is(err, errors.ERROR_RESOURCE_NOT_FOUND))
+}
+
+func TestDependencyGraphImpactBothSubnets(t *testing.T) {
+	graph, err := NewDependencyGraph(nil, []GraphResource{
+		{Name: "Name", Dependency: mustParseDependency(t, "[IP A] or [IP B]")},
+		{Name: "IP A", Dependency: DependencyResource("NIC")},
+		{Name: "IP B", Dependency: DependencyResource("NIC")},
+		{Name: "NIC"},
+	})
+	assert.Nil(t, err)
+	impact, err := graph.Impact("nic")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Name", "IP A", "IP B"}, impact)
+}
+
+func TestDependencyGraphDOT(t *testing.T) {
+	graph, err := NewDependencyGraph([]string{"G"}, []GraphResource{
+		{Name: `Say "hi"`, Group: "G", Dependency: mustParseDependency(t, "[A] or [B]")},
+		{Name: "A", Group: "G"},
+		{Name: "B"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `digraph cluster {
+	subgraph cluster_0 {
+		label="G";
+		"Say \"hi\"";
+		"A";
+	}
+	"B";
+	"Say \"hi\"" -> "A" [style=dashed];
+	"Say \"hi\"" -> "B" [style=dashed];
+}
+`, graph.DOT())
+}
+
+func TestDependencyGraphMermaid(t *testing.T) {
+	graph, err := NewDependencyGraph([]string{"G"}, []GraphResource{
+		{Name: `Say "hi"`, Group: "G", Dependency: mustParseDependency(t, "[A] and [B]")},
+		{Name: "A", Group: "G"},
+		{Name: "B"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `graph TD
+	subgraph g0["G"]
+		r0["Say #quot;hi#quot;"]
+		r1["A"]
+	end
+	r2["B"]
+	r0 --> r1
+	r0 --> r2
+`, graph.Mermaid())
+}
+
+func TestDependencyGraphJSON(t *testing.T) {
+	graph, err := NewDependencyGraph([]string{"Empty"}, []GraphResource{
+		{Name: "Name", Group: "G", State: ClusterResourceOnline, Dependency: mustParseDependency(t, "[IP]")},
+		{Name: "IP", Group: "G", State: ClusterResourceFailed},
+	})
+	assert.Nil(t, err)
+	data, err := json.Marshal(graph)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"groups": [{"name": "Empty", "resources": []}, {"name": "G", "resources": ["Name", "IP"]}],
+		"resources": [
+			{"name": "Name", "group": "G", "state": "ClusterResourceOnline", "dependency": "[IP]"},
+			{"name": "IP", "group": "G", "state": "ClusterResourceFailed"}
+		],
+		"edges": [{"from": "Name", "to": "IP", "required": true}]
+	}`, string(data))
+}
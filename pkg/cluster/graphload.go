@@ -0,0 +1,41 @@
+package cluster
+
+// DependencyGraph loads every group and resource of the cluster with
+// the state and dependency expression of each resource
+func (cluster ClusterHandle) DependencyGraph() (*DependencyGraph, error) {
+	groups, err := itemNames(cluster.Groups())
+	if err != nil {
+		return nil, err
+	}
+	names, err := itemNames(cluster.Resources())
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]GraphResource, 0, len(names))
+	for _, name := range names {
+		resource, err := cluster.graphResource(name)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return NewDependencyGraph(groups, resources)
+}
+
+func (cluster ClusterHandle) graphResource(name string) (GraphResource, error) {
+	handle, err := cluster.OpenResource(name)
+	if err != nil {
+		return GraphResource{}, err
+	}
+	defer handle.Close()
+
+	state, _, group, err := handle.State()
+	if err != nil {
+		return GraphResource{}, err
+	}
+	dependency, err := handle.Dependencies()
+	if err != nil {
+		return GraphResource{}, err
+	}
+	return GraphResource{Name: name, Group: group, State: state, Dependency: dependency}, nil
+}
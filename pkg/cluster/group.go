@@ -0,0 +1,6 @@
+package cluster
+
+type (
+	GroupHandle     uintptr
+	GroupEnumHandle uintptr
+)
@@ -0,0 +1,90 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+func (cluster ClusterHandle) OpenGroup(groupName string) (handle GroupHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) CreateGroup(groupName string) (handle GroupHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) CreateGroupEx(groupName string, groupType ClusterGroupType) (handle GroupHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Delete() error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Online(node NodeHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Offline() error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Move(node NodeHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) MoveEx(node NodeHandle, flags uint32, inBuffer []byte) error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) State() (state ClusterGroupState, ownerNode string, err error) {
+	return 0, "", errors.ErrNotSupported
+}
+
+func (handle GroupHandle) WaitSettled(ctx context.Context) (ClusterGroupState, string, error) {
+	return 0, "", errors.ErrNotSupported
+}
+
+func (handle GroupHandle) OpenEnum(enumType ClusterGroupEnumType) (GroupEnumHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Enumerate(enumType ClusterGroupEnumType) (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle GroupHandle) Resources() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle GroupEnumHandle) Count() uint32 {
+	return 0
+}
+
+func (handle GroupEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	return ClusterEnumItem{}, errors.ErrNotSupported
+}
+
+func (handle GroupEnumHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) SetPreferredOwners(nodes []NodeHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle GroupHandle) PreferredOwners() ([]string, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle GroupHandle) SyncPreferredOwners(cluster ClusterHandle, desired []string) error {
+	return errors.ErrNotSupported
+}
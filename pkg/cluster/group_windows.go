@@ -0,0 +1,335 @@
+package cluster
+
+import (
+	"context"
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procnativeOpenClusterGroup         = clusapi_dll.NewProc("OpenClusterGroup")
+	procnativeCloseClusterGroup        = clusapi_dll.NewProc("CloseClusterGroup")
+	procnativeCreateClusterGroup       = clusapi_dll.NewProc("CreateClusterGroup")
+	procnativeCreateClusterGroupEx     = clusapi_dll.NewProc("CreateClusterGroupEx")
+	procnativeDeleteClusterGroup       = clusapi_dll.NewProc("DeleteClusterGroup")
+	procnativeOnlineClusterGroup       = clusapi_dll.NewProc("OnlineClusterGroup")
+	procnativeOfflineClusterGroup      = clusapi_dll.NewProc("OfflineClusterGroup")
+	procnativeMoveClusterGroup         = clusapi_dll.NewProc("MoveClusterGroup")
+	procnativeMoveClusterGroupEx       = clusapi_dll.NewProc("MoveClusterGroupEx")
+	procnativeGetClusterGroupState     = clusapi_dll.NewProc("GetClusterGroupState")
+	procnativeClusterGroupOpenEnum     = clusapi_dll.NewProc("ClusterGroupOpenEnum")
+	procnativeClusterGroupGetEnumCount = clusapi_dll.NewProc("ClusterGroupGetEnumCount")
+	procnativeClusterGroupEnum         = clusapi_dll.NewProc("ClusterGroupEnum")
+	procnativeClusterGroupCloseEnum    = clusapi_dll.NewProc("ClusterGroupCloseEnum")
+	procnativeSetClusterGroupNodeList  = clusapi_dll.NewProc("SetClusterGroupNodeList")
+)
+
+// clusterCreateGroupInfo is CLUSTER_CREATE_GROUP_INFO
+type clusterCreateGroupInfo struct {
+	dwVersion uint32
+	groupType ClusterGroupType
+}
+
+const clusterCreateGroupInfoVersion1 = 1
+
+func openClusterGroup(cluster ClusterHandle, groupName *uint16) (GroupHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeOpenClusterGroup.Addr(), 2, uintptr(cluster), uintptr(unsafe.Pointer(groupName)), 0)
+	handle := GroupHandle(r0)
+	return handle, handles.opened(groupHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func (cluster ClusterHandle) OpenGroup(groupName string) (handle GroupHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeOpenClusterGroup.Name, groupName, err) }()
+	gn, err := windows.UTF16PtrFromString(groupName)
+	if err != nil {
+		return
+	}
+	handle, err = openClusterGroup(cluster, gn)
+	return
+}
+
+func createClusterGroup(cluster ClusterHandle, groupName *uint16) (GroupHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeCreateClusterGroup.Addr(), 2, uintptr(cluster), uintptr(unsafe.Pointer(groupName)), 0)
+	handle := GroupHandle(r0)
+	return handle, handles.opened(groupHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// CreateGroup creates an empty group, or opens it if it already exists
+func (cluster ClusterHandle) CreateGroup(groupName string) (handle GroupHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeCreateClusterGroup.Name, groupName, err) }()
+	gn, err := windows.UTF16PtrFromString(groupName)
+	if err != nil {
+		return
+	}
+	handle, err = createClusterGroup(cluster, gn)
+	return
+}
+
+func createClusterGroupEx(cluster ClusterHandle, groupName *uint16, info *clusterCreateGroupInfo) (GroupHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeCreateClusterGroupEx.Addr(), 3, uintptr(cluster), uintptr(unsafe.Pointer(groupName)), uintptr(unsafe.Pointer(info)))
+	handle := GroupHandle(r0)
+	return handle, handles.opened(groupHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// CreateGroupEx creates an empty group of groupType
+func (cluster ClusterHandle) CreateGroupEx(groupName string, groupType ClusterGroupType) (handle GroupHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeCreateClusterGroupEx.Name, groupName, err) }()
+	gn, err := windows.UTF16PtrFromString(groupName)
+	if err != nil {
+		return
+	}
+	info := clusterCreateGroupInfo{dwVersion: clusterCreateGroupInfoVersion1, groupType: groupType}
+	handle, err = createClusterGroupEx(cluster, gn, &info)
+	return
+}
+
+func closeClusterGroup(handle GroupHandle) error {
+	r0, _, lastError := syscall.Syscall(procnativeCloseClusterGroup.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotNill(r0, lastError)
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle GroupHandle) Close() error {
+	err := handles.closed(groupHandleKind, uintptr(handle), func() error { return closeClusterGroup(handle) })
+	return errors.Wrap(procnativeCloseClusterGroup.Name, "", err)
+}
+
+func deleteClusterGroup(handle GroupHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeDeleteClusterGroup.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Delete deletes the empty, offline group, the handle still needs to be closed
+func (handle GroupHandle) Delete() error {
+	return errors.Wrap(procnativeDeleteClusterGroup.Name, "", deleteClusterGroup(handle))
+}
+
+func onlineClusterGroup(handle GroupHandle, node NodeHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeOnlineClusterGroup.Addr(), 2, uintptr(handle), uintptr(node), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Online brings the group online on node, pass 0 to let the cluster pick the node
+// returns ERROR_IO_PENDING while the group is still coming online, see WaitSettled
+func (handle GroupHandle) Online(node NodeHandle) error {
+	return errors.Wrap(procnativeOnlineClusterGroup.Name, "", onlineClusterGroup(handle, node))
+}
+
+func offlineClusterGroup(handle GroupHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeOfflineClusterGroup.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Offline takes the group offline, returns ERROR_IO_PENDING
+// while the group is still going offline, see WaitSettled
+func (handle GroupHandle) Offline() error {
+	return errors.Wrap(procnativeOfflineClusterGroup.Name, "", offlineClusterGroup(handle))
+}
+
+func moveClusterGroup(handle GroupHandle, node NodeHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeMoveClusterGroup.Addr(), 2, uintptr(handle), uintptr(node), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Move moves the group to node, pass 0 to let the cluster pick the node
+// returns ERROR_IO_PENDING while the group is still moving, see WaitSettled
+func (handle GroupHandle) Move(node NodeHandle) error {
+	return errors.Wrap(procnativeMoveClusterGroup.Name, "", moveClusterGroup(handle, node))
+}
+
+func moveClusterGroupEx(handle GroupHandle, node NodeHandle, flags uint32, inBuffer []byte) error {
+	var inPtr uintptr
+	if len(inBuffer) > 0 {
+		inPtr = uintptr(unsafe.Pointer(&inBuffer[0]))
+	}
+	r0, _, _ := syscall.Syscall6(procnativeMoveClusterGroupEx.Addr(),
+		5,
+		uintptr(handle),
+		uintptr(node),
+		uintptr(flags),
+		inPtr,
+		uintptr(len(inBuffer)),
+		0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// MoveEx moves the group to node, for flags use the CLUSAPI_GROUP_MOVE_ values
+// inBuffer is an optional property list passed to the move
+func (handle GroupHandle) MoveEx(node NodeHandle, flags uint32, inBuffer []byte) error {
+	return errors.Wrap(procnativeMoveClusterGroupEx.Name, "", moveClusterGroupEx(handle, node, flags, inBuffer))
+}
+
+func getClusterGroupState(handle GroupHandle) (state ClusterGroupState, nodeName string, err error) {
+	nodeCCh := uint32(50)
+
+	var nodeNameArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nodeCCh += 2
+		nodeNameArr = make([]uint16, nodeCCh)
+		r0, _, lastError := syscall.Syscall(procnativeGetClusterGroupState.Addr(),
+			3,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&nodeNameArr[0])),
+			uintptr(unsafe.Pointer(&nodeCCh)))
+		state = ClusterGroupState(r0)
+		if state != ClusterGroupStateUnknown {
+			return 0
+		}
+		return lastError
+	})
+	if err != nil {
+		return
+	}
+	if state == ClusterGroupStateUnknown {
+		// the api failed without setting a last error
+		err = errors.ERROR_INVALID_STATE
+		return
+	}
+
+	nodeName = syscall.UTF16ToString(nodeNameArr)
+	return
+}
+
+// State returns the state of the group and the node that owns it,
+// ERROR_INVALID_STATE if the state is unknown without an error from the api
+func (handle GroupHandle) State() (state ClusterGroupState, ownerNode string, err error) {
+	state, ownerNode, err = getClusterGroupState(handle)
+	err = errors.Wrap(procnativeGetClusterGroupState.Name, "", err)
+	return
+}
+
+// WaitSettled polls the group until it is no longer pending or ctx is done
+// and returns the last state and owner node, use it after Online, Offline
+// or Move return ERROR_IO_PENDING
+func (handle GroupHandle) WaitSettled(ctx context.Context) (ClusterGroupState, string, error) {
+	state, ownerNode, err := waitGroupSettled(ctx, handle.State, ResourcePollInterval)
+	return state, ownerNode, errors.Wrap(procnativeGetClusterGroupState.Name, "", err)
+}
+
+func clusterGroupOpenEnum(handle GroupHandle, enumType ClusterGroupEnumType) (GroupEnumHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeClusterGroupOpenEnum.Addr(), 2, uintptr(handle), uintptr(enumType), 0)
+	enum := GroupEnumHandle(r0)
+	return enum, handles.opened(groupEnumHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// OpenEnum opens an enumeration of the resources in the group
+// or the preferred owner nodes of the group
+func (handle GroupHandle) OpenEnum(enumType ClusterGroupEnumType) (GroupEnumHandle, error) {
+	enum, err := clusterGroupOpenEnum(handle, enumType)
+	return enum, errors.Wrap(procnativeClusterGroupOpenEnum.Name, "", err)
+}
+
+// Enumerate iterates the group objects of enumType
+func (handle GroupHandle) Enumerate(enumType ClusterGroupEnumType) (*ClusterIterator, error) {
+	enum, err := handle.OpenEnum(enumType)
+	if err != nil {
+		return nil, err
+	}
+	return newClusterIterator(enum), nil
+}
+
+// Resources iterates the resources in the group
+func (handle GroupHandle) Resources() (*ClusterIterator, error) {
+	return handle.Enumerate(CLUSTER_GROUP_ENUM_CONTAINS)
+}
+
+// Count returns the number of objects in the enumeration
+func (handle GroupEnumHandle) Count() uint32 {
+	r0, _, _ := syscall.Syscall(procnativeClusterGroupGetEnumCount.Addr(), 1, uintptr(handle), 0, 0)
+	return uint32(r0)
+}
+
+func clusterGroupEnum(handle GroupEnumHandle, index uint32) (item ClusterEnumItem, err error) {
+	nameCCh := uint32(50)
+
+	var dwType uint32
+	var nameArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nameCCh += 2
+		nameArr = make([]uint16, nameCCh)
+		r0, _, _ := syscall.Syscall6(procnativeClusterGroupEnum.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&dwType)),
+			uintptr(unsafe.Pointer(&nameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	item.Type = ClusterGroupEnumType(dwType).ClusterEnumType()
+	item.ObjectEnumType = dwType
+	item.Name = syscall.UTF16ToString(nameArr[:nameCCh])
+	return
+}
+
+// Enum returns the object at index, Type is CLUSTER_ENUM_RESOURCE or CLUSTER_ENUM_NODE
+// and ObjectEnumType the ClusterGroupEnumType, returns ERROR_NO_MORE_ITEMS after the last object
+func (handle GroupEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	item, err := clusterGroupEnum(handle, index)
+	return item, wrapEnumError(procnativeClusterGroupEnum.Name, err)
+}
+
+func (handle GroupEnumHandle) item(index uint32) (ClusterEnumItem, error) {
+	return handle.Enum(index)
+}
+
+func clusterGroupCloseEnum(handle GroupEnumHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterGroupCloseEnum.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle GroupEnumHandle) Close() error {
+	err := handles.closed(groupEnumHandleKind, uintptr(handle), func() error { return clusterGroupCloseEnum(handle) })
+	return errors.Wrap(procnativeClusterGroupCloseEnum.Name, "", err)
+}
+
+func setClusterGroupNodeList(handle GroupHandle, nodes []NodeHandle) error {
+	var nodeList uintptr
+	if len(nodes) > 0 {
+		nodeList = uintptr(unsafe.Pointer(&nodes[0]))
+	}
+	r0, _, _ := syscall.Syscall(procnativeSetClusterGroupNodeList.Addr(), 3, uintptr(handle), uintptr(len(nodes)), nodeList)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// SetPreferredOwners replaces the preferred owners of the group with nodes,
+// the first node is the most preferred. An empty list clears the preferred owners
+func (handle GroupHandle) SetPreferredOwners(nodes []NodeHandle) error {
+	return errors.Wrap(procnativeSetClusterGroupNodeList.Name, "", setClusterGroupNodeList(handle, nodes))
+}
+
+// PreferredOwners returns the names of the preferred owners of the group in order of preference
+func (handle GroupHandle) PreferredOwners() ([]string, error) {
+	return itemNames(handle.Enumerate(CLUSTER_GROUP_ENUM_NODES))
+}
+
+// SyncPreferredOwners makes the preferred owners of the group the nodes in
+// desired, the list is only written when it differs in content or order
+func (handle GroupHandle) SyncPreferredOwners(cluster ClusterHandle, desired []string) error {
+	current, err := handle.PreferredOwners()
+	if err != nil {
+		return err
+	}
+	err = syncPreferredOwners(current, desired, func(names []string) error {
+		nodes, err := cluster.openNodes(names)
+		if err != nil {
+			return err
+		}
+		defer closeNodes(nodes)
+		return handle.SetPreferredOwners(nodes)
+	})
+	return errors.Wrap(procnativeSetClusterGroupNodeList.Name, "", err)
+}
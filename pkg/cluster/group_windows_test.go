@@ -0,0 +1,98 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testGroupName = "go-windows-group"
+
+func TestGroupResources(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	resource, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	_, _, groupName, err := resource.State()
+	resource.Close()
+	assert.Nil(t, err, "error should be null")
+
+	group, err := cluster.OpenGroup(groupName)
+	assert.Nil(t, err, "error should be null")
+	defer group.Close()
+
+	state, node, err := group.State()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEqual(t, ClusterGroupStateUnknown, state)
+	assert.NotEmpty(t, node)
+
+	it, err := group.Resources()
+	assert.Nil(t, err, "error should be null")
+	resources, err := it.All()
+	assert.Nil(t, err, "error should be null")
+	assert.Contains(t, resources, ClusterEnumItem{Type: CLUSTER_ENUM_RESOURCE, Name: validResourceName, ObjectEnumType: uint32(CLUSTER_GROUP_ENUM_CONTAINS)})
+}
+
+func TestGroupLifecycle(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	group, err := cluster.CreateGroupEx(testGroupName, ClusGroupTypeUnknown)
+	assert.Nil(t, err, "error should be null")
+	defer group.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err = group.Online(0)
+	if errors.Is(err, errors.ERROR_IO_PENDING) {
+		_, _, err = group.WaitSettled(ctx)
+	}
+	assert.Nil(t, err, "error should be null")
+
+	err = group.Offline()
+	if errors.Is(err, errors.ERROR_IO_PENDING) {
+		_, _, err = group.WaitSettled(ctx)
+	}
+	assert.Nil(t, err, "error should be null")
+
+	state, _, err := group.State()
+	assert.Nil(t, err, "error should be null")
+	assert.Equal(t, ClusterGroupOffline, state)
+
+	assert.Nil(t, group.Delete(), "error should be null")
+}
+
+func TestGroupPreferredOwners(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	nodes, err := itemNames(cluster.Nodes())
+	assert.Nil(t, err, "error should be null")
+
+	group, err := cluster.CreateGroup(testGroupName)
+	assert.Nil(t, err, "error should be null")
+	defer group.Close()
+	defer group.Delete()
+
+	desired := []string{nodes[len(nodes)-1], nodes[0]}
+	err = group.SyncPreferredOwners(cluster, desired)
+	assert.Nil(t, err, "error should be null")
+
+	owners, err := group.PreferredOwners()
+	assert.Nil(t, err, "error should be null")
+	assert.True(t, SameOwners(desired, owners), "%v != %v", desired, owners)
+
+	err = group.SyncPreferredOwners(cluster, nil)
+	assert.Nil(t, err, "error should be null")
+	owners, err = group.PreferredOwners()
+	assert.Nil(t, err, "error should be null")
+	assert.Empty(t, owners)
+}
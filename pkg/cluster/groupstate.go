@@ -0,0 +1,115 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ClusterGroupState is CLUSTER_GROUP_STATE
+type ClusterGroupState int32
+
+const (
+	ClusterGroupStateUnknown  ClusterGroupState = -1
+	ClusterGroupOnline        ClusterGroupState = 0
+	ClusterGroupOffline       ClusterGroupState = 1
+	ClusterGroupFailed        ClusterGroupState = 2
+	ClusterGroupPartialOnline ClusterGroupState = 3
+	ClusterGroupPending       ClusterGroupState = 4
+)
+
+var clusterGroupStateNames = map[ClusterGroupState]string{
+	ClusterGroupStateUnknown:  "ClusterGroupStateUnknown",
+	ClusterGroupOnline:        "ClusterGroupOnline",
+	ClusterGroupOffline:       "ClusterGroupOffline",
+	ClusterGroupFailed:        "ClusterGroupFailed",
+	ClusterGroupPartialOnline: "ClusterGroupPartialOnline",
+	ClusterGroupPending:       "ClusterGroupPending",
+}
+
+func (state ClusterGroupState) String() string {
+	if name, ok := clusterGroupStateNames[state]; ok {
+		return name
+	}
+	return fmt.Sprintf("ClusterGroupState(%d)", int32(state))
+}
+
+// Settled is false while the group is pending
+func (state ClusterGroupState) Settled() bool {
+	return state != ClusterGroupPending
+}
+
+// ClusterGroupType is CLUSGROUP_TYPE, used by CreateGroupEx
+type ClusterGroupType uint32
+
+const (
+	ClusGroupTypeCoreCluster        ClusterGroupType = 1
+	ClusGroupTypeAvailableStorage   ClusterGroupType = 2
+	ClusGroupTypeTemporary          ClusterGroupType = 3
+	ClusGroupTypeSharedVolume       ClusterGroupType = 4
+	ClusGroupTypeStoragePool        ClusterGroupType = 5
+	ClusGroupTypeFileServer         ClusterGroupType = 100
+	ClusGroupTypePrintServer        ClusterGroupType = 101
+	ClusGroupTypeDhcpServer         ClusterGroupType = 102
+	ClusGroupTypeDtc                ClusterGroupType = 103
+	ClusGroupTypeMsmq               ClusterGroupType = 104
+	ClusGroupTypeWins               ClusterGroupType = 105
+	ClusGroupTypeStandAloneDfs      ClusterGroupType = 106
+	ClusGroupTypeGenericApplication ClusterGroupType = 107
+	ClusGroupTypeGenericService     ClusterGroupType = 108
+	ClusGroupTypeGenericScript      ClusterGroupType = 109
+	ClusGroupTypeIScsiNameService   ClusterGroupType = 110
+	ClusGroupTypeVirtualMachine     ClusterGroupType = 111
+	ClusGroupTypeTsSessionBroker    ClusterGroupType = 112
+	ClusGroupTypeIScsiTarget        ClusterGroupType = 113
+	ClusGroupTypeScaleoutFileServer ClusterGroupType = 114
+	ClusGroupTypeTaskScheduler      ClusterGroupType = 116
+	ClusGroupTypeClusterUpdateAgent ClusterGroupType = 117
+	ClusGroupTypeUnknown            ClusterGroupType = 9999
+)
+
+// MoveGroupEx flags
+const (
+	CLUSAPI_GROUP_MOVE_IGNORE_RESOURCE_STATUS         uint32 = 0x00000001
+	CLUSAPI_GROUP_MOVE_RETURN_TO_SOURCE_NODE_ON_ERROR uint32 = 0x00000002
+	CLUSAPI_GROUP_MOVE_QUEUE_ENABLED                  uint32 = 0x00000004
+	CLUSAPI_GROUP_MOVE_HIGH_PRIORITY_START            uint32 = 0x00000008
+	CLUSAPI_GROUP_MOVE_FAILBACK                       uint32 = 0x00000010
+	CLUSAPI_GROUP_MOVE_IGNORE_AFFINITY_RULE           uint32 = 0x00000020
+)
+
+// ClusterGroupEnumType selects what GroupHandle.OpenEnum enumerates
+type ClusterGroupEnumType uint32
+
+const (
+	CLUSTER_GROUP_ENUM_CONTAINS ClusterGroupEnumType = 0x00000001
+	CLUSTER_GROUP_ENUM_NODES    ClusterGroupEnumType = 0x00000002
+	CLUSTER_GROUP_ENUM_ALL      ClusterGroupEnumType = CLUSTER_GROUP_ENUM_CONTAINS | CLUSTER_GROUP_ENUM_NODES
+)
+
+// ClusterEnumType maps the group enumeration type onto the cluster one
+// so group enumerations return the same ClusterEnumItem, contained
+// resources are CLUSTER_ENUM_RESOURCE and preferred nodes CLUSTER_ENUM_NODE
+func (enumType ClusterGroupEnumType) ClusterEnumType() ClusterEnumType {
+	var mapped ClusterEnumType
+	if enumType&CLUSTER_GROUP_ENUM_CONTAINS != 0 {
+		mapped |= CLUSTER_ENUM_RESOURCE
+	}
+	if enumType&CLUSTER_GROUP_ENUM_NODES != 0 {
+		mapped |= CLUSTER_ENUM_NODE
+	}
+	return mapped
+}
+
+// groupStateFunc returns the state and owner node of a group
+type groupStateFunc func() (state ClusterGroupState, ownerNode string, err error)
+
+// waitGroupSettled polls getState until the state is settled or ctx is done
+func waitGroupSettled(ctx context.Context, getState groupStateFunc, interval time.Duration) (state ClusterGroupState, ownerNode string, err error) {
+	err = pollUntil(ctx, interval, func() (bool, error) {
+		var stateErr error
+		state, ownerNode, stateErr = getState()
+		return state.Settled(), stateErr
+	})
+	return
+}
@@ -0,0 +1,43 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterGroupState(t *testing.T) {
+	assert.Equal(t, "ClusterGroupPartialOnline", ClusterGroupPartialOnline.String())
+	assert.Equal(t, "ClusterGroupState(9)", ClusterGroupState(9).String())
+	assert.True(t, ClusterGroupFailed.Settled())
+	assert.False(t, ClusterGroupPending.Settled())
+}
+
+func TestClusterGroupEnumType(t *testing.T) {
+	assert.Equal(t, CLUSTER_ENUM_RESOURCE, CLUSTER_GROUP_ENUM_CONTAINS.ClusterEnumType())
+	assert.Equal(t, CLUSTER_ENUM_NODE, CLUSTER_GROUP_ENUM_NODES.ClusterEnumType())
+	assert.Equal(t, CLUSTER_ENUM_NODE|CLUSTER_ENUM_RESOURCE, CLUSTER_GROUP_ENUM_ALL.ClusterEnumType())
+}
+
+func TestWaitGroupSettled(t *testing.T) {
+	states := []ClusterGroupState{ClusterGroupPending, ClusterGroupPending, ClusterGroupOnline}
+	calls := 0
+	state, node, err := waitGroupSettled(context.Background(), func() (ClusterGroupState, string, error) {
+		calls++
+		return states[calls-1], "node2", nil
+	}, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, ClusterGroupOnline, state)
+	assert.Equal(t, "node2", node)
+	assert.Equal(t, 3, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	state, _, err = waitGroupSettled(ctx, func() (ClusterGroupState, string, error) {
+		return ClusterGroupPending, "node2", nil
+	}, time.Hour)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, ClusterGroupPending, state)
+}
@@ -0,0 +1,174 @@
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// Handle kinds recorded by the handle tracker
+const (
+	clusterHandleKind              = "ClusterHandle"
+	resourceHandleKind             = "ResourceHandle"
+	groupHandleKind                = "GroupHandle"
+	nodeHandleKind                 = "NodeHandle"
+	networkHandleKind              = "NetworkHandle"
+	netInterfaceHandleKind         = "NetInterfaceHandle"
+	keyHandleKind                  = "KeyHandle"
+	clusterEnumHandleKind          = "ClusterEnumHandle"
+	clusterEnumExHandleKind        = "ClusterEnumExHandle"
+	groupEnumHandleKind            = "GroupEnumHandle"
+	nodeEnumHandleKind             = "NodeEnumHandle"
+	resourceEnumHandleKind         = "ResourceEnumHandle"
+	notifyPortHandleKind           = "NotifyPortHandle"
+	regBatchPortHandleKind         = "RegBatchPortHandle"
+	regBatchNotificationHandleKind = "RegBatchNotificationHandle"
+	cryptProviderHandleKind        = "HCLUSCRYPTPROVIDER"
+)
+
+// HandleLeak is a handle that is still open, Stack is where it was
+// opened and is only recorded while handle debugging is enabled
+type HandleLeak struct {
+	Kind   string
+	Handle uintptr
+	Stack  string
+}
+
+func (leak HandleLeak) String() string {
+	if leak.Stack == "" {
+		return fmt.Sprintf("%s 0x%x", leak.Kind, leak.Handle)
+	}
+	return fmt.Sprintf("%s 0x%x opened at\n%s", leak.Kind, leak.Handle, leak.Stack)
+}
+
+type handleID struct {
+	kind  string
+	value uintptr
+}
+
+// handleTracker records every open handle so OpenHandles can list the
+// handles that leaked. The handle types are plain uintptr values, so Close
+// can not tell a second close, or a stale copy whose value Windows reused,
+// from a valid handle and always calls the api. A handle the tracker does
+// not know, for example one converted from a uintptr obtained elsewhere, is
+// closed the same way. Wrap a handle with Safe to close it at most once and
+// to have the garbage collector close and report it when it leaks
+type handleTracker struct {
+	mu    sync.Mutex
+	debug bool
+	open  map[handleID]handleRecord
+	next  uint64
+	// leaked is called by the finalizer of a safe handle that was not closed
+	leaked func(HandleLeak)
+}
+
+// handleRecord is an open handle, serial orders the handles by open time
+type handleRecord struct {
+	serial uint64
+	stack  string
+}
+
+func newHandleTracker() *handleTracker {
+	return &handleTracker{open: make(map[handleID]handleRecord)}
+}
+
+// handles tracks the handles opened by this package
+var handles = newHandleTracker()
+
+// SetHandleDebug turns recording the stack of every opened handle and reporting
+// leaked safe handles on or off, it is off by default as capturing the stack
+// slows down every open
+func SetHandleDebug(enabled bool) {
+	handles.setDebug(enabled)
+}
+
+// OpenHandles returns the handles that are open in the order they were opened,
+// call it at shutdown or at the end of a test to find leaked handles
+func OpenHandles() []HandleLeak {
+	return handles.leaks()
+}
+
+// SetHandleLeakHandler sets the function called while handle debugging is
+// enabled with every safe handle the garbage collector finds open, nil
+// writes them to standard error. The handle is closed either way
+func SetHandleLeakHandler(handler func(HandleLeak)) {
+	handles.mu.Lock()
+	defer handles.mu.Unlock()
+	handles.leaked = handler
+}
+
+func (t *handleTracker) setDebug(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.debug = enabled
+}
+
+// opened records value when err is nil and returns err,
+// it wraps the result of the native open call
+func (t *handleTracker) opened(kind string, value uintptr, err error) error {
+	if err != nil || value == 0 {
+		return err
+	}
+	var stack string
+	t.mu.Lock()
+	debugEnabled := t.debug
+	t.mu.Unlock()
+	if debugEnabled {
+		stack = string(debug.Stack())
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.open[handleID{kind, value}] = handleRecord{serial: t.next, stack: stack}
+	t.next++
+	return nil
+}
+
+// closed forgets value and calls close, a handle that is not
+// tracked is closed as well
+func (t *handleTracker) closed(kind string, value uintptr, close func() error) error {
+	t.mu.Lock()
+	delete(t.open, handleID{kind, value})
+	t.mu.Unlock()
+	return close()
+}
+
+// stack returns where value was opened, "" unless handle debugging was on
+func (t *handleTracker) stack(kind string, value uintptr) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.open[handleID{kind, value}].stack
+}
+
+// reportLeak passes leak to the leak handler while handle debugging is
+// enabled, without a handler it is written to standard error
+func (t *handleTracker) reportLeak(leak HandleLeak) {
+	t.mu.Lock()
+	debugEnabled, leaked := t.debug, t.leaked
+	t.mu.Unlock()
+	if !debugEnabled {
+		return
+	}
+	if leaked == nil {
+		fmt.Fprintf(os.Stderr, "leaked %s\n", leak)
+		return
+	}
+	leaked(leak)
+}
+
+func (t *handleTracker) leaks() []HandleLeak {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]handleID, 0, len(t.open))
+	for id := range t.open {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return t.open[ids[i]].serial < t.open[ids[j]].serial })
+	leaks := make([]HandleLeak, 0, len(ids))
+	for _, id := range ids {
+		leaks = append(leaks, HandleLeak{Kind: id.kind, Handle: id.value, Stack: t.open[id].stack})
+	}
+	return leaks
+}
@@ -0,0 +1,114 @@
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleTrackerClose(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0x10, nil))
+
+	calls := 0
+	closeFunc := func() error {
+		calls++
+		return nil
+	}
+	assert.Nil(t, tracker.closed(clusterHandleKind, 0x10, closeFunc))
+	assert.Equal(t, 1, calls)
+
+	// the tracker can not tell a second close from an untracked handle
+	assert.Nil(t, tracker.closed(clusterHandleKind, 0x10, closeFunc))
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, tracker.leaks())
+}
+
+// TestHandleTrackerUntracked checks a handle converted from a uintptr
+// obtained elsewhere is still closed
+func TestHandleTrackerUntracked(t *testing.T) {
+	tracker := newHandleTracker()
+	closed := false
+	assert.Nil(t, tracker.closed(keyHandleKind, 0x40, func() error {
+		closed = true
+		return nil
+	}))
+	assert.True(t, closed)
+
+	err := tracker.closed(keyHandleKind, 0x40, func() error { return errors.ERROR_INVALID_HANDLE })
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err)
+}
+
+func TestHandleTrackerCloseError(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Nil(t, tracker.opened(keyHandleKind, 0x10, nil))
+
+	err := tracker.closed(keyHandleKind, 0x10, func() error { return errors.ERROR_ACCESS_DENIED })
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, err)
+	assert.Empty(t, tracker.leaks())
+}
+
+func TestHandleTrackerKinds(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0x10, nil))
+
+	assert.Nil(t, tracker.closed(resourceHandleKind, 0x10, func() error { return nil }))
+	assert.Equal(t, []HandleLeak{{Kind: clusterHandleKind, Handle: 0x10}}, tracker.leaks())
+}
+
+func TestHandleTrackerFailedOpen(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, tracker.opened(clusterHandleKind, 0, errors.ERROR_ACCESS_DENIED))
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, tracker.opened(clusterHandleKind, 0x10, errors.ERROR_ACCESS_DENIED))
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0, nil))
+	assert.Empty(t, tracker.leaks())
+}
+
+func TestHandleTrackerLeaks(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Nil(t, tracker.opened(groupHandleKind, 0x30, nil))
+	assert.Nil(t, tracker.opened(nodeHandleKind, 0x10, nil))
+	assert.Nil(t, tracker.opened(keyHandleKind, 0x20, nil))
+	assert.Nil(t, tracker.closed(nodeHandleKind, 0x10, func() error { return nil }))
+
+	leaks := tracker.leaks()
+	assert.Equal(t, []HandleLeak{
+		{Kind: groupHandleKind, Handle: 0x30},
+		{Kind: keyHandleKind, Handle: 0x20},
+	}, leaks)
+	assert.Equal(t, "GroupHandle 0x30", leaks[0].String())
+}
+
+func TestHandleTrackerDebug(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0x10, nil))
+	tracker.setDebug(true)
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0x20, nil))
+
+	leaks := tracker.leaks()
+	assert.Equal(t, 2, len(leaks))
+	assert.Equal(t, "", leaks[0].Stack)
+	assert.True(t, strings.Contains(leaks[1].Stack, "TestHandleTrackerDebug"))
+	assert.True(t, strings.HasPrefix(leaks[1].String(), "ClusterHandle 0x20 opened at\n"))
+}
+
+// TestHandleTrackerReusedValue pins a documented limit of the plain handles:
+// a stale copy can not be told apart from a newer handle that reuses its value
+func TestHandleTrackerReusedValue(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0x10, nil))
+	assert.Nil(t, tracker.closed(clusterHandleKind, 0x10, func() error { return nil }))
+
+	// Windows hands out 0x10 again for the next cluster handle
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0x10, nil))
+
+	closedNewer := false
+	assert.Nil(t, tracker.closed(clusterHandleKind, 0x10, func() error {
+		closedNewer = true
+		return nil
+	}), "the stale copy closes the newer handle")
+	assert.True(t, closedNewer)
+	assert.Empty(t, tracker.leaks())
+}
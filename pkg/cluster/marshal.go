@@ -0,0 +1,464 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+	"github.com/KnicKnic/go-windows/pkg/util/guid"
+)
+
+// Marshal and Unmarshal map the exported fields of a struct to the values of a
+// cluster registry key using the clusreg struct tag
+//
+//	type Settings struct {
+//		Timeout  uint32   `clusreg:"Timeout,dword"`
+//		Path     string   `clusreg:"Path,expand_sz"`
+//		Servers  []string `clusreg:"Servers"`
+//		Advanced Advanced `clusreg:"Advanced"`
+//		Ignored  string   `clusreg:"-"`
+//	}
+//
+// The name defaults to the field name and the type is inferred from the field
+// when omitted. Supported types are sz and expand_sz for string, multi_sz for
+// []string, dword for bool and integers up to 32 bits, qword for 64 bit
+// integers and binary for []byte and guid.GUID. Struct and pointer to struct
+// fields map to subkeys.
+
+const structTag = "clusreg"
+
+var guidType = reflect.TypeOf(guid.GUID{})
+
+// regTree holds the values and subkeys of a key, it is what the
+// encoding works on so the encoding can be tested without a cluster
+type regTree struct {
+	values  map[string]RegistryValue
+	subkeys map[string]*regTree
+}
+
+type regField struct {
+	index  int
+	name   string
+	dwType uint32
+	subkey bool
+}
+
+func newRegTree() *regTree {
+	return &regTree{
+		values:  make(map[string]RegistryValue),
+		subkeys: make(map[string]*regTree),
+	}
+}
+
+// Marshal writes the fields of the struct v to key in a single batch
+// so either every value is written or none are
+func Marshal(key Key, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("clusreg: Marshal of non struct %T", v)
+	}
+	tree, err := encodeStruct(value)
+	if err != nil {
+		return err
+	}
+
+	batch, err := key.CreateBatch()
+	if err != nil {
+		return err
+	}
+	if err = addTreeCommands(batch, tree, ""); err != nil {
+		batch.CloseBatch(false)
+		return err
+	}
+	err, _ = batch.CloseBatch(true)
+	return err
+}
+
+// Unmarshal reads key into the struct pointed to by v
+// fields without a matching value are left unchanged
+func Unmarshal(key Key, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("clusreg: Unmarshal requires a non nil struct pointer, got %T", v)
+	}
+	value = value.Elem()
+	tree, err := loadTree(key, value.Type())
+	if err != nil {
+		return err
+	}
+	return decodeStruct(tree, value)
+}
+
+// addTreeCommands adds the commands to write tree, subkeys are created
+// relative to the key of the batch
+func addTreeCommands(batch RegBatch, tree *regTree, path string) error {
+	if path != "" {
+		if err := batch.BatchAddCommand(CLUSREG_CREATE_KEY, path, 0, nil); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedNames(tree.values) {
+		value := tree.values[name]
+		if err := batch.BatchAddCommand(CLUSREG_SET_VALUE, name, value.DwType, value.Data); err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(tree.subkeys))
+	for name := range tree.subkeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		subpath := name
+		if path != "" {
+			subpath = path + `\` + name
+		}
+		if err := addTreeCommands(batch, tree.subkeys[name], subpath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedNames(values map[string]RegistryValue) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadTree reads the values of key and the subkeys used by t
+// missing subkeys are left out of the tree
+func loadTree(key Key, t reflect.Type) (*regTree, error) {
+	fields, err := structFields(t)
+	if err != nil {
+		return nil, err
+	}
+	tree := newRegTree()
+	tree.values, err = key.LoadValues()
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if !field.subkey {
+			continue
+		}
+		subkey, err := key.OpenKey(field.name, KEY_READ)
+		if errors.Is(err, errors.ERROR_FILE_NOT_FOUND) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		subtree, err := loadTree(subkey, structType(t.Field(field.index).Type))
+		subkey.Close()
+		if err != nil {
+			return nil, err
+		}
+		tree.subkeys[field.name] = subtree
+	}
+	return tree, nil
+}
+
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// structFields parses the clusreg tags of t
+func structFields(t reflect.Type) ([]regField, error) {
+	var fields []regField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tag := sf.Tag.Get(structTag)
+		if tag == "-" {
+			continue
+		}
+		field := regField{index: i, name: sf.Name}
+		kind := ""
+		if tag != "" {
+			parts := strings.SplitN(tag, ",", 2)
+			if parts[0] != "" {
+				field.name = parts[0]
+			}
+			if len(parts) == 2 {
+				kind = parts[1]
+			}
+		}
+
+		ft := sf.Type
+		if ft != guidType && structType(ft).Kind() == reflect.Struct {
+			if kind != "" {
+				return nil, fmt.Errorf("clusreg: struct field %s can not have type %s", sf.Name, kind)
+			}
+			field.subkey = true
+			fields = append(fields, field)
+			continue
+		}
+
+		dwType, err := fieldType(ft, kind)
+		if err != nil {
+			return nil, fmt.Errorf("clusreg: field %s: %v", sf.Name, err)
+		}
+		field.dwType = dwType
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// fieldType returns the registry type for a field of type t tagged with kind
+func fieldType(t reflect.Type, kind string) (uint32, error) {
+	var allowed []uint32
+	switch {
+	case t == guidType:
+		allowed = []uint32{REG_BINARY}
+	case t.Kind() == reflect.String:
+		allowed = []uint32{REG_SZ, REG_EXPAND_SZ}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		allowed = []uint32{REG_MULTI_SZ}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		allowed = []uint32{REG_BINARY}
+	case t.Kind() == reflect.Bool:
+		allowed = []uint32{REG_DWORD}
+	case t.Kind() == reflect.Int64, t.Kind() == reflect.Uint64:
+		allowed = []uint32{REG_QWORD, REG_DWORD}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		allowed = []uint32{REG_DWORD, REG_QWORD}
+	default:
+		return 0, fmt.Errorf("unsupported type %s", t)
+	}
+	if kind == "" {
+		return allowed[0], nil
+	}
+
+	var dwType uint32
+	switch kind {
+	case "sz":
+		dwType = REG_SZ
+	case "expand_sz":
+		dwType = REG_EXPAND_SZ
+	case "multi_sz":
+		dwType = REG_MULTI_SZ
+	case "dword":
+		dwType = REG_DWORD
+	case "qword":
+		dwType = REG_QWORD
+	case "binary":
+		dwType = REG_BINARY
+	default:
+		return 0, fmt.Errorf("unknown registry type %s", kind)
+	}
+	for _, a := range allowed {
+		if a == dwType {
+			return dwType, nil
+		}
+	}
+	return 0, fmt.Errorf("type %s can not be stored as %s", t, kind)
+}
+
+func encodeStruct(v reflect.Value) (*regTree, error) {
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	tree := newRegTree()
+	for _, field := range fields {
+		fv := v.Field(field.index)
+		if field.subkey {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			subtree, err := encodeStruct(fv)
+			if err != nil {
+				return nil, err
+			}
+			tree.subkeys[field.name] = subtree
+			continue
+		}
+		data, err := encodeField(fv, field.dwType)
+		if err != nil {
+			return nil, fmt.Errorf("clusreg: field %s: %v", v.Type().Field(field.index).Name, err)
+		}
+		tree.values[field.name] = RegistryValue{Data: data, DwType: field.dwType}
+	}
+	return tree, nil
+}
+
+func encodeField(v reflect.Value, dwType uint32) ([]byte, error) {
+	if v.Type() == guidType {
+		return v.Interface().(guid.GUID).ToByte()
+	}
+	switch dwType {
+	case REG_SZ, REG_EXPAND_SZ:
+		return util.StringToUTF16Bytes(v.String())
+	case REG_MULTI_SZ:
+		return util.StringsToUTF16Bytes(v.Convert(reflect.TypeOf([]string(nil))).Interface().([]string))
+	case REG_BINARY:
+		return append([]byte(nil), v.Bytes()...), nil
+	}
+
+	var number uint64
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			number = 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		signed := v.Int()
+		if dwType == REG_DWORD && (signed < math.MinInt32 || signed > math.MaxInt32) {
+			return nil, fmt.Errorf("%d overflows a dword", signed)
+		}
+		number = uint64(signed)
+	default:
+		number = v.Uint()
+		if dwType == REG_DWORD && number > math.MaxUint32 {
+			return nil, fmt.Errorf("%d overflows a dword", number)
+		}
+	}
+	if dwType == REG_DWORD {
+		return util.Uint32ToByte(uint32(number)), nil
+	}
+	return util.Uint64ToByte(number), nil
+}
+
+func decodeStruct(tree *regTree, v reflect.Value) error {
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		fv := v.Field(field.index)
+		if field.subkey {
+			subtree, ok := tree.subkeys[field.name]
+			if !ok {
+				continue
+			}
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			if err := decodeStruct(subtree, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := lookupValue(tree.values, field.name)
+		if !ok {
+			continue
+		}
+		if err := decodeField(fv, field.dwType, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupValue finds a value ignoring case like the registry does
+func lookupValue(values map[string]RegistryValue, name string) (RegistryValue, bool) {
+	if value, ok := values[name]; ok {
+		return value, true
+	}
+	for n, value := range values {
+		if strings.EqualFold(n, name) {
+			return value, true
+		}
+	}
+	return RegistryValue{}, false
+}
+
+func decodeField(v reflect.Value, dwType uint32, value RegistryValue) error {
+	if v.Type() == guidType {
+		if value.DwType != REG_BINARY || len(value.Data) != 16 {
+			return errors.ERROR_INVALID_DATA
+		}
+		g, err := guid.FromBytes(value.Data)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(g))
+		return nil
+	}
+
+	switch dwType {
+	case REG_SZ, REG_EXPAND_SZ:
+		str, err := decodeString(value.DwType, value.Data)
+		if err != nil {
+			return err
+		}
+		v.SetString(str)
+		return nil
+	case REG_MULTI_SZ:
+		strs, err := decodeStrings(value.DwType, value.Data)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(strs).Convert(v.Type()))
+		return nil
+	case REG_BINARY:
+		if value.DwType != REG_BINARY {
+			return errors.ERROR_INVALID_DATA
+		}
+		v.SetBytes(append([]byte(nil), value.Data...))
+		return nil
+	}
+
+	// accept either integer size so a field can be widened later
+	var number uint64
+	signed := false
+	switch value.DwType {
+	case REG_DWORD:
+		dword, err := decodeDWord(value.DwType, value.Data)
+		if err != nil {
+			return err
+		}
+		number = uint64(dword)
+		signed = true
+	case REG_QWORD:
+		qword, err := decodeQWord(value.DwType, value.Data)
+		if err != nil {
+			return err
+		}
+		number = qword
+	default:
+		return errors.ERROR_INVALID_DATA
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(number != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := int64(number)
+		if signed {
+			n = int64(int32(number))
+		}
+		if v.OverflowInt(n) {
+			return errors.ERROR_INVALID_DATA
+		}
+		v.SetInt(n)
+	default:
+		if v.OverflowUint(number) {
+			return errors.ERROR_INVALID_DATA
+		}
+		v.SetUint(number)
+	}
+	return nil
+}
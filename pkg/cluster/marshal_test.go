@@ -0,0 +1,199 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util/guid"
+	"github.com/stretchr/testify/assert"
+)
+
+type marshalAdvanced struct {
+	Retries int8
+	Enabled bool `clusreg:"IsEnabled"`
+}
+
+type marshalSettings struct {
+	Timeout  uint32    `clusreg:"Timeout,dword"`
+	Path     string    `clusreg:"Path,expand_sz"`
+	Name     string    `clusreg:",sz"`
+	Servers  []string  `clusreg:"Servers"`
+	Offset   int       `clusreg:"Offset"`
+	Size     int64     `clusreg:"Size"`
+	Small    uint64    `clusreg:"Small,dword"`
+	Blob     []byte    `clusreg:"Blob"`
+	ID       guid.GUID `clusreg:"ID"`
+	Advanced marshalAdvanced
+	Optional *marshalAdvanced `clusreg:"Optional"`
+	Ignored  string           `clusreg:"-"`
+	private  string
+}
+
+func testSettings() marshalSettings {
+	id, _ := guid.FromString("206994D6-C7B7-ABDB-D89E-AB9CBF3853C4")
+	return marshalSettings{
+		Timeout:  30,
+		Path:     `%SystemRoot%\temp`,
+		Name:     "name",
+		Servers:  []string{"a", "b"},
+		Offset:   -5,
+		Size:     1 << 40,
+		Small:    7,
+		Blob:     []byte{1, 2, 3},
+		ID:       id,
+		Advanced: marshalAdvanced{Retries: 3, Enabled: true},
+		Ignored:  "ignored",
+		private:  "private",
+	}
+}
+
+func TestEncodeStruct(t *testing.T) {
+	tree, err := encodeStruct(reflect.ValueOf(testSettings()))
+	assert.Nil(t, err)
+
+	assert.Equal(t, RegistryValue{Data: []byte{30, 0, 0, 0}, DwType: REG_DWORD}, tree.values["Timeout"])
+	assert.Equal(t, REG_EXPAND_SZ, tree.values["Path"].DwType)
+	assert.Equal(t, REG_SZ, tree.values["Name"].DwType)
+	assert.Equal(t, REG_MULTI_SZ, tree.values["Servers"].DwType)
+	assert.Equal(t, RegistryValue{Data: []byte{0xfb, 0xff, 0xff, 0xff}, DwType: REG_DWORD}, tree.values["Offset"])
+	assert.Equal(t, REG_QWORD, tree.values["Size"].DwType)
+	assert.Equal(t, REG_DWORD, tree.values["Small"].DwType)
+	assert.Equal(t, RegistryValue{Data: []byte{1, 2, 3}, DwType: REG_BINARY}, tree.values["Blob"])
+	assert.Equal(t, 16, len(tree.values["ID"].Data))
+	assert.Equal(t, 9, len(tree.values))
+
+	assert.Equal(t, 1, len(tree.subkeys))
+	advanced := tree.subkeys["Advanced"]
+	assert.Equal(t, RegistryValue{Data: []byte{1, 0, 0, 0}, DwType: REG_DWORD}, advanced.values["IsEnabled"])
+	assert.Equal(t, RegistryValue{Data: []byte{3, 0, 0, 0}, DwType: REG_DWORD}, advanced.values["Retries"])
+}
+
+func TestDecodeStruct(t *testing.T) {
+	expected := testSettings()
+	expected.Optional = &marshalAdvanced{Retries: -1}
+	tree, err := encodeStruct(reflect.ValueOf(expected))
+	assert.Nil(t, err)
+
+	var settings marshalSettings
+	err = decodeStruct(tree, reflect.ValueOf(&settings).Elem())
+	assert.Nil(t, err)
+
+	expected.Ignored = ""
+	expected.private = ""
+	assert.Equal(t, expected, settings)
+}
+
+func TestDecodeStructErrors(t *testing.T) {
+	var settings marshalSettings
+	value := reflect.ValueOf(&settings).Elem()
+
+	tree := newRegTree()
+	tree.values["timeout"] = RegistryValue{Data: []byte{1, 0, 0, 0, 0, 0, 0, 0}, DwType: REG_QWORD}
+	assert.Nil(t, decodeStruct(tree, value))
+	assert.Equal(t, uint32(1), settings.Timeout)
+
+	tree.values["timeout"] = RegistryValue{Data: []byte{0, 0, 0, 0, 1, 0, 0, 0}, DwType: REG_QWORD}
+	assert.Equal(t, errors.ERROR_INVALID_DATA, decodeStruct(tree, value))
+
+	tree = newRegTree()
+	tree.values["Name"] = RegistryValue{Data: []byte{1, 0, 0, 0}, DwType: REG_DWORD}
+	assert.Equal(t, errors.ERROR_INVALID_DATA, decodeStruct(tree, value))
+
+	tree = newRegTree()
+	tree.values["ID"] = RegistryValue{Data: []byte{1}, DwType: REG_BINARY}
+	assert.Equal(t, errors.ERROR_INVALID_DATA, decodeStruct(tree, value))
+}
+
+func TestStructFieldErrors(t *testing.T) {
+	_, err := structFields(reflect.TypeOf(struct {
+		Value string `clusreg:"Value,dword"`
+	}{}))
+	assert.NotNil(t, err)
+
+	_, err = structFields(reflect.TypeOf(struct {
+		Value uint32 `clusreg:"Value,float"`
+	}{}))
+	assert.NotNil(t, err)
+
+	_, err = structFields(reflect.TypeOf(struct {
+		Value float64
+	}{}))
+	assert.NotNil(t, err)
+
+	_, err = encodeStruct(reflect.ValueOf(struct {
+		Value int64 `clusreg:"Value,dword"`
+	}{Value: 1 << 40}))
+	assert.NotNil(t, err)
+}
+
+func TestMarshal(t *testing.T) {
+	_, key := openMemoryKey(t)
+	defer key.Close()
+
+	expected := testSettings()
+	err := Marshal(key, &expected)
+	assert.Nil(t, err)
+
+	dwType, _, err := key.QueryValue("Path")
+	assert.Nil(t, err)
+	assert.Equal(t, REG_EXPAND_SZ, dwType)
+
+	advanced, _, err := key.CreateKey("Advanced", KEY_READ)
+	assert.Nil(t, err)
+	_, data, err := advanced.QueryValue("Retries")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{3, 0, 0, 0}, data)
+
+	var settings marshalSettings
+	err = Unmarshal(key, &settings)
+	assert.Nil(t, err)
+	expected.Ignored = ""
+	expected.private = ""
+	assert.Equal(t, expected, settings)
+
+	assert.NotNil(t, Unmarshal(key, settings))
+	assert.NotNil(t, Marshal(key, "string"))
+
+	// subkeys are opened, not created, when reading
+	_, err = key.OpenKey("Optional", KEY_READ)
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, err)
+	assert.Nil(t, settings.Optional)
+}
+
+// readOnlyKey refuses to create keys and wraps its errors like a KeyHandle
+// opened with KEY_READ
+type readOnlyKey struct {
+	Key
+}
+
+func (key readOnlyKey) CreateKey(keyName string, samDesired int) (Key, bool, error) {
+	return nil, false, errors.Wrap("ClusterRegCreateKey", keyName, errors.ERROR_ACCESS_DENIED)
+}
+
+func (key readOnlyKey) OpenKey(keyName string, samDesired int) (Key, error) {
+	subkey, err := key.Key.OpenKey(keyName, samDesired)
+	if err != nil {
+		return nil, errors.Wrap("ClusterRegOpenKey", keyName, err)
+	}
+	return readOnlyKey{subkey}, nil
+}
+
+func TestUnmarshalReadOnly(t *testing.T) {
+	_, key := openMemoryKey(t)
+	defer key.Close()
+
+	var settings marshalSettings
+	assert.Nil(t, Unmarshal(readOnlyKey{key}, &settings))
+	assert.Equal(t, marshalSettings{}, settings)
+	names, err := key.EnumKeys()
+	assert.Nil(t, err)
+	assert.Empty(t, names, "reading creates no subkeys")
+
+	expected := testSettings()
+	assert.Nil(t, Marshal(key, &expected))
+	assert.Nil(t, Unmarshal(readOnlyKey{key}, &settings))
+	expected.Ignored = ""
+	expected.private = ""
+	assert.Equal(t, expected, settings)
+}
@@ -0,0 +1,644 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// MemoryBackend is an in-memory Backend that mimics the clusapi registry semantics
+// so code written against Cluster, Resource and Key can be tested without a cluster.
+//
+// Key and value names are case insensitive, missing keys and values return
+// ERROR_FILE_NOT_FOUND, handles to deleted keys return ERROR_KEY_DELETED
+// and batches are applied atomically.
+//
+// Within a batch CLUSREG_CREATE_KEY takes a path relative to the key the batch
+// was created on and makes it the target of the following commands,
+// CLUSREG_DELETE_KEY takes a path relative to the same key. A failed
+// CLUSREG_CONDITION_* returns ERROR_FILE_NOT_FOUND for the *_EXISTS conditions,
+// ERROR_ALREADY_EXISTS for the *_NOT_EXISTS conditions and ERROR_INVALID_DATA
+// for the comparisons.
+type MemoryBackend struct {
+	mu        sync.Mutex
+	name      string
+	resources map[string]*memResource
+}
+
+type memResource struct {
+	name string
+	root *memKeyNode
+}
+
+type memKeyNode struct {
+	name      string
+	parent    *memKeyNode
+	values    []*memValue
+	subkeys   []*memKeyNode
+	deleted   bool
+	lastWrite time.Time
+}
+
+type memValue struct {
+	name   string
+	dwType uint32
+	data   []byte
+}
+
+type memCluster struct {
+	backend *MemoryBackend
+	closed  bool
+}
+
+type memResourceHandle struct {
+	backend  *MemoryBackend
+	resource *memResource
+	closed   bool
+}
+
+type memKey struct {
+	backend *MemoryBackend
+	node    *memKeyNode
+	closed  bool
+}
+
+type memCommand struct {
+	command ClusterRegCommand
+	name    string
+	dwType  uint32
+	data    []byte
+}
+
+type memBatch struct {
+	key      *memKey
+	commands []memCommand
+	closed   bool
+}
+
+// NewMemoryBackend creates an empty in-memory cluster named clusterName
+func NewMemoryBackend(clusterName string) *MemoryBackend {
+	return &MemoryBackend{
+		name:      clusterName,
+		resources: make(map[string]*memResource),
+	}
+}
+
+// AddResource adds a resource with an empty registry key, it does nothing
+// if the resource already exists
+func (backend *MemoryBackend) AddResource(resourceName string) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	id := strings.ToLower(resourceName)
+	if _, ok := backend.resources[id]; ok {
+		return
+	}
+	backend.resources[id] = &memResource{
+		name: resourceName,
+		root: &memKeyNode{name: resourceName, lastWrite: time.Now()},
+	}
+}
+
+// OpenCluster opens the local in-memory cluster
+func (backend *MemoryBackend) OpenCluster() (Cluster, error) {
+	return &memCluster{backend: backend}, nil
+}
+
+// OpenRemoteCluster returns RPC_S_SERVER_UNAVAILABLE unless clusterName
+// is the name of the in-memory cluster or the local machine
+func (backend *MemoryBackend) OpenRemoteCluster(clusterName string) (Cluster, error) {
+	switch {
+	case strings.EqualFold(clusterName, backend.name),
+		strings.EqualFold(clusterName, "localhost"),
+		clusterName == ".":
+		return &memCluster{backend: backend}, nil
+	}
+	return nil, errors.RPC_S_SERVER_UNAVAILABLE
+}
+
+func (cluster *memCluster) OpenResource(resourceName string) (Resource, error) {
+	cluster.backend.mu.Lock()
+	defer cluster.backend.mu.Unlock()
+
+	if cluster.closed {
+		return nil, errors.ERROR_INVALID_HANDLE
+	}
+	resource, ok := cluster.backend.resources[strings.ToLower(resourceName)]
+	if !ok {
+		return nil, errors.ERROR_RESOURCE_NOT_FOUND
+	}
+	return &memResourceHandle{backend: cluster.backend, resource: resource}, nil
+}
+
+func (cluster *memCluster) Close() error {
+	cluster.backend.mu.Lock()
+	defer cluster.backend.mu.Unlock()
+	if cluster.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	cluster.closed = true
+	return nil
+}
+
+func (resource *memResourceHandle) GetKey(samDesired int) (Key, error) {
+	resource.backend.mu.Lock()
+	defer resource.backend.mu.Unlock()
+
+	if resource.closed {
+		return nil, errors.ERROR_INVALID_HANDLE
+	}
+	return &memKey{backend: resource.backend, node: resource.resource.root}, nil
+}
+
+func (resource *memResourceHandle) Close() error {
+	resource.backend.mu.Lock()
+	defer resource.backend.mu.Unlock()
+	if resource.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	resource.closed = true
+	return nil
+}
+
+// splitKeyPath splits a \ separated key path, "" is the key itself
+func splitKeyPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	parts := strings.Split(path, `\`)
+	for _, part := range parts {
+		if part == "" {
+			return nil, errors.ERROR_INVALID_PARAMETER
+		}
+	}
+	return parts, nil
+}
+
+func (node *memKeyNode) subkey(name string) *memKeyNode {
+	for _, subkey := range node.subkeys {
+		if strings.EqualFold(subkey.name, name) {
+			return subkey
+		}
+	}
+	return nil
+}
+
+func (node *memKeyNode) value(name string) *memValue {
+	for _, value := range node.values {
+		if strings.EqualFold(value.name, name) {
+			return value
+		}
+	}
+	return nil
+}
+
+// find returns the key at path below node or nil
+func (node *memKeyNode) find(path string) (*memKeyNode, error) {
+	parts, err := splitKeyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		node = node.subkey(part)
+		if node == nil {
+			return nil, nil
+		}
+	}
+	return node, nil
+}
+
+// create returns the key at path below node, creating missing keys
+func (node *memKeyNode) create(path string) (*memKeyNode, bool, error) {
+	parts, err := splitKeyPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	created := false
+	for _, part := range parts {
+		subkey := node.subkey(part)
+		if subkey == nil {
+			subkey = &memKeyNode{name: part, parent: node, lastWrite: time.Now()}
+			node.subkeys = append(node.subkeys, subkey)
+			node.lastWrite = subkey.lastWrite
+			sort.Slice(node.subkeys, func(i, j int) bool {
+				return strings.ToLower(node.subkeys[i].name) < strings.ToLower(node.subkeys[j].name)
+			})
+			created = true
+		} else {
+			created = false
+		}
+		node = subkey
+	}
+	return node, created, nil
+}
+
+func (node *memKeyNode) setValue(name string, dwType uint32, data []byte) {
+	data = append([]byte(nil), data...)
+	node.lastWrite = time.Now()
+	if value := node.value(name); value != nil {
+		value.dwType = dwType
+		value.data = data
+		return
+	}
+	node.values = append(node.values, &memValue{name: name, dwType: dwType, data: data})
+}
+
+func (node *memKeyNode) deleteValue(name string) error {
+	for i, value := range node.values {
+		if strings.EqualFold(value.name, name) {
+			node.values = append(node.values[:i], node.values[i+1:]...)
+			node.lastWrite = time.Now()
+			return nil
+		}
+	}
+	return errors.ERROR_FILE_NOT_FOUND
+}
+
+// remove unlinks node from its parent and marks the subtree deleted
+func (node *memKeyNode) remove() {
+	if parent := node.parent; parent != nil {
+		for i, subkey := range parent.subkeys {
+			if subkey == node {
+				parent.subkeys = append(parent.subkeys[:i], parent.subkeys[i+1:]...)
+				parent.lastWrite = time.Now()
+				break
+			}
+		}
+	}
+	node.markDeleted()
+}
+
+func (node *memKeyNode) markDeleted() {
+	node.deleted = true
+	for _, subkey := range node.subkeys {
+		subkey.markDeleted()
+	}
+}
+
+func (node *memKeyNode) clone(parent *memKeyNode) *memKeyNode {
+	copied := &memKeyNode{name: node.name, parent: parent, lastWrite: node.lastWrite}
+	for _, value := range node.values {
+		copied.values = append(copied.values, &memValue{name: value.name, dwType: value.dwType, data: value.data})
+	}
+	for _, subkey := range node.subkeys {
+		copied.subkeys = append(copied.subkeys, subkey.clone(copied))
+	}
+	return copied
+}
+
+// check validates the handle, the caller must hold the backend lock
+func (key *memKey) check() error {
+	if key.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	if key.node.deleted {
+		return errors.ERROR_KEY_DELETED
+	}
+	return nil
+}
+
+func (key *memKey) CreateKey(keyName string, samDesired int) (Key, bool, error) {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return nil, false, err
+	}
+	node, created, err := key.node.create(keyName)
+	if err != nil {
+		return nil, false, err
+	}
+	return &memKey{backend: key.backend, node: node}, created, nil
+}
+
+func (key *memKey) OpenKey(keyName string, samDesired int) (Key, error) {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return nil, err
+	}
+	node, err := key.node.find(keyName)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, errors.ERROR_FILE_NOT_FOUND
+	}
+	return &memKey{backend: key.backend, node: node}, nil
+}
+
+func (key *memKey) EnumKeys() ([]string, error) {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, subkey := range key.node.subkeys {
+		names = append(names, subkey.name)
+	}
+	return names, nil
+}
+
+// DeleteKey returns ERROR_ACCESS_DENIED if the subkey has subkeys
+func (key *memKey) DeleteKey(keyName string) error {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return err
+	}
+	node, err := key.node.find(keyName)
+	if err != nil {
+		return err
+	}
+	if node == nil || node == key.node {
+		return errors.ERROR_FILE_NOT_FOUND
+	}
+	if len(node.subkeys) != 0 {
+		return errors.ERROR_ACCESS_DENIED
+	}
+	node.remove()
+	return nil
+}
+
+func (key *memKey) DeleteTree(keyName string) error {
+	return deleteTree(key, keyName)
+}
+
+func (key *memKey) QueryInfo() (KeyInfo, error) {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return KeyInfo{}, err
+	}
+	info := KeyInfo{
+		SubKeys:       uint32(len(key.node.subkeys)),
+		Values:        uint32(len(key.node.values)),
+		LastWriteTime: key.node.lastWrite,
+	}
+	for _, subkey := range key.node.subkeys {
+		info.MaxSubKeyLen = maxUint32(info.MaxSubKeyLen, utf16Len(subkey.name))
+	}
+	for _, value := range key.node.values {
+		info.MaxValueNameLen = maxUint32(info.MaxValueNameLen, utf16Len(value.name))
+		info.MaxValueLen = maxUint32(info.MaxValueLen, uint32(len(value.data)))
+	}
+	return info, nil
+}
+
+func utf16Len(name string) uint32 {
+	return uint32(len(utf16.Encode([]rune(name))))
+}
+
+func maxUint32(a, b uint32) uint32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (key *memKey) SetValue(value string, dwType uint32, data []byte) error {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return err
+	}
+	key.node.setValue(value, dwType, data)
+	return nil
+}
+
+func (key *memKey) QueryValue(valueName string) (dwType uint32, data []byte, err error) {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err = key.check(); err != nil {
+		return
+	}
+	value := key.node.value(valueName)
+	if value == nil {
+		err = errors.ERROR_FILE_NOT_FOUND
+		return
+	}
+	return value.dwType, append([]byte(nil), value.data...), nil
+}
+
+func (key *memKey) DeleteValue(valueName string) error {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return err
+	}
+	return key.node.deleteValue(valueName)
+}
+
+func (key *memKey) LoadValues() (map[string]RegistryValue, error) {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return nil, err
+	}
+	loaded := make(map[string]RegistryValue)
+	for _, value := range key.node.values {
+		loaded[value.name] = RegistryValue{
+			Data:   append([]byte(nil), value.data...),
+			DwType: value.dwType,
+		}
+	}
+	return loaded, nil
+}
+
+func (key *memKey) CreateBatch() (RegBatch, error) {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+
+	if err := key.check(); err != nil {
+		return nil, err
+	}
+	return &memBatch{key: &memKey{backend: key.backend, node: key.node}}, nil
+}
+
+func (key *memKey) Close() error {
+	key.backend.mu.Lock()
+	defer key.backend.mu.Unlock()
+	if key.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	key.closed = true
+	return nil
+}
+
+func (batch *memBatch) BatchAddCommand(command ClusterRegCommand, value string, dwType uint32, data []byte) error {
+	batch.key.backend.mu.Lock()
+	defer batch.key.backend.mu.Unlock()
+
+	if batch.closed {
+		return errors.ERROR_INVALID_HANDLE
+	}
+	switch command {
+	case CLUSREG_SET_VALUE,
+		CLUSREG_CREATE_KEY,
+		CLUSREG_DELETE_KEY,
+		CLUSREG_DELETE_VALUE,
+		CLUSREG_CONDITION_EXISTS,
+		CLUSREG_CONDITION_NOT_EXISTS,
+		CLUSREG_CONDITION_IS_EQUAL,
+		CLUSREG_CONDITION_IS_NOT_EQUAL,
+		CLUSREG_CONDITION_IS_GREATER_THAN,
+		CLUSREG_CONDITION_IS_LESS_THAN,
+		CLUSREG_CONDITION_KEY_EXISTS,
+		CLUSREG_CONDITION_KEY_NOT_EXISTS:
+	default:
+		return errors.ERROR_INVALID_PARAMETER
+	}
+	batch.commands = append(batch.commands, memCommand{
+		command: command,
+		name:    value,
+		dwType:  dwType,
+		data:    append([]byte(nil), data...),
+	})
+	return nil
+}
+
+func (batch *memBatch) CloseBatch(commit bool) (error, int) {
+	batch.key.backend.mu.Lock()
+	defer batch.key.backend.mu.Unlock()
+
+	if batch.closed {
+		return errors.ERROR_INVALID_HANDLE, -1
+	}
+	batch.closed = true
+	if !commit {
+		return nil, 0
+	}
+	if batch.key.node.deleted {
+		return errors.ERROR_KEY_DELETED, -1
+	}
+
+	// run against a copy first so a failing command leaves the tree untouched
+	if err, failed := batch.execute(batch.key.node.clone(nil)); err != nil {
+		return err, failed
+	}
+	return batch.execute(batch.key.node)
+}
+
+func (batch *memBatch) execute(root *memKeyNode) (error, int) {
+	current := root
+	for index, command := range batch.commands {
+		var err error
+		switch command.command {
+		case CLUSREG_SET_VALUE:
+			current.setValue(command.name, command.dwType, command.data)
+		case CLUSREG_CREATE_KEY:
+			current, _, err = root.create(command.name)
+		case CLUSREG_DELETE_KEY:
+			var node *memKeyNode
+			node, err = root.find(command.name)
+			if err == nil && (node == nil || node == root) {
+				err = errors.ERROR_FILE_NOT_FOUND
+			}
+			if err == nil {
+				node.remove()
+				if current.deleted {
+					current = root
+				}
+			}
+		case CLUSREG_DELETE_VALUE:
+			err = current.deleteValue(command.name)
+		default:
+			err = evaluateCondition(current, command)
+		}
+		if err != nil {
+			return err, index
+		}
+	}
+	return nil, 0
+}
+
+func evaluateCondition(current *memKeyNode, command memCommand) error {
+	switch command.command {
+	case CLUSREG_CONDITION_KEY_EXISTS, CLUSREG_CONDITION_KEY_NOT_EXISTS:
+		node, err := current.find(command.name)
+		if err != nil {
+			return err
+		}
+		if command.command == CLUSREG_CONDITION_KEY_EXISTS && node == nil {
+			return errors.ERROR_FILE_NOT_FOUND
+		}
+		if command.command == CLUSREG_CONDITION_KEY_NOT_EXISTS && node != nil {
+			return errors.ERROR_ALREADY_EXISTS
+		}
+		return nil
+	}
+
+	value := current.value(command.name)
+	switch command.command {
+	case CLUSREG_CONDITION_EXISTS:
+		if value == nil {
+			return errors.ERROR_FILE_NOT_FOUND
+		}
+		return nil
+	case CLUSREG_CONDITION_NOT_EXISTS:
+		if value != nil {
+			return errors.ERROR_ALREADY_EXISTS
+		}
+		return nil
+	}
+
+	if value == nil {
+		return errors.ERROR_FILE_NOT_FOUND
+	}
+	equal := value.dwType == command.dwType && bytes.Equal(value.data, command.data)
+	switch command.command {
+	case CLUSREG_CONDITION_IS_EQUAL:
+		if !equal {
+			return errors.ERROR_INVALID_DATA
+		}
+	case CLUSREG_CONDITION_IS_NOT_EQUAL:
+		if equal {
+			return errors.ERROR_INVALID_DATA
+		}
+	case CLUSREG_CONDITION_IS_GREATER_THAN:
+		if compareValueData(value.data, command.data) <= 0 {
+			return errors.ERROR_INVALID_DATA
+		}
+	case CLUSREG_CONDITION_IS_LESS_THAN:
+		if compareValueData(value.data, command.data) >= 0 {
+			return errors.ERROR_INVALID_DATA
+		}
+	}
+	return nil
+}
+
+// compareValueData compares DWORD and QWORD sized data as little endian
+// integers and everything else byte by byte
+func compareValueData(stored []byte, data []byte) int {
+	if len(stored) == len(data) && (len(data) == 4 || len(data) == 8) {
+		var a, b uint64
+		if len(data) == 4 {
+			a, b = uint64(binary.LittleEndian.Uint32(stored)), uint64(binary.LittleEndian.Uint32(data))
+		} else {
+			a, b = binary.LittleEndian.Uint64(stored), binary.LittleEndian.Uint64(data)
+		}
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	return bytes.Compare(stored, data)
+}
@@ -0,0 +1,311 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	memResourceName string = "r1"
+)
+
+func openMemoryKey(t *testing.T) (*MemoryBackend, Key) {
+	backend := NewMemoryBackend("test1")
+	backend.AddResource(memResourceName)
+
+	clus, err := backend.OpenCluster()
+	assert.Nil(t, err)
+	defer clus.Close()
+
+	res, err := clus.OpenResource(memResourceName)
+	assert.Nil(t, err)
+	defer res.Close()
+
+	key, err := res.GetKey(0)
+	assert.Nil(t, err)
+	return backend, key
+}
+
+func TestMemoryOpen(t *testing.T) {
+	backend := NewMemoryBackend("test1")
+	backend.AddResource(memResourceName)
+
+	_, err := backend.OpenRemoteCluster("missing")
+	assert.Equal(t, errors.RPC_S_SERVER_UNAVAILABLE, err)
+
+	clus, err := backend.OpenRemoteCluster("TEST1")
+	assert.Nil(t, err)
+
+	_, err = clus.OpenResource("missing")
+	assert.Equal(t, errors.ERROR_RESOURCE_NOT_FOUND, err)
+
+	res, err := clus.OpenResource("R1")
+	assert.Nil(t, err)
+	assert.Nil(t, res.Close())
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, res.Close())
+
+	_, err = res.GetKey(0)
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err)
+
+	assert.Nil(t, clus.Close())
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, clus.Close())
+	_, err = clus.OpenResource(memResourceName)
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err)
+}
+
+func TestMemoryValues(t *testing.T) {
+	_, key := openMemoryKey(t)
+	defer key.Close()
+
+	_, _, err := key.QueryValue("missing")
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, err)
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, key.DeleteValue("missing"))
+
+	data := []byte{1, 2, 3}
+	assert.Nil(t, key.SetValue("Value", REG_BINARY, data))
+	data[0] = 9
+
+	dwType, queried, err := key.QueryValue("value")
+	assert.Nil(t, err)
+	assert.Equal(t, REG_BINARY, dwType)
+	assert.Equal(t, []byte{1, 2, 3}, queried)
+
+	assert.Nil(t, key.SetValue("VALUE", REG_DWORD, []byte{1, 0, 0, 0}))
+	values, err := key.LoadValues()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]RegistryValue{
+		"Value": {Data: []byte{1, 0, 0, 0}, DwType: REG_DWORD},
+	}, values)
+
+	assert.Nil(t, key.DeleteValue("Value"))
+	values, err = key.LoadValues()
+	assert.Nil(t, err)
+	assert.Empty(t, values)
+}
+
+func TestMemoryKeys(t *testing.T) {
+	backend, root := openMemoryKey(t)
+	defer root.Close()
+
+	key, created, err := root.CreateKey(`a\b`, 0)
+	assert.Nil(t, err)
+	assert.True(t, created)
+	assert.Nil(t, key.SetValue("v", REG_BINARY, nil))
+
+	again, created, err := root.CreateKey(`A\B`, 0)
+	assert.Nil(t, err)
+	assert.False(t, created)
+	_, _, err = again.QueryValue("v")
+	assert.Nil(t, err)
+
+	_, _, err = root.CreateKey(`a\\b`, 0)
+	assert.Equal(t, errors.ERROR_INVALID_PARAMETER, err)
+
+	// the key is shared by every handle to the resource
+	clus, _ := backend.OpenCluster()
+	res, _ := clus.OpenResource(memResourceName)
+	other, err := res.GetKey(0)
+	assert.Nil(t, err)
+	sub, created, err := other.CreateKey("a", 0)
+	assert.Nil(t, err)
+	assert.False(t, created)
+
+	batch, err := sub.CreateBatch()
+	assert.Nil(t, err)
+	assert.Nil(t, batch.BatchAddCommand(CLUSREG_DELETE_KEY, "b", 0, nil))
+	err, _ = batch.CloseBatch(true)
+	assert.Nil(t, err)
+
+	_, _, err = key.QueryValue("v")
+	assert.Equal(t, errors.ERROR_KEY_DELETED, err)
+
+	key.Close()
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, key.SetValue("v", REG_BINARY, nil))
+}
+
+func TestMemoryBatch(t *testing.T) {
+	_, key := openMemoryKey(t)
+	defer key.Close()
+
+	assert.Nil(t, key.SetValue("guid", REG_BINARY, []byte{1}))
+
+	batch, err := key.CreateBatch()
+	assert.Nil(t, err)
+	assert.Nil(t, batch.BatchAddCommand(CLUSREG_CONDITION_IS_EQUAL, "guid", REG_BINARY, []byte{1}))
+	assert.Nil(t, batch.BatchAddCommand(CLUSREG_CREATE_KEY, "sub", 0, nil))
+	assert.Nil(t, batch.BatchAddCommand(CLUSREG_SET_VALUE, "inner", REG_BINARY, []byte{2}))
+	assert.Nil(t, batch.BatchAddCommand(CLUSREG_CREATE_KEY, "", 0, nil))
+	assert.Nil(t, batch.BatchAddCommand(CLUSREG_DELETE_VALUE, "guid", 0, nil))
+	err, _ = batch.CloseBatch(true)
+	assert.Nil(t, err)
+
+	sub, created, err := key.CreateKey("sub", 0)
+	assert.Nil(t, err)
+	assert.False(t, created)
+	_, data, err := sub.QueryValue("inner")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{2}, data)
+	_, _, err = key.QueryValue("guid")
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, err)
+
+	err = batch.BatchAddCommand(CLUSREG_SET_VALUE, "closed", REG_BINARY, nil)
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err)
+}
+
+func TestMemoryBatchConditions(t *testing.T) {
+	_, key := openMemoryKey(t)
+	defer key.Close()
+
+	assert.Nil(t, key.SetValue("count", REG_DWORD, []byte{5, 0, 0, 0}))
+
+	tests := []struct {
+		name      string
+		condition ClusterRegCommand
+		value     string
+		data      []byte
+		err       error
+	}{
+		{"Exists", CLUSREG_CONDITION_EXISTS, "count", nil, nil},
+		{"ExistsMissing", CLUSREG_CONDITION_EXISTS, "missing", nil, errors.ERROR_FILE_NOT_FOUND},
+		{"NotExists", CLUSREG_CONDITION_NOT_EXISTS, "missing", nil, nil},
+		{"NotExistsPresent", CLUSREG_CONDITION_NOT_EXISTS, "count", nil, errors.ERROR_ALREADY_EXISTS},
+		{"IsEqual", CLUSREG_CONDITION_IS_EQUAL, "count", []byte{5, 0, 0, 0}, nil},
+		{"IsEqualDiffers", CLUSREG_CONDITION_IS_EQUAL, "count", []byte{6, 0, 0, 0}, errors.ERROR_INVALID_DATA},
+		{"IsNotEqual", CLUSREG_CONDITION_IS_NOT_EQUAL, "count", []byte{6, 0, 0, 0}, nil},
+		{"IsGreaterThan", CLUSREG_CONDITION_IS_GREATER_THAN, "count", []byte{0, 0, 0, 0}, nil},
+		{"IsGreaterThanFails", CLUSREG_CONDITION_IS_GREATER_THAN, "count", []byte{0, 1, 0, 0}, errors.ERROR_INVALID_DATA},
+		{"IsLessThan", CLUSREG_CONDITION_IS_LESS_THAN, "count", []byte{0, 1, 0, 0}, nil},
+		{"KeyNotExists", CLUSREG_CONDITION_KEY_NOT_EXISTS, "sub", nil, nil},
+		{"KeyExistsMissing", CLUSREG_CONDITION_KEY_EXISTS, "sub", nil, errors.ERROR_FILE_NOT_FOUND},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batch, err := key.CreateBatch()
+			assert.Nil(t, err)
+			assert.Nil(t, batch.BatchAddCommand(CLUSREG_SET_VALUE, "before", REG_BINARY, nil))
+			assert.Nil(t, batch.BatchAddCommand(test.condition, test.value, REG_DWORD, test.data))
+			assert.Nil(t, batch.BatchAddCommand(CLUSREG_SET_VALUE, test.name, REG_BINARY, nil))
+
+			err, failed := batch.CloseBatch(true)
+			assert.Equal(t, test.err, err)
+
+			_, _, queryErr := key.QueryValue(test.name)
+			if test.err == nil {
+				assert.Nil(t, queryErr)
+			} else {
+				assert.Equal(t, 1, failed)
+				assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, queryErr)
+				// nothing from a failed batch is applied
+				_, _, queryErr = key.QueryValue("before")
+				assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, queryErr)
+			}
+			key.DeleteValue("before")
+		})
+	}
+}
+
+func TestMemoryBatchDiscard(t *testing.T) {
+	_, key := openMemoryKey(t)
+	defer key.Close()
+
+	batch, err := key.CreateBatch()
+	assert.Nil(t, err)
+	assert.Nil(t, batch.BatchAddCommand(CLUSREG_SET_VALUE, "guid", REG_BINARY, []byte{1}))
+	assert.Equal(t, errors.ERROR_INVALID_PARAMETER, batch.BatchAddCommand(CLUSREG_READ_KEY, "", 0, nil))
+	err, _ = batch.CloseBatch(false)
+	assert.Nil(t, err)
+
+	_, _, err = key.QueryValue("guid")
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, err)
+}
+
+func TestMemoryKeyTree(t *testing.T) {
+	_, root := openMemoryKey(t)
+	defer root.Close()
+
+	_, err := root.OpenKey("a", KEY_READ)
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, err)
+
+	for _, path := range []string{`b\x`, `a\y\z`, `a\x`} {
+		key, _, err := root.CreateKey(path, KEY_ALL_ACCESS)
+		assert.Nil(t, err)
+		assert.Nil(t, key.SetValue("value", REG_BINARY, []byte{1, 2}))
+		key.Close()
+	}
+	assert.Nil(t, root.SetValue("rootValue", REG_BINARY, nil))
+
+	names, err := root.EnumKeys()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, names)
+
+	key, err := root.OpenKey("A", KEY_READ)
+	assert.Nil(t, err)
+	names, err = key.EnumKeys()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"x", "y"}, names)
+
+	info, err := root.QueryInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), info.SubKeys)
+	assert.Equal(t, uint32(1), info.MaxSubKeyLen)
+	assert.Equal(t, uint32(1), info.Values)
+	assert.Equal(t, uint32(9), info.MaxValueNameLen)
+	assert.Equal(t, uint32(0), info.MaxValueLen)
+	assert.False(t, info.LastWriteTime.IsZero())
+
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, root.DeleteKey("a"))
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, root.DeleteKey("missing"))
+	assert.Nil(t, root.DeleteKey(`a\x`))
+
+	assert.Nil(t, root.DeleteTree("a"))
+	_, err = key.EnumKeys()
+	assert.Equal(t, errors.ERROR_KEY_DELETED, err)
+	assert.Equal(t, errors.ERROR_FILE_NOT_FOUND, root.DeleteTree("a"))
+
+	assert.Nil(t, root.DeleteTree(""))
+	info, err = root.QueryInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), info.SubKeys)
+	assert.Equal(t, uint32(0), info.Values)
+}
+
+// racingKey deletes a value before DeleteValue like another writer would,
+// DeleteValue then fails with the wrapped ERROR_FILE_NOT_FOUND of a KeyHandle
+type racingKey struct {
+	Key
+}
+
+func (key racingKey) OpenKey(keyName string, samDesired int) (Key, error) {
+	subkey, err := key.Key.OpenKey(keyName, samDesired)
+	if err != nil {
+		return nil, err
+	}
+	return racingKey{subkey}, nil
+}
+
+func (key racingKey) DeleteValue(valueName string) error {
+	if err := key.Key.DeleteValue(valueName); err != nil {
+		return err
+	}
+	return errors.Wrap("ClusterRegDeleteValue", valueName, errors.ERROR_FILE_NOT_FOUND)
+}
+
+func TestDeleteTreeValueDeletedConcurrently(t *testing.T) {
+	_, root := openMemoryKey(t)
+	defer root.Close()
+
+	key, _, err := root.CreateKey(`a\x`, KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	assert.Nil(t, key.SetValue("value", REG_BINARY, []byte{1, 2}))
+	key.Close()
+	assert.Nil(t, root.SetValue("rootValue", REG_BINARY, nil))
+
+	assert.Nil(t, deleteTree(racingKey{root}, ""))
+	info, err := root.QueryInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), info.SubKeys)
+	assert.Equal(t, uint32(0), info.Values)
+}
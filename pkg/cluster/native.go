@@ -0,0 +1,128 @@
+package cluster
+
+// NativeBackend opens clusters through clusapi.dll
+var NativeBackend Backend = nativeBackend{}
+
+var _ RegBatch = RegBatchHandle(0)
+
+type (
+	nativeBackend  struct{}
+	nativeCluster  struct{ handle ClusterHandle }
+	nativeResource struct{ handle ResourceHandle }
+	nativeKey      struct{ handle KeyHandle }
+)
+
+// NativeCluster wraps an opened ClusterHandle as a Cluster
+func NativeCluster(handle ClusterHandle) Cluster {
+	return nativeCluster{handle}
+}
+
+// NativeResource wraps an opened ResourceHandle as a Resource
+func NativeResource(handle ResourceHandle) Resource {
+	return nativeResource{handle}
+}
+
+// NativeKey wraps an opened KeyHandle as a Key
+func NativeKey(handle KeyHandle) Key {
+	return nativeKey{handle}
+}
+
+func (nativeBackend) OpenCluster() (Cluster, error) {
+	handle, err := OpenCluster()
+	if err != nil {
+		return nil, err
+	}
+	return NativeCluster(handle), nil
+}
+
+func (nativeBackend) OpenRemoteCluster(clusterName string) (Cluster, error) {
+	handle, err := OpenRemoteCluster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return NativeCluster(handle), nil
+}
+
+func (cluster nativeCluster) OpenResource(resourceName string) (Resource, error) {
+	handle, err := cluster.handle.OpenResource(resourceName)
+	if err != nil {
+		return nil, err
+	}
+	return NativeResource(handle), nil
+}
+
+func (cluster nativeCluster) Close() error {
+	return cluster.handle.Close()
+}
+
+func (resource nativeResource) GetKey(samDesired int) (Key, error) {
+	handle, err := resource.handle.GetKey(samDesired)
+	if err != nil {
+		return nil, err
+	}
+	return NativeKey(handle), nil
+}
+
+func (resource nativeResource) Close() error {
+	return resource.handle.Close()
+}
+
+func (key nativeKey) CreateKey(keyName string, samDesired int) (Key, bool, error) {
+	handle, created, err := key.handle.CreateKey(keyName, samDesired)
+	if err != nil {
+		return nil, created, err
+	}
+	return NativeKey(handle), created, nil
+}
+
+func (key nativeKey) OpenKey(keyName string, samDesired int) (Key, error) {
+	handle, err := key.handle.OpenKey(keyName, samDesired)
+	if err != nil {
+		return nil, err
+	}
+	return NativeKey(handle), nil
+}
+
+func (key nativeKey) EnumKeys() ([]string, error) {
+	return key.handle.EnumKeys()
+}
+
+func (key nativeKey) DeleteKey(keyName string) error {
+	return key.handle.DeleteKey(keyName)
+}
+
+func (key nativeKey) DeleteTree(keyName string) error {
+	return key.handle.DeleteTree(keyName)
+}
+
+func (key nativeKey) QueryInfo() (KeyInfo, error) {
+	return key.handle.QueryInfo()
+}
+
+func (key nativeKey) SetValue(value string, dwType uint32, data []byte) error {
+	return key.handle.SetValue(value, dwType, data)
+}
+
+func (key nativeKey) QueryValue(valueName string) (uint32, []byte, error) {
+	return key.handle.QueryValue(valueName)
+}
+
+func (key nativeKey) DeleteValue(valueName string) error {
+	return key.handle.DeleteValue(valueName)
+}
+
+func (key nativeKey) LoadValues() (map[string]RegistryValue, error) {
+	return key.handle.LoadValues()
+}
+
+func (key nativeKey) CreateBatch() (RegBatch, error) {
+	handle, err := key.handle.CreateBatch()
+	if err != nil {
+		return nil, err
+	}
+	return handle, nil
+}
+
+func (key nativeKey) Close() error {
+	return key.handle.Close()
+}
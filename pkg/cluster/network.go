@@ -0,0 +1,6 @@
+package cluster
+
+type (
+	NetworkHandle      uintptr
+	NetInterfaceHandle uintptr
+)
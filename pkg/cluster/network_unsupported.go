@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import "github.com/KnicKnic/go-windows/pkg/errors"
+
+func (cluster ClusterHandle) OpenNetwork(networkName string) (handle NetworkHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle NetworkHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) OpenNetInterface(interfaceName string) (handle NetInterfaceHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle NetInterfaceHandle) Close() error {
+	return errors.ErrNotSupported
+}
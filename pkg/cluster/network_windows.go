@@ -0,0 +1,70 @@
+package cluster
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procnativeOpenClusterNetwork       = clusapi_dll.NewProc("OpenClusterNetwork")
+	procnativeCloseClusterNetwork      = clusapi_dll.NewProc("CloseClusterNetwork")
+	procnativeOpenClusterNetInterface  = clusapi_dll.NewProc("OpenClusterNetInterface")
+	procnativeCloseClusterNetInterface = clusapi_dll.NewProc("CloseClusterNetInterface")
+)
+
+func openClusterNetwork(cluster ClusterHandle, networkName *uint16) (NetworkHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeOpenClusterNetwork.Addr(), 2, uintptr(cluster), uintptr(unsafe.Pointer(networkName)), 0)
+	handle := NetworkHandle(r0)
+	return handle, handles.opened(networkHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func (cluster ClusterHandle) OpenNetwork(networkName string) (handle NetworkHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeOpenClusterNetwork.Name, networkName, err) }()
+	nn, err := windows.UTF16PtrFromString(networkName)
+	if err != nil {
+		return
+	}
+	handle, err = openClusterNetwork(cluster, nn)
+	return
+}
+
+func closeClusterNetwork(handle NetworkHandle) error {
+	r0, _, lastError := syscall.Syscall(procnativeCloseClusterNetwork.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotNill(r0, lastError)
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle NetworkHandle) Close() error {
+	err := handles.closed(networkHandleKind, uintptr(handle), func() error { return closeClusterNetwork(handle) })
+	return errors.Wrap(procnativeCloseClusterNetwork.Name, "", err)
+}
+
+func openClusterNetInterface(cluster ClusterHandle, interfaceName *uint16) (NetInterfaceHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeOpenClusterNetInterface.Addr(), 2, uintptr(cluster), uintptr(unsafe.Pointer(interfaceName)), 0)
+	handle := NetInterfaceHandle(r0)
+	return handle, handles.opened(netInterfaceHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func (cluster ClusterHandle) OpenNetInterface(interfaceName string) (handle NetInterfaceHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeOpenClusterNetInterface.Name, interfaceName, err) }()
+	in, err := windows.UTF16PtrFromString(interfaceName)
+	if err != nil {
+		return
+	}
+	handle, err = openClusterNetInterface(cluster, in)
+	return
+}
+
+func closeClusterNetInterface(handle NetInterfaceHandle) error {
+	r0, _, lastError := syscall.Syscall(procnativeCloseClusterNetInterface.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotNill(r0, lastError)
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle NetInterfaceHandle) Close() error {
+	err := handles.closed(netInterfaceHandleKind, uintptr(handle), func() error { return closeClusterNetInterface(handle) })
+	return errors.Wrap(procnativeCloseClusterNetInterface.Name, "", err)
+}
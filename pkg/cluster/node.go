@@ -0,0 +1,6 @@
+package cluster
+
+type (
+	NodeHandle     uintptr
+	NodeEnumHandle uintptr
+)
@@ -0,0 +1,82 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+func (cluster ClusterHandle) OpenNode(nodeName string) (handle NodeHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) OpenNodeEx(nodeName string, desiredAccess uint32) (handle NodeHandle, grantedAccess uint32, err error) {
+	return 0, 0, errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle NodeHandle) State() (ClusterNodeState, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Id() (string, error) {
+	return "", errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Pause() error {
+	return errors.ErrNotSupported
+}
+
+func (handle NodeHandle) PauseEx(drain bool, flags uint32, drainTarget NodeHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Resume() error {
+	return errors.ErrNotSupported
+}
+
+func (handle NodeHandle) ResumeEx(failback ClusterNodeResumeFailbackType) error {
+	return errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Evict() error {
+	return errors.ErrNotSupported
+}
+
+func (handle NodeHandle) GetKey(samDesired int) (KeyHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle NodeHandle) OpenEnum(enumType ClusterNodeEnumType) (NodeEnumHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Enumerate(enumType ClusterNodeEnumType) (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle NodeHandle) Groups() (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle NodeHandle) WaitDrained(ctx context.Context) ([]string, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle NodeEnumHandle) Count() uint32 {
+	return 0
+}
+
+func (handle NodeEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	return ClusterEnumItem{}, errors.ErrNotSupported
+}
+
+func (handle NodeEnumHandle) Close() error {
+	return errors.ErrNotSupported
+}
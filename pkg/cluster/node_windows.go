@@ -0,0 +1,323 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procnativeOpenClusterNode         = clusapi_dll.NewProc("OpenClusterNode")
+	procnativeOpenClusterNodeEx       = clusapi_dll.NewProc("OpenClusterNodeEx")
+	procnativeCloseClusterNode        = clusapi_dll.NewProc("CloseClusterNode")
+	procnativeGetClusterNodeState     = clusapi_dll.NewProc("GetClusterNodeState")
+	procnativeGetClusterNodeId        = clusapi_dll.NewProc("GetClusterNodeId")
+	procnativePauseClusterNode        = clusapi_dll.NewProc("PauseClusterNode")
+	procnativePauseClusterNodeEx      = clusapi_dll.NewProc("PauseClusterNodeEx")
+	procnativeResumeClusterNode       = clusapi_dll.NewProc("ResumeClusterNode")
+	procnativeResumeClusterNodeEx     = clusapi_dll.NewProc("ResumeClusterNodeEx")
+	procnativeEvictClusterNode        = clusapi_dll.NewProc("EvictClusterNode")
+	procnativeGetClusterNodeKey       = clusapi_dll.NewProc("GetClusterNodeKey")
+	procnativeClusterNodeOpenEnum     = clusapi_dll.NewProc("ClusterNodeOpenEnum")
+	procnativeClusterNodeGetEnumCount = clusapi_dll.NewProc("ClusterNodeGetEnumCount")
+	procnativeClusterNodeEnum         = clusapi_dll.NewProc("ClusterNodeEnum")
+	procnativeClusterNodeCloseEnum    = clusapi_dll.NewProc("ClusterNodeCloseEnum")
+)
+
+func openClusterNode(cluster ClusterHandle, nodeName *uint16) (NodeHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeOpenClusterNode.Addr(), 2, uintptr(cluster), uintptr(unsafe.Pointer(nodeName)), 0)
+	handle := NodeHandle(r0)
+	return handle, handles.opened(nodeHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func (cluster ClusterHandle) OpenNode(nodeName string) (handle NodeHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeOpenClusterNode.Name, nodeName, err) }()
+	nn, err := windows.UTF16PtrFromString(nodeName)
+	if err != nil {
+		return
+	}
+	handle, err = openClusterNode(cluster, nn)
+	return
+}
+
+// openNodes opens every node in names, on error the nodes
+// already opened are closed
+func (cluster ClusterHandle) openNodes(names []string) ([]NodeHandle, error) {
+	nodes := make([]NodeHandle, 0, len(names))
+	for _, name := range names {
+		node, err := cluster.OpenNode(name)
+		if err != nil {
+			closeNodes(nodes)
+			return nil, fmt.Errorf("open node %s: %w", name, err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func closeNodes(nodes []NodeHandle) {
+	for _, node := range nodes {
+		node.Close()
+	}
+}
+
+func openClusterNodeEx(cluster ClusterHandle, nodeName *uint16, desiredAccess uint32, grantedAccess *uint32) (NodeHandle, error) {
+	r0, _, lastError := syscall.Syscall6(procnativeOpenClusterNodeEx.Addr(),
+		4,
+		uintptr(cluster),
+		uintptr(unsafe.Pointer(nodeName)),
+		uintptr(desiredAccess),
+		uintptr(unsafe.Pointer(grantedAccess)),
+		0,
+		0)
+	handle := NodeHandle(r0)
+	return handle, handles.opened(nodeHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// OpenNodeEx opens the node with desiredAccess and returns the access that was granted
+// for desiredAccess use windows.GENERIC_READ or windows.GENERIC_ALL
+func (cluster ClusterHandle) OpenNodeEx(nodeName string, desiredAccess uint32) (handle NodeHandle, grantedAccess uint32, err error) {
+	defer func() { err = errors.Wrap(procnativeOpenClusterNodeEx.Name, nodeName, err) }()
+	nn, err := windows.UTF16PtrFromString(nodeName)
+	if err != nil {
+		return
+	}
+	handle, err = openClusterNodeEx(cluster, nn, desiredAccess, &grantedAccess)
+	return
+}
+
+func closeClusterNode(handle NodeHandle) error {
+	r0, _, lastError := syscall.Syscall(procnativeCloseClusterNode.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotNill(r0, lastError)
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle NodeHandle) Close() error {
+	err := handles.closed(nodeHandleKind, uintptr(handle), func() error { return closeClusterNode(handle) })
+	return errors.Wrap(procnativeCloseClusterNode.Name, "", err)
+}
+
+// State returns the state of the node,
+// ERROR_INVALID_STATE if the state is unknown without an error from the api
+func (handle NodeHandle) State() (ClusterNodeState, error) {
+	r0, _, lastError := syscall.Syscall(procnativeGetClusterNodeState.Addr(), 1, uintptr(handle), 0, 0)
+	state := ClusterNodeState(r0)
+	if state != ClusterNodeStateUnknown {
+		return state, nil
+	}
+	err := errors.NotZero(lastError)
+	if err == nil {
+		// the api failed without setting a last error
+		err = errors.ERROR_INVALID_STATE
+	}
+	return state, errors.Wrap(procnativeGetClusterNodeState.Name, "", err)
+}
+
+func getClusterNodeId(handle NodeHandle) (nodeId string, err error) {
+	idCCh := uint32(50)
+
+	var idArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		idCCh += 2
+		idArr = make([]uint16, idCCh)
+		r0, _, _ := syscall.Syscall(procnativeGetClusterNodeId.Addr(),
+			3,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&idArr[0])),
+			uintptr(unsafe.Pointer(&idCCh)))
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	nodeId = syscall.UTF16ToString(idArr[:idCCh])
+	return
+}
+
+// Id returns the unique id of the node
+func (handle NodeHandle) Id() (string, error) {
+	nodeId, err := getClusterNodeId(handle)
+	return nodeId, errors.Wrap(procnativeGetClusterNodeId.Name, "", err)
+}
+
+func pauseClusterNode(handle NodeHandle) error {
+	r0, _, _ := syscall.Syscall(procnativePauseClusterNode.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Pause pauses the node without moving its groups
+func (handle NodeHandle) Pause() error {
+	return errors.Wrap(procnativePauseClusterNode.Name, "", pauseClusterNode(handle))
+}
+
+func pauseClusterNodeEx(handle NodeHandle, drain bool, flags uint32, drainTarget NodeHandle) error {
+	var bDrain uintptr
+	if drain {
+		bDrain = 1
+	}
+	r0, _, _ := syscall.Syscall6(procnativePauseClusterNodeEx.Addr(),
+		4,
+		uintptr(handle),
+		bDrain,
+		uintptr(flags),
+		uintptr(drainTarget),
+		0,
+		0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// PauseEx pauses the node, if drain is set the groups are moved to drainTarget
+// pass 0 to let the cluster pick the nodes, for flags use the CLUSAPI_NODE_ values
+// the drain continues after PauseEx returns, see WaitDrained
+func (handle NodeHandle) PauseEx(drain bool, flags uint32, drainTarget NodeHandle) error {
+	return errors.Wrap(procnativePauseClusterNodeEx.Name, "", pauseClusterNodeEx(handle, drain, flags, drainTarget))
+}
+
+func resumeClusterNode(handle NodeHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeResumeClusterNode.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Resume resumes the paused node
+func (handle NodeHandle) Resume() error {
+	return errors.Wrap(procnativeResumeClusterNode.Name, "", resumeClusterNode(handle))
+}
+
+func resumeClusterNodeEx(handle NodeHandle, failback ClusterNodeResumeFailbackType) error {
+	r0, _, _ := syscall.Syscall(procnativeResumeClusterNodeEx.Addr(), 3, uintptr(handle), uintptr(failback), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// ResumeEx resumes the paused node and fails back its groups as set by failback
+func (handle NodeHandle) ResumeEx(failback ClusterNodeResumeFailbackType) error {
+	return errors.Wrap(procnativeResumeClusterNodeEx.Name, "", resumeClusterNodeEx(handle, failback))
+}
+
+func evictClusterNode(handle NodeHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeEvictClusterNode.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Evict removes the node from the cluster, the handle still needs to be closed
+func (handle NodeHandle) Evict() error {
+	return errors.Wrap(procnativeEvictClusterNode.Name, "", evictClusterNode(handle))
+}
+
+// GetKey gets a cluster registry key for the node
+// for samDesired use KEY_ALL_ACCESS KEY_READ KEY_WRITE KEY_SET_VALUE
+func (handle NodeHandle) GetKey(samDesired int) (KeyHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeGetClusterNodeKey.Addr(), 2, uintptr(handle), uintptr(samDesired), 0)
+	key := KeyHandle(r0)
+	err := handles.opened(keyHandleKind, r0, errors.NotNill(r0, lastError))
+	return key, errors.Wrap(procnativeGetClusterNodeKey.Name, "", err)
+}
+
+func clusterNodeOpenEnum(handle NodeHandle, enumType ClusterNodeEnumType) (NodeEnumHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeClusterNodeOpenEnum.Addr(), 2, uintptr(handle), uintptr(enumType), 0)
+	enum := NodeEnumHandle(r0)
+	return enum, handles.opened(nodeEnumHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// OpenEnum opens an enumeration of the network interfaces
+// or the owned or preferred groups of the node
+func (handle NodeHandle) OpenEnum(enumType ClusterNodeEnumType) (NodeEnumHandle, error) {
+	enum, err := clusterNodeOpenEnum(handle, enumType)
+	return enum, errors.Wrap(procnativeClusterNodeOpenEnum.Name, "", err)
+}
+
+// Enumerate iterates the node objects of enumType
+func (handle NodeHandle) Enumerate(enumType ClusterNodeEnumType) (*ClusterIterator, error) {
+	enum, err := handle.OpenEnum(enumType)
+	if err != nil {
+		return nil, err
+	}
+	return newClusterIterator(enum), nil
+}
+
+// Groups iterates the groups owned by the node
+func (handle NodeHandle) Groups() (*ClusterIterator, error) {
+	return handle.Enumerate(CLUSTER_NODE_ENUM_GROUPS)
+}
+
+// WaitDrained polls the groups owned by the node until there are none or ctx
+// is done and returns the groups still on the node, use it after PauseEx with drain
+func (handle NodeHandle) WaitDrained(ctx context.Context) ([]string, error) {
+	names, err := waitDrained(ctx, func() ([]string, error) {
+		it, err := handle.Groups()
+		if err != nil {
+			return nil, err
+		}
+		items, err := it.All()
+		names := make([]string, 0, len(items))
+		for _, item := range items {
+			names = append(names, item.Name)
+		}
+		return names, err
+	}, ResourcePollInterval)
+	return names, errors.Wrap(procnativeClusterNodeEnum.Name, "", err)
+}
+
+// Count returns the number of objects in the enumeration
+func (handle NodeEnumHandle) Count() uint32 {
+	r0, _, _ := syscall.Syscall(procnativeClusterNodeGetEnumCount.Addr(), 1, uintptr(handle), 0, 0)
+	return uint32(r0)
+}
+
+func clusterNodeEnum(handle NodeEnumHandle, index uint32) (item ClusterEnumItem, err error) {
+	nameCCh := uint32(50)
+
+	var dwType uint32
+	var nameArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nameCCh += 2
+		nameArr = make([]uint16, nameCCh)
+		r0, _, _ := syscall.Syscall6(procnativeClusterNodeEnum.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&dwType)),
+			uintptr(unsafe.Pointer(&nameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	item.Type = ClusterNodeEnumType(dwType).ClusterEnumType()
+	item.ObjectEnumType = dwType
+	item.Name = syscall.UTF16ToString(nameArr[:nameCCh])
+	return
+}
+
+// Enum returns the object at index, Type is CLUSTER_ENUM_NETINTERFACE or CLUSTER_ENUM_GROUP
+// and ObjectEnumType the ClusterNodeEnumType, returns ERROR_NO_MORE_ITEMS after the last object
+func (handle NodeEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	item, err := clusterNodeEnum(handle, index)
+	return item, wrapEnumError(procnativeClusterNodeEnum.Name, err)
+}
+
+func (handle NodeEnumHandle) item(index uint32) (ClusterEnumItem, error) {
+	return handle.Enum(index)
+}
+
+func clusterNodeCloseEnum(handle NodeEnumHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterNodeCloseEnum.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle NodeEnumHandle) Close() error {
+	err := handles.closed(nodeEnumHandleKind, uintptr(handle), func() error { return clusterNodeCloseEnum(handle) })
+	return errors.Wrap(procnativeClusterNodeCloseEnum.Name, "", err)
+}
@@ -0,0 +1,40 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	it, err := cluster.Nodes()
+	assert.Nil(t, err, "error should be null")
+	nodes, err := it.All()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEmpty(t, nodes)
+
+	node, err := cluster.OpenNode(nodes[0].Name)
+	assert.Nil(t, err, "error should be null")
+	defer node.Close()
+
+	state, err := node.State()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEqual(t, ClusterNodeStateUnknown, state)
+
+	id, err := node.Id()
+	assert.Nil(t, err, "error should be null")
+	assert.Equal(t, nodes[0].ID, id)
+
+	key, err := node.GetKey(KEY_READ)
+	assert.Nil(t, err, "error should be null")
+	key.Close()
+
+	groups, err := node.Groups()
+	assert.Nil(t, err, "error should be null")
+	_, err = groups.All()
+	assert.Nil(t, err, "error should be null")
+}
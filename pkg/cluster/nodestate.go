@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ClusterNodeState is CLUSTER_NODE_STATE
+type ClusterNodeState int32
+
+const (
+	ClusterNodeStateUnknown ClusterNodeState = -1
+	ClusterNodeUp           ClusterNodeState = 0
+	ClusterNodeDown         ClusterNodeState = 1
+	ClusterNodePaused       ClusterNodeState = 2
+	ClusterNodeJoining      ClusterNodeState = 3
+)
+
+var clusterNodeStateNames = map[ClusterNodeState]string{
+	ClusterNodeStateUnknown: "ClusterNodeStateUnknown",
+	ClusterNodeUp:           "ClusterNodeUp",
+	ClusterNodeDown:         "ClusterNodeDown",
+	ClusterNodePaused:       "ClusterNodePaused",
+	ClusterNodeJoining:      "ClusterNodeJoining",
+}
+
+func (state ClusterNodeState) String() string {
+	if name, ok := clusterNodeStateNames[state]; ok {
+		return name
+	}
+	return fmt.Sprintf("ClusterNodeState(%d)", int32(state))
+}
+
+// PauseEx flags
+const (
+	CLUSAPI_NODE_PAUSE_REMAIN_ON_PAUSED_NODE_ON_MOVE_ERROR uint32 = 0x00000001
+	CLUSAPI_NODE_AVOID_PLACEMENT                           uint32 = 0x00000002
+	CLUSAPI_NODE_PAUSE_RETRY_DRAIN_ON_FAILURE              uint32 = 0x00000004
+)
+
+// ClusterNodeResumeFailbackType is CLUSTER_NODE_RESUME_FAILBACK_TYPE, used by ResumeEx
+type ClusterNodeResumeFailbackType uint32
+
+const (
+	DoNotFailbackGroups       ClusterNodeResumeFailbackType = 0
+	FailbackGroupsImmediately ClusterNodeResumeFailbackType = 1
+	FailbackGroupsPerPolicy   ClusterNodeResumeFailbackType = 2
+)
+
+// ClusterNodeEnumType selects what NodeHandle.OpenEnum enumerates
+type ClusterNodeEnumType uint32
+
+const (
+	CLUSTER_NODE_ENUM_NETINTERFACES    ClusterNodeEnumType = 0x00000001
+	CLUSTER_NODE_ENUM_GROUPS           ClusterNodeEnumType = 0x00000002
+	CLUSTER_NODE_ENUM_PREFERRED_GROUPS ClusterNodeEnumType = 0x00000004
+)
+
+// ClusterEnumType maps the node enumeration type onto the cluster one
+// so node enumerations return the same ClusterEnumItem, interfaces are
+// CLUSTER_ENUM_NETINTERFACE and owned or preferred groups CLUSTER_ENUM_GROUP
+func (enumType ClusterNodeEnumType) ClusterEnumType() ClusterEnumType {
+	var mapped ClusterEnumType
+	if enumType&CLUSTER_NODE_ENUM_NETINTERFACES != 0 {
+		mapped |= CLUSTER_ENUM_NETINTERFACE
+	}
+	if enumType&(CLUSTER_NODE_ENUM_GROUPS|CLUSTER_NODE_ENUM_PREFERRED_GROUPS) != 0 {
+		mapped |= CLUSTER_ENUM_GROUP
+	}
+	return mapped
+}
+
+// waitDrained polls ownedGroups until it returns no groups or ctx is done
+// and returns the groups still owned by the node
+func waitDrained(ctx context.Context, ownedGroups func() ([]string, error), interval time.Duration) (groups []string, err error) {
+	err = pollUntil(ctx, interval, func() (bool, error) {
+		var groupsErr error
+		groups, groupsErr = ownedGroups()
+		return len(groups) == 0, groupsErr
+	})
+	return
+}
@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterNodeState(t *testing.T) {
+	assert.Equal(t, "ClusterNodePaused", ClusterNodePaused.String())
+	assert.Equal(t, "ClusterNodeStateUnknown", ClusterNodeStateUnknown.String())
+	assert.Equal(t, "ClusterNodeState(4)", ClusterNodeState(4).String())
+}
+
+func TestClusterNodeEnumType(t *testing.T) {
+	assert.Equal(t, CLUSTER_ENUM_NETINTERFACE, CLUSTER_NODE_ENUM_NETINTERFACES.ClusterEnumType())
+	assert.Equal(t, CLUSTER_ENUM_GROUP, CLUSTER_NODE_ENUM_GROUPS.ClusterEnumType())
+	assert.Equal(t, CLUSTER_ENUM_GROUP, CLUSTER_NODE_ENUM_PREFERRED_GROUPS.ClusterEnumType())
+}
+
+func TestWaitDrained(t *testing.T) {
+	owned := [][]string{{"g1", "g2"}, {"g2"}, {}}
+	calls := 0
+	groups, err := waitDrained(context.Background(), func() ([]string, error) {
+		calls++
+		return owned[calls-1], nil
+	}, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Empty(t, groups)
+	assert.Equal(t, 3, calls)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	groups, err = waitDrained(ctx, func() ([]string, error) {
+		return []string{"stuck"}, nil
+	}, time.Millisecond)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, []string{"stuck"}, groups)
+
+	_, err = waitDrained(context.Background(), func() ([]string, error) {
+		return nil, errors.ERROR_INVALID_HANDLE
+	}, time.Millisecond)
+	assert.Equal(t, errors.ERROR_INVALID_HANDLE, err)
+}
@@ -0,0 +1,253 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+)
+
+// ClusterObjectType is CLUSTER_OBJECT_TYPE
+type ClusterObjectType uint32
+
+const (
+	CLUSTER_OBJECT_TYPE_NONE              ClusterObjectType = 0
+	CLUSTER_OBJECT_TYPE_CLUSTER           ClusterObjectType = 1
+	CLUSTER_OBJECT_TYPE_GROUP             ClusterObjectType = 2
+	CLUSTER_OBJECT_TYPE_RESOURCE          ClusterObjectType = 3
+	CLUSTER_OBJECT_TYPE_RESOURCE_TYPE     ClusterObjectType = 4
+	CLUSTER_OBJECT_TYPE_NETWORK_INTERFACE ClusterObjectType = 5
+	CLUSTER_OBJECT_TYPE_NETWORK           ClusterObjectType = 6
+	CLUSTER_OBJECT_TYPE_NODE              ClusterObjectType = 7
+	CLUSTER_OBJECT_TYPE_REGISTRY          ClusterObjectType = 8
+	CLUSTER_OBJECT_TYPE_QUORUM            ClusterObjectType = 9
+	CLUSTER_OBJECT_TYPE_SHARED_VOLUME     ClusterObjectType = 10
+)
+
+var clusterObjectTypeNames = map[ClusterObjectType]string{
+	CLUSTER_OBJECT_TYPE_NONE:              "CLUSTER_OBJECT_TYPE_NONE",
+	CLUSTER_OBJECT_TYPE_CLUSTER:           "CLUSTER_OBJECT_TYPE_CLUSTER",
+	CLUSTER_OBJECT_TYPE_GROUP:             "CLUSTER_OBJECT_TYPE_GROUP",
+	CLUSTER_OBJECT_TYPE_RESOURCE:          "CLUSTER_OBJECT_TYPE_RESOURCE",
+	CLUSTER_OBJECT_TYPE_RESOURCE_TYPE:     "CLUSTER_OBJECT_TYPE_RESOURCE_TYPE",
+	CLUSTER_OBJECT_TYPE_NETWORK_INTERFACE: "CLUSTER_OBJECT_TYPE_NETWORK_INTERFACE",
+	CLUSTER_OBJECT_TYPE_NETWORK:           "CLUSTER_OBJECT_TYPE_NETWORK",
+	CLUSTER_OBJECT_TYPE_NODE:              "CLUSTER_OBJECT_TYPE_NODE",
+	CLUSTER_OBJECT_TYPE_REGISTRY:          "CLUSTER_OBJECT_TYPE_REGISTRY",
+	CLUSTER_OBJECT_TYPE_QUORUM:            "CLUSTER_OBJECT_TYPE_QUORUM",
+	CLUSTER_OBJECT_TYPE_SHARED_VOLUME:     "CLUSTER_OBJECT_TYPE_SHARED_VOLUME",
+}
+
+func (objectType ClusterObjectType) String() string {
+	if name, ok := clusterObjectTypeNames[objectType]; ok {
+		return name
+	}
+	return fmt.Sprintf("ClusterObjectType(%d)", uint32(objectType))
+}
+
+// CLUSTER_CHANGE_CLUSTER_V2 flags
+const (
+	CLUSTER_CHANGE_CLUSTER_RECONNECT_V2           uint64 = 0x00000001
+	CLUSTER_CHANGE_CLUSTER_STATE_V2               uint64 = 0x00000002
+	CLUSTER_CHANGE_CLUSTER_GROUP_ADDED_V2         uint64 = 0x00000004
+	CLUSTER_CHANGE_CLUSTER_HANDLE_CLOSE_V2        uint64 = 0x00000008
+	CLUSTER_CHANGE_CLUSTER_NETWORK_ADDED_V2       uint64 = 0x00000010
+	CLUSTER_CHANGE_CLUSTER_NODE_ADDED_V2          uint64 = 0x00000020
+	CLUSTER_CHANGE_CLUSTER_RESOURCE_TYPE_ADDED_V2 uint64 = 0x00000040
+	CLUSTER_CHANGE_CLUSTER_COMMON_PROPERTY_V2     uint64 = 0x00000080
+	CLUSTER_CHANGE_CLUSTER_PRIVATE_PROPERTY_V2    uint64 = 0x00000100
+	CLUSTER_CHANGE_CLUSTER_LOST_NOTIFICATIONS_V2  uint64 = 0x00000200
+	CLUSTER_CHANGE_CLUSTER_RENAME_V2              uint64 = 0x00000400
+	CLUSTER_CHANGE_CLUSTER_MEMBERSHIP_V2          uint64 = 0x00000800
+	CLUSTER_CHANGE_CLUSTER_UPGRADED_V2            uint64 = 0x00001000
+	CLUSTER_CHANGE_CLUSTER_ALL_V2                 uint64 = 0x00001FFF
+)
+
+// CLUSTER_CHANGE_GROUP_V2 flags
+const (
+	CLUSTER_CHANGE_GROUP_DELETED_V2          uint64 = 0x00000001
+	CLUSTER_CHANGE_GROUP_COMMON_PROPERTY_V2  uint64 = 0x00000002
+	CLUSTER_CHANGE_GROUP_PRIVATE_PROPERTY_V2 uint64 = 0x00000004
+	CLUSTER_CHANGE_GROUP_STATE_V2            uint64 = 0x00000008
+	CLUSTER_CHANGE_GROUP_OWNER_NODE_V2       uint64 = 0x00000010
+	CLUSTER_CHANGE_GROUP_PREFERRED_OWNERS_V2 uint64 = 0x00000020
+	CLUSTER_CHANGE_GROUP_RESOURCE_ADDED_V2   uint64 = 0x00000040
+	CLUSTER_CHANGE_GROUP_RESOURCE_GAINED_V2  uint64 = 0x00000080
+	CLUSTER_CHANGE_GROUP_RESOURCE_LOST_V2    uint64 = 0x00000100
+	CLUSTER_CHANGE_GROUP_HANDLE_CLOSE_V2     uint64 = 0x00000200
+	CLUSTER_CHANGE_GROUP_ALL_V2              uint64 = 0x000003FF
+)
+
+// CLUSTER_CHANGE_RESOURCE_V2 flags
+const (
+	CLUSTER_CHANGE_RESOURCE_COMMON_PROPERTY_V2  uint64 = 0x00000001
+	CLUSTER_CHANGE_RESOURCE_PRIVATE_PROPERTY_V2 uint64 = 0x00000002
+	CLUSTER_CHANGE_RESOURCE_STATE_V2            uint64 = 0x00000004
+	CLUSTER_CHANGE_RESOURCE_OWNER_GROUP_V2      uint64 = 0x00000008
+	CLUSTER_CHANGE_RESOURCE_DEPENDENCIES_V2     uint64 = 0x00000010
+	CLUSTER_CHANGE_RESOURCE_DEPENDENTS_V2       uint64 = 0x00000020
+	CLUSTER_CHANGE_RESOURCE_POSSIBLE_OWNERS_V2  uint64 = 0x00000040
+	CLUSTER_CHANGE_RESOURCE_DELETED_V2          uint64 = 0x00000080
+	CLUSTER_CHANGE_RESOURCE_DLL_UPGRADED_V2     uint64 = 0x00000100
+	CLUSTER_CHANGE_RESOURCE_HANDLE_CLOSE_V2     uint64 = 0x00000200
+	CLUSTER_CHANGE_RESOURCE_TERMINAL_STATE_V2   uint64 = 0x00000400
+	CLUSTER_CHANGE_RESOURCE_ALL_V2              uint64 = 0x000007FF
+)
+
+// CLUSTER_CHANGE_NODE_V2 flags
+const (
+	CLUSTER_CHANGE_NODE_NETINTERFACE_ADDED_V2 uint64 = 0x00000001
+	CLUSTER_CHANGE_NODE_DELETED_V2            uint64 = 0x00000002
+	CLUSTER_CHANGE_NODE_COMMON_PROPERTY_V2    uint64 = 0x00000004
+	CLUSTER_CHANGE_NODE_PRIVATE_PROPERTY_V2   uint64 = 0x00000008
+	CLUSTER_CHANGE_NODE_STATE_V2              uint64 = 0x00000010
+	CLUSTER_CHANGE_NODE_GROUP_GAINED_V2       uint64 = 0x00000020
+	CLUSTER_CHANGE_NODE_GROUP_LOST_V2         uint64 = 0x00000040
+	CLUSTER_CHANGE_NODE_HANDLE_CLOSE_V2       uint64 = 0x00000080
+	CLUSTER_CHANGE_NODE_ALL_V2                uint64 = 0x000000FF
+)
+
+// NotifyFilter is NOTIFY_FILTER_AND_TYPE, Flags are the
+// CLUSTER_CHANGE_*_V2 values of ObjectType
+type NotifyFilter struct {
+	ObjectType ClusterObjectType
+	Flags      uint64
+}
+
+// ClusterEvent is one change read from a notification port
+type ClusterEvent struct {
+	ObjectType ClusterObjectType
+	// Flags is the CLUSTER_CHANGE_*_V2 value of the change
+	Flags        uint64
+	Name         string
+	ID           string
+	ParentID     string
+	ResourceType string
+	// NotifyKey is the key the filter was registered with
+	NotifyKey uintptr
+	// Payload is the decoded Data, see decodeNotifyPayload,
+	// it is nil when the change has no decoded form
+	Payload interface{}
+	Data    []byte
+	// Err is set on the last event if watching failed
+	Err error
+}
+
+// newClusterEvent builds the event for a notification and decodes its payload
+func newClusterEvent(filter NotifyFilter, data []byte, objectID, parentID, name, resourceType string) ClusterEvent {
+	return ClusterEvent{
+		ObjectType:   filter.ObjectType,
+		Flags:        filter.Flags,
+		Name:         name,
+		ID:           objectID,
+		ParentID:     parentID,
+		ResourceType: resourceType,
+		Payload:      decodeNotifyPayload(filter, data),
+		Data:         data,
+	}
+}
+
+// decodeNotifyPayload decodes the buffer of a notification. State changes of
+// resources, groups and nodes carry the new state as a DWORD and are returned
+// as ClusterResourceState, ClusterGroupState and ClusterNodeState
+func decodeNotifyPayload(filter NotifyFilter, data []byte) interface{} {
+	if len(data) != 4 {
+		return nil
+	}
+	state := int32(util.ByteToUint32(data))
+	switch {
+	case filter.ObjectType == CLUSTER_OBJECT_TYPE_RESOURCE && filter.Flags == CLUSTER_CHANGE_RESOURCE_STATE_V2:
+		return ClusterResourceState(state)
+	case filter.ObjectType == CLUSTER_OBJECT_TYPE_GROUP && filter.Flags == CLUSTER_CHANGE_GROUP_STATE_V2:
+		return ClusterGroupState(state)
+	case filter.ObjectType == CLUSTER_OBJECT_TYPE_NODE && filter.Flags == CLUSTER_CHANGE_NODE_STATE_V2:
+		return ClusterNodeState(state)
+	}
+	return nil
+}
+
+// notifyPort is implemented by NotifyPortHandle
+type notifyPort interface {
+	// next waits up to timeout milliseconds for an event, returns WAIT_TIMEOUT if there is none
+	next(timeout uint32) (ClusterEvent, error)
+	Close() error
+}
+
+// notifyWaitTimeout bounds each wait on the port so Watch notices ctx being done
+const notifyWaitTimeout uint32 = 500
+
+// NotifyReconnectInterval is how long Watch waits before recreating
+// the notification port after the cluster became unavailable
+var NotifyReconnectInterval = 2 * time.Second
+
+// isReconnectError reports errors after which the port is recreated
+func isReconnectError(err error) bool {
+	return errors.Is(err, errors.RPC_S_SERVER_UNAVAILABLE) || errors.Is(err, errors.EPT_S_NOT_REGISTERED)
+}
+
+// watchNotifyPort sends the events of port until ctx is done, events is closed afterwards.
+// When the cluster becomes unavailable the port is recreated with open and a
+// CLUSTER_CHANGE_CLUSTER_RECONNECT_V2 event is sent as changes may have been missed
+func watchNotifyPort(ctx context.Context, port notifyPort, open func() (notifyPort, error), events chan<- ClusterEvent, reconnectInterval time.Duration) {
+	defer close(events)
+	defer func() {
+		if port != nil {
+			port.Close()
+		}
+	}()
+
+	send := func(event ClusterEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		event, err := port.next(notifyWaitTimeout)
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err == nil:
+			if !send(event) {
+				return
+			}
+		case errors.Is(err, errors.WAIT_TIMEOUT):
+		case isReconnectError(err):
+			port.Close()
+			port, err = reopenNotifyPort(ctx, open, reconnectInterval)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				send(ClusterEvent{Err: err})
+				return
+			}
+			reconnected := ClusterEvent{ObjectType: CLUSTER_OBJECT_TYPE_CLUSTER, Flags: CLUSTER_CHANGE_CLUSTER_RECONNECT_V2}
+			if !send(reconnected) {
+				return
+			}
+		default:
+			send(ClusterEvent{Err: err})
+			return
+		}
+	}
+}
+
+// reopenNotifyPort calls open every reconnectInterval while the cluster is unavailable
+func reopenNotifyPort(ctx context.Context, open func() (notifyPort, error), reconnectInterval time.Duration) (port notifyPort, err error) {
+	err = pollUntil(ctx, reconnectInterval, func() (bool, error) {
+		var openErr error
+		port, openErr = open()
+		if isReconnectError(openErr) {
+			return false, nil
+		}
+		return true, openErr
+	})
+	return
+}
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// notifyRecorder is a notifyPort returning queued results
+type notifyRecorder struct {
+	results []notifyResult
+	closed  bool
+}
+
+type notifyResult struct {
+	event ClusterEvent
+	err   error
+}
+
+func (port *notifyRecorder) next(timeout uint32) (ClusterEvent, error) {
+	if len(port.results) == 0 {
+		return ClusterEvent{}, errors.WAIT_TIMEOUT
+	}
+	result := port.results[0]
+	port.results = port.results[1:]
+	return result.event, result.err
+}
+
+func (port *notifyRecorder) Close() error {
+	port.closed = true
+	return nil
+}
+
+func TestDecodeNotifyPayload(t *testing.T) {
+	// buffers as returned by GetClusterNotifyV2
+	resourceOnline := []byte{2, 0, 0, 0}
+	groupPending := []byte{4, 0, 0, 0}
+	nodePaused := []byte{2, 0, 0, 0}
+
+	assert.Equal(t, ClusterResourceOnline, decodeNotifyPayload(NotifyFilter{CLUSTER_OBJECT_TYPE_RESOURCE, CLUSTER_CHANGE_RESOURCE_STATE_V2}, resourceOnline))
+	assert.Equal(t, ClusterGroupPending, decodeNotifyPayload(NotifyFilter{CLUSTER_OBJECT_TYPE_GROUP, CLUSTER_CHANGE_GROUP_STATE_V2}, groupPending))
+	assert.Equal(t, ClusterNodePaused, decodeNotifyPayload(NotifyFilter{CLUSTER_OBJECT_TYPE_NODE, CLUSTER_CHANGE_NODE_STATE_V2}, nodePaused))
+	assert.Equal(t, ClusterResourceStateUnknown, decodeNotifyPayload(NotifyFilter{CLUSTER_OBJECT_TYPE_RESOURCE, CLUSTER_CHANGE_RESOURCE_STATE_V2}, []byte{0xff, 0xff, 0xff, 0xff}))
+
+	assert.Nil(t, decodeNotifyPayload(NotifyFilter{CLUSTER_OBJECT_TYPE_RESOURCE, CLUSTER_CHANGE_RESOURCE_STATE_V2}, []byte{2, 0}))
+	assert.Nil(t, decodeNotifyPayload(NotifyFilter{CLUSTER_OBJECT_TYPE_GROUP, CLUSTER_CHANGE_GROUP_OWNER_NODE_V2}, groupPending))
+	assert.Nil(t, decodeNotifyPayload(NotifyFilter{CLUSTER_OBJECT_TYPE_CLUSTER, CLUSTER_CHANGE_CLUSTER_GROUP_ADDED_V2}, nil))
+
+	event := newClusterEvent(NotifyFilter{CLUSTER_OBJECT_TYPE_RESOURCE, CLUSTER_CHANGE_RESOURCE_STATE_V2}, resourceOnline, "id", "parent", "r1", "Generic Service")
+	assert.Equal(t, ClusterEvent{
+		ObjectType:   CLUSTER_OBJECT_TYPE_RESOURCE,
+		Flags:        CLUSTER_CHANGE_RESOURCE_STATE_V2,
+		Name:         "r1",
+		ID:           "id",
+		ParentID:     "parent",
+		ResourceType: "Generic Service",
+		Payload:      ClusterResourceOnline,
+		Data:         resourceOnline,
+	}, event)
+	assert.Equal(t, "CLUSTER_OBJECT_TYPE_RESOURCE", event.ObjectType.String())
+}
+
+func collectEvents(events <-chan ClusterEvent) []ClusterEvent {
+	var collected []ClusterEvent
+	for event := range events {
+		collected = append(collected, event)
+	}
+	return collected
+}
+
+func TestWatchNotifyPort(t *testing.T) {
+	first := ClusterEvent{ObjectType: CLUSTER_OBJECT_TYPE_GROUP, Flags: CLUSTER_CHANGE_GROUP_STATE_V2, Name: "g1"}
+	second := ClusterEvent{ObjectType: CLUSTER_OBJECT_TYPE_NODE, Flags: CLUSTER_CHANGE_NODE_STATE_V2, Name: "n1"}
+	port := &notifyRecorder{results: []notifyResult{
+		{event: first},
+		{err: errors.WAIT_TIMEOUT},
+		{event: second},
+		{err: errors.ERROR_INVALID_HANDLE},
+	}}
+
+	events := make(chan ClusterEvent)
+	go watchNotifyPort(context.Background(), port, nil, events, time.Millisecond)
+
+	assert.Equal(t, []ClusterEvent{first, second, {Err: errors.ERROR_INVALID_HANDLE}}, collectEvents(events))
+	assert.True(t, port.closed)
+}
+
+func TestWatchNotifyPortReconnect(t *testing.T) {
+	event := ClusterEvent{ObjectType: CLUSTER_OBJECT_TYPE_RESOURCE, Name: "r1"}
+	port := &notifyRecorder{results: []notifyResult{{err: errors.RPC_S_SERVER_UNAVAILABLE}}}
+	reopened := &notifyRecorder{results: []notifyResult{{event: event}, {err: errors.ERROR_INVALID_HANDLE}}}
+
+	opens := 0
+	open := func() (notifyPort, error) {
+		opens++
+		if opens < 3 {
+			return nil, errors.EPT_S_NOT_REGISTERED
+		}
+		return reopened, nil
+	}
+
+	events := make(chan ClusterEvent)
+	go watchNotifyPort(context.Background(), port, open, events, time.Millisecond)
+
+	assert.Equal(t, []ClusterEvent{
+		{ObjectType: CLUSTER_OBJECT_TYPE_CLUSTER, Flags: CLUSTER_CHANGE_CLUSTER_RECONNECT_V2},
+		event,
+		{Err: errors.ERROR_INVALID_HANDLE},
+	}, collectEvents(events))
+	assert.Equal(t, 3, opens)
+	assert.True(t, port.closed)
+	assert.True(t, reopened.closed)
+}
+
+func TestWatchNotifyPortReconnectFails(t *testing.T) {
+	port := &notifyRecorder{results: []notifyResult{{err: errors.RPC_S_SERVER_UNAVAILABLE}}}
+	open := func() (notifyPort, error) {
+		return nil, errors.ERROR_ACCESS_DENIED
+	}
+
+	events := make(chan ClusterEvent)
+	go watchNotifyPort(context.Background(), port, open, events, time.Millisecond)
+	assert.Equal(t, []ClusterEvent{{Err: errors.ERROR_ACCESS_DENIED}}, collectEvents(events))
+}
+
+func TestWatchNotifyPortCancel(t *testing.T) {
+	port := &notifyRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events := make(chan ClusterEvent)
+	go watchNotifyPort(ctx, port, nil, events, time.Millisecond)
+	cancel()
+
+	assert.Empty(t, collectEvents(events))
+	assert.True(t, port.closed)
+}
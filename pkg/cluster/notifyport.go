@@ -0,0 +1,5 @@
+package cluster
+
+type (
+	NotifyPortHandle uintptr
+)
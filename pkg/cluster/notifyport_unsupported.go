@@ -0,0 +1,30 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+func (cluster ClusterHandle) CreateNotifyPortV2(filters []NotifyFilter, notifyKey uintptr) (NotifyPortHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle NotifyPortHandle) Register(filter NotifyFilter, object uintptr, notifyKey uintptr) error {
+	return errors.ErrNotSupported
+}
+
+func (handle NotifyPortHandle) GetNotify(timeout uint32) (ClusterEvent, error) {
+	return ClusterEvent{}, errors.ErrNotSupported
+}
+
+func (handle NotifyPortHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (cluster ClusterHandle) Watch(ctx context.Context, filters ...NotifyFilter) (<-chan ClusterEvent, error) {
+	return nil, errors.ErrNotSupported
+}
@@ -0,0 +1,210 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+var (
+	procnativeCreateClusterNotifyPortV2 = clusapi_dll.NewProc("CreateClusterNotifyPortV2")
+	procnativeRegisterClusterNotifyV2   = clusapi_dll.NewProc("RegisterClusterNotifyV2")
+	procnativeGetClusterNotifyV2        = clusapi_dll.NewProc("GetClusterNotifyV2")
+	procnativeCloseClusterNotifyPort    = clusapi_dll.NewProc("CloseClusterNotifyPort")
+)
+
+// notifyFilterAndType is NOTIFY_FILTER_AND_TYPE, the padding keeps
+// filterFlags 8 byte aligned on 386 as well
+type notifyFilterAndType struct {
+	dwObjectType uint32
+	_            uint32
+	filterFlags  uint64
+}
+
+func toNotifyFilterAndType(filter NotifyFilter) notifyFilterAndType {
+	return notifyFilterAndType{dwObjectType: uint32(filter.ObjectType), filterFlags: filter.Flags}
+}
+
+// invalidHandleValue is INVALID_HANDLE_VALUE, passed to create a new port
+const invalidHandleValue = ^uintptr(0)
+
+func createClusterNotifyPortV2(cluster ClusterHandle, filters []notifyFilterAndType, notifyKey uintptr) (NotifyPortHandle, error) {
+	var filtersPtr uintptr
+	if len(filters) > 0 {
+		filtersPtr = uintptr(unsafe.Pointer(&filters[0]))
+	}
+	r0, _, lastError := syscall.Syscall6(procnativeCreateClusterNotifyPortV2.Addr(),
+		5,
+		invalidHandleValue,
+		uintptr(cluster),
+		filtersPtr,
+		uintptr(len(filters)),
+		notifyKey,
+		0)
+	handle := NotifyPortHandle(r0)
+	return handle, handles.opened(notifyPortHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// CreateNotifyPortV2 creates a notification port for the changes in filters,
+// notifyKey is returned with every event of these filters
+func (cluster ClusterHandle) CreateNotifyPortV2(filters []NotifyFilter, notifyKey uintptr) (NotifyPortHandle, error) {
+	native := make([]notifyFilterAndType, 0, len(filters))
+	for _, filter := range filters {
+		native = append(native, toNotifyFilterAndType(filter))
+	}
+	port, err := createClusterNotifyPortV2(cluster, native, notifyKey)
+	return port, errors.Wrap(procnativeCreateClusterNotifyPortV2.Name, "", err)
+}
+
+func registerClusterNotifyV2(handle NotifyPortHandle, filter notifyFilterAndType, object uintptr, notifyKey uintptr) error {
+	var r0 uintptr
+	switch runtime.GOARCH {
+	case "amd64":
+		// the 16 byte struct is passed by reference on amd64
+		r0, _, _ = syscall.Syscall6(procnativeRegisterClusterNotifyV2.Addr(),
+			4,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&filter)),
+			object,
+			notifyKey,
+			0,
+			0)
+	case "arm64":
+		// in two registers holding its 64 bit halves on arm64
+		r0, _, _ = syscall.Syscall6(procnativeRegisterClusterNotifyV2.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(filter.dwObjectType),
+			uintptr(filter.filterFlags),
+			object,
+			notifyKey,
+			0)
+	case "386":
+		// and pushed as four DWORDs on 386
+		r0, _, _ = syscall.Syscall9(procnativeRegisterClusterNotifyV2.Addr(),
+			7,
+			uintptr(handle),
+			uintptr(filter.dwObjectType),
+			0,
+			uintptr(uint32(filter.filterFlags)),
+			uintptr(uint32(filter.filterFlags>>32)),
+			object,
+			notifyKey,
+			0,
+			0)
+	default:
+		return fmt.Errorf("passing NOTIFY_FILTER_AND_TYPE on %s: %w", runtime.GOARCH, errors.ERROR_NOT_SUPPORTED)
+	}
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Register adds the changes in filter of a single object to the port, object
+// is a ClusterHandle, GroupHandle, ResourceHandle or NodeHandle matching filter.ObjectType
+func (handle NotifyPortHandle) Register(filter NotifyFilter, object uintptr, notifyKey uintptr) error {
+	return errors.Wrap(procnativeRegisterClusterNotifyV2.Name, "", registerClusterNotifyV2(handle, toNotifyFilterAndType(filter), object, notifyKey))
+}
+
+func getClusterNotifyV2(handle NotifyPortHandle, timeout uint32) (event ClusterEvent, err error) {
+	dataCB := uint32(248)
+	idCCh := uint32(50)
+	parentCCh := uint32(50)
+	nameCCh := uint32(50)
+	typeCCh := uint32(50)
+
+	var notifyKey uintptr
+	var filter notifyFilterAndType
+	var data []byte
+	var idArr, parentArr, nameArr, typeArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		dataCB += 8
+		idCCh += 2
+		parentCCh += 2
+		nameCCh += 2
+		typeCCh += 2
+		data = make([]byte, dataCB)
+		idArr = make([]uint16, idCCh)
+		parentArr = make([]uint16, parentCCh)
+		nameArr = make([]uint16, nameCCh)
+		typeArr = make([]uint16, typeCCh)
+		r0, _, _ := syscall.Syscall15(procnativeGetClusterNotifyV2.Addr(),
+			14,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&notifyKey)),
+			uintptr(unsafe.Pointer(&filter)),
+			uintptr(unsafe.Pointer(&data[0])),
+			uintptr(unsafe.Pointer(&dataCB)),
+			uintptr(unsafe.Pointer(&idArr[0])),
+			uintptr(unsafe.Pointer(&idCCh)),
+			uintptr(unsafe.Pointer(&parentArr[0])),
+			uintptr(unsafe.Pointer(&parentCCh)),
+			uintptr(unsafe.Pointer(&nameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			uintptr(unsafe.Pointer(&typeArr[0])),
+			uintptr(unsafe.Pointer(&typeCCh)),
+			uintptr(timeout),
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	event = newClusterEvent(NotifyFilter{ClusterObjectType(filter.dwObjectType), filter.filterFlags},
+		data[:dataCB],
+		syscall.UTF16ToString(idArr),
+		syscall.UTF16ToString(parentArr),
+		syscall.UTF16ToString(nameArr),
+		syscall.UTF16ToString(typeArr))
+	event.NotifyKey = notifyKey
+	return
+}
+
+// GetNotify waits up to timeout milliseconds for the next event,
+// returns WAIT_TIMEOUT if there is none, use syscall.INFINITE to wait forever
+func (handle NotifyPortHandle) GetNotify(timeout uint32) (ClusterEvent, error) {
+	event, err := getClusterNotifyV2(handle, timeout)
+	return event, errors.Wrap(procnativeGetClusterNotifyV2.Name, "", err)
+}
+
+func (handle NotifyPortHandle) next(timeout uint32) (ClusterEvent, error) {
+	return handle.GetNotify(timeout)
+}
+
+func closeClusterNotifyPort(handle NotifyPortHandle) error {
+	r0, _, lastError := syscall.Syscall(procnativeCloseClusterNotifyPort.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotNill(r0, lastError)
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle NotifyPortHandle) Close() error {
+	err := handles.closed(notifyPortHandleKind, uintptr(handle), func() error { return closeClusterNotifyPort(handle) })
+	return errors.Wrap(procnativeCloseClusterNotifyPort.Name, "", err)
+}
+
+// Watch delivers the changes matching filters until ctx is done, the channel is
+// closed afterwards. If the cluster becomes unavailable the port is recreated and
+// a CLUSTER_CHANGE_CLUSTER_RECONNECT_V2 event is sent. If reading fails the last
+// event carries the error
+func (cluster ClusterHandle) Watch(ctx context.Context, filters ...NotifyFilter) (<-chan ClusterEvent, error) {
+	open := func() (notifyPort, error) {
+		port, err := cluster.CreateNotifyPortV2(filters, 0)
+		if err != nil {
+			return nil, err
+		}
+		return port, nil
+	}
+	port, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ClusterEvent)
+	go watchNotifyPort(ctx, port, open, events, NotifyReconnectInterval)
+	return events, nil
+}
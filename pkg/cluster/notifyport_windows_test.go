@@ -0,0 +1,55 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	events, err := cluster.Watch(ctx,
+		NotifyFilter{CLUSTER_OBJECT_TYPE_CLUSTER, CLUSTER_CHANGE_CLUSTER_GROUP_ADDED_V2},
+		NotifyFilter{CLUSTER_OBJECT_TYPE_GROUP, CLUSTER_CHANGE_GROUP_DELETED_V2})
+	assert.Nil(t, err, "error should be null")
+
+	group, err := cluster.CreateGroup(testGroupName)
+	assert.Nil(t, err, "error should be null")
+	assert.Nil(t, group.Delete(), "error should be null")
+	group.Close()
+
+	var added, deleted bool
+	for event := range events {
+		assert.Nil(t, event.Err, "error should be null")
+		if event.Name != testGroupName {
+			continue
+		}
+		added = added || event.Flags == CLUSTER_CHANGE_CLUSTER_GROUP_ADDED_V2
+		deleted = deleted || event.Flags == CLUSTER_CHANGE_GROUP_DELETED_V2
+		if added && deleted {
+			cancel()
+		}
+	}
+	assert.True(t, added)
+	assert.True(t, deleted)
+}
+
+func TestNotifyPortClose(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	port, err := cluster.CreateNotifyPortV2([]NotifyFilter{{CLUSTER_OBJECT_TYPE_CLUSTER, CLUSTER_CHANGE_CLUSTER_GROUP_ADDED_V2}}, 0)
+	if !assert.Nil(t, err, "error should be null") {
+		return
+	}
+	assert.Nil(t, port.Close(), "successful close should not return an error")
+}
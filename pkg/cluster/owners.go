@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// itemNames drains it and returns the item names in order
+func itemNames(it *ClusterIterator, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	items, err := it.All()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return names, nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueNames removes repeated names keeping the first, node names are case insensitive
+func uniqueNames(names []string) []string {
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if !containsName(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// OwnerChanges returns the names to add and remove to turn current into desired,
+// names are compared case insensitively and order is ignored
+func OwnerChanges(current []string, desired []string) (add []string, remove []string) {
+	for _, name := range uniqueNames(desired) {
+		if !containsName(current, name) {
+			add = append(add, name)
+		}
+	}
+	for _, name := range uniqueNames(current) {
+		if !containsName(desired, name) {
+			remove = append(remove, name)
+		}
+	}
+	return
+}
+
+// SameOwners returns true when a and b hold the same names in the same order
+func SameOwners(a []string, b []string) bool {
+	a, b = uniqueNames(a), uniqueNames(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// syncPossibleOwners adds the missing owners before removing the extra ones
+// so the resource always keeps at least one possible owner
+func syncPossibleOwners(current []string, desired []string, add func(string) error, remove func(string) error) error {
+	if len(desired) == 0 {
+		return fmt.Errorf("a resource needs at least one possible owner: %w", errors.ERROR_INVALID_PARAMETER)
+	}
+	toAdd, toRemove := OwnerChanges(current, desired)
+	for _, name := range toAdd {
+		if err := add(name); err != nil {
+			return fmt.Errorf("add possible owner %s: %w", name, err)
+		}
+	}
+	for _, name := range toRemove {
+		if err := remove(name); err != nil {
+			return fmt.Errorf("remove possible owner %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// syncPreferredOwners sets desired only when it differs from current,
+// the preferred owner list is ordered so it is replaced as a whole
+func syncPreferredOwners(current []string, desired []string, set func([]string) error) error {
+	if SameOwners(current, desired) {
+		return nil
+	}
+	return set(uniqueNames(desired))
+}
@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerChanges(t *testing.T) {
+	add, remove := OwnerChanges([]string{"n1", "N2", "n3"}, []string{"n2", "n4", "n4", "n1"})
+	assert.Equal(t, []string{"n4"}, add)
+	assert.Equal(t, []string{"n3"}, remove)
+
+	add, remove = OwnerChanges(nil, []string{"n1"})
+	assert.Equal(t, []string{"n1"}, add)
+	assert.Nil(t, remove)
+}
+
+func TestSameOwners(t *testing.T) {
+	assert.True(t, SameOwners([]string{"n1", "n2"}, []string{"N1", "n2"}))
+	assert.True(t, SameOwners([]string{"n1", "n2", "n1"}, []string{"n1", "n2"}))
+	assert.False(t, SameOwners([]string{"n1", "n2"}, []string{"n2", "n1"}))
+	assert.False(t, SameOwners([]string{"n1"}, []string{"n1", "n2"}))
+	assert.True(t, SameOwners(nil, []string{}))
+}
+
+func TestSyncPossibleOwners(t *testing.T) {
+	var calls []string
+	record := func(op string) func(string) error {
+		return func(name string) error {
+			calls = append(calls, op+" "+name)
+			return nil
+		}
+	}
+	err := syncPossibleOwners([]string{"n1", "n2"}, []string{"n2", "n3"}, record("add"), record("remove"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"add n3", "remove n1"}, calls)
+
+	calls = nil
+	err = syncPossibleOwners([]string{"n1", "n2"}, []string{"N2", "n1"}, record("add"), record("remove"))
+	assert.Nil(t, err)
+	assert.Nil(t, calls)
+
+	err = syncPossibleOwners([]string{"n1"}, nil, record("add"), record("remove"))
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER))
+	assert.Nil(t, calls)
+}
+
+func TestSyncPossibleOwnersStopsOnError(t *testing.T) {
+	removed := false
+	err := syncPossibleOwners([]string{"n1"}, []string{"n2"},
+		func(string) error { return errors.ERROR_ACCESS_DENIED },
+		func(string) error { removed = true; return nil })
+	assert.True(t, goerrors.Is(err, errors.ERROR_ACCESS_DENIED))
+	assert.False(t, removed, "the last owner must not be removed when the add failed")
+}
+
+func TestSyncPreferredOwners(t *testing.T) {
+	var written [][]string
+	set := func(names []string) error {
+		written = append(written, names)
+		return nil
+	}
+	assert.Nil(t, syncPreferredOwners([]string{"n1", "n2"}, []string{"n1", "n2"}, set))
+	assert.Nil(t, written)
+
+	assert.Nil(t, syncPreferredOwners([]string{"n1", "n2"}, []string{"n2", "n1", "n2"}, set))
+	assert.Equal(t, [][]string{{"n2", "n1"}}, written)
+
+	assert.Nil(t, syncPreferredOwners([]string{"n1"}, nil, set))
+	assert.Equal(t, []string{}, written[1])
+}
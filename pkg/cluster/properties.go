@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/KnicKnic/go-windows/pkg/cluster/clusprop"
+)
+
+// controlFunc sends a control code to an object, it is the Control method of a handle
+type controlFunc func(code ControlCode, in []byte) ([]byte, error)
+
+// getProperties sends the get code and parses the property list
+func getProperties(control controlFunc, get ControlCode) (clusprop.PropertyList, error) {
+	out, err := control(get, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return clusprop.PropertyList{}, nil
+	}
+	return clusprop.ParsePropertyList(out)
+}
+
+// getPropertiesInto reads the properties into the struct pointed to by v
+func getPropertiesInto(control controlFunc, get ControlCode, v interface{}) error {
+	list, err := getProperties(control, get)
+	if err != nil {
+		return err
+	}
+	return clusprop.Unmarshal(list, v)
+}
+
+// setProperties writes v, a clusprop.PropertyList or a struct with clusprop tags.
+// The properties are sent with the validate code first and only set
+// when the object accepts them
+func setProperties(control controlFunc, validate ControlCode, set ControlCode, v interface{}) error {
+	list, ok := v.(clusprop.PropertyList)
+	if !ok {
+		var err error
+		list, err = clusprop.Marshal(v)
+		if err != nil {
+			return err
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	in, err := list.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if _, err = control(validate, in); err != nil {
+		return fmt.Errorf("validate properties %v: %w", list.Names(), err)
+	}
+	_, err = control(set, in)
+	return err
+}
@@ -0,0 +1,87 @@
+package cluster
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/cluster/clusprop"
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// controlRecorder records the codes sent and answers from results
+type controlRecorder struct {
+	codes   []ControlCode
+	inputs  [][]byte
+	results map[ControlCode]error
+	out     []byte
+}
+
+func (r *controlRecorder) control(code ControlCode, in []byte) ([]byte, error) {
+	r.codes = append(r.codes, code)
+	r.inputs = append(r.inputs, in)
+	return r.out, r.results[code]
+}
+
+type testParameters struct {
+	ServiceName string `clusprop:"ServiceName"`
+	Restart     uint32 `clusprop:"RestartThreshold,omitempty"`
+}
+
+func TestSetPropertiesValidatesFirst(t *testing.T) {
+	recorder := &controlRecorder{}
+	err := setProperties(recorder.control, CLCTL_VALIDATE_PRIVATE_PROPERTIES, CLCTL_SET_PRIVATE_PROPERTIES, testParameters{ServiceName: "svc", Restart: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, []ControlCode{CLCTL_VALIDATE_PRIVATE_PROPERTIES, CLCTL_SET_PRIVATE_PROPERTIES}, recorder.codes)
+	assert.Equal(t, recorder.inputs[0], recorder.inputs[1])
+
+	list, err := clusprop.ParsePropertyList(recorder.inputs[1])
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ServiceName", "RestartThreshold"}, list.Names())
+}
+
+func TestSetPropertiesValidationFails(t *testing.T) {
+	recorder := &controlRecorder{results: map[ControlCode]error{
+		CLCTL_VALIDATE_PRIVATE_PROPERTIES: errors.ERROR_INVALID_PARAMETER,
+	}}
+	err := setProperties(recorder.control, CLCTL_VALIDATE_PRIVATE_PROPERTIES, CLCTL_SET_PRIVATE_PROPERTIES, testParameters{ServiceName: "svc"})
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER))
+	assert.Equal(t, []ControlCode{CLCTL_VALIDATE_PRIVATE_PROPERTIES}, recorder.codes)
+}
+
+func TestSetPropertiesList(t *testing.T) {
+	recorder := &controlRecorder{results: map[ControlCode]error{
+		CLCTL_SET_COMMON_PROPERTIES: errors.ERROR_RESOURCE_PROPERTIES_STORED,
+	}}
+	var list clusprop.PropertyList
+	list.Set("PendingTimeout", clusprop.DWordValue(1000))
+	err := setProperties(recorder.control, CLCTL_VALIDATE_COMMON_PROPERTIES, CLCTL_SET_COMMON_PROPERTIES, list)
+	assert.Equal(t, errors.ERROR_RESOURCE_PROPERTIES_STORED, err)
+
+	// nothing to change sends nothing
+	recorder.codes = nil
+	err = setProperties(recorder.control, CLCTL_VALIDATE_COMMON_PROPERTIES, CLCTL_SET_COMMON_PROPERTIES, &struct {
+		Description string `clusprop:",omitempty"`
+	}{})
+	assert.Nil(t, err)
+	assert.Empty(t, recorder.codes)
+}
+
+func TestGetProperties(t *testing.T) {
+	var list clusprop.PropertyList
+	list.Set("ServiceName", clusprop.Value{Syntax: clusprop.CLUSPROP_SYNTAX_LIST_VALUE_SZ, Data: []byte{'s', 0, 0, 0}})
+	list.Set("RestartThreshold", clusprop.DWordValue(5))
+	out, err := list.MarshalBinary()
+	assert.Nil(t, err)
+
+	recorder := &controlRecorder{out: out}
+	var params testParameters
+	assert.Nil(t, getPropertiesInto(recorder.control, CLCTL_GET_PRIVATE_PROPERTIES, &params))
+	assert.Equal(t, testParameters{ServiceName: "s", Restart: 5}, params)
+	assert.Equal(t, []ControlCode{CLCTL_GET_PRIVATE_PROPERTIES}, recorder.codes)
+
+	recorder.out = nil
+	empty, err := getProperties(recorder.control, CLCTL_GET_PRIVATE_PROPERTIES)
+	assert.Nil(t, err)
+	assert.Len(t, empty, 0)
+}
@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// BatchCommand is a raw command read from a batch notification
+// for CLUSREG_SET_VALUE Options holds the registry value type
+type BatchCommand struct {
+	Command ClusterRegCommand
+	Options uint32
+	Name    string
+	Data    []byte
+}
+
+// batchMemory returns a copy of the size bytes at address, the memory
+// the wzName and lpData pointers of a CLUSTER_BATCH_COMMAND refer to
+type batchMemory func(address uintptr, size int) ([]byte, error)
+
+// maxBatchNameLength bounds the characters read for wzName
+const maxBatchNameLength = 32767
+
+// batchCommandSize is the size of CLUSTER_BATCH_COMMAND for pointerSize
+// 4 or 8, the trailing cbData is padded to the pointer alignment
+func batchCommandSize(pointerSize int) int {
+	size := 4 + 4 + 2*pointerSize + 4
+	return (size + pointerSize - 1) / pointerSize * pointerSize
+}
+
+// decodeBatchCommand decodes the CLUSTER_BATCH_COMMAND ClusterRegBatchReadCommand
+// filled in, raw is its memory in little endian
+//
+//	Command uint32 | dwOptions uint32 | wzName pointer | lpData pointer | cbData uint32
+//
+// pointerSize is 8 on 64 bit and 4 on 32 bit Windows, read copies the name and data
+func decodeBatchCommand(raw []byte, pointerSize int, read batchMemory) (command BatchCommand, err error) {
+	if pointerSize != 4 && pointerSize != 8 {
+		return command, fmt.Errorf("CLUSTER_BATCH_COMMAND pointer size %d: %w", pointerSize, errors.ERROR_INVALID_PARAMETER)
+	}
+	if size := batchCommandSize(pointerSize); len(raw) < size {
+		return command, fmt.Errorf("CLUSTER_BATCH_COMMAND of %d bytes, %d expected: %w", len(raw), size, errors.ERROR_INVALID_DATA)
+	}
+	pointer := func(offset int) uintptr {
+		if pointerSize == 8 {
+			return uintptr(binary.LittleEndian.Uint64(raw[offset:]))
+		}
+		return uintptr(binary.LittleEndian.Uint32(raw[offset:]))
+	}
+	command.Command = ClusterRegCommand(binary.LittleEndian.Uint32(raw[0:]))
+	command.Options = binary.LittleEndian.Uint32(raw[4:])
+	name := pointer(8)
+	data := pointer(8 + pointerSize)
+	dataSize := binary.LittleEndian.Uint32(raw[8+2*pointerSize:])
+
+	if name != 0 {
+		if command.Name, err = readBatchName(read, name); err != nil {
+			return
+		}
+	}
+	if dataSize == 0 {
+		command.Data = []byte{}
+		return
+	}
+	if data == 0 {
+		return command, fmt.Errorf("CLUSTER_BATCH_COMMAND %s has %d bytes of data at NULL: %w", command.Command, dataSize, errors.ERROR_INVALID_DATA)
+	}
+	command.Data, err = read(data, int(dataSize))
+	return
+}
+
+// readBatchName reads the null terminated UTF-16 string at address
+// a character at a time, so read never goes past the terminator
+func readBatchName(read batchMemory, address uintptr) (string, error) {
+	var name []uint16
+	for len(name) < maxBatchNameLength {
+		char, err := read(address+uintptr(2*len(name)), 2)
+		if err != nil {
+			return "", err
+		}
+		if char[0] == 0 && char[1] == 0 {
+			return string(utf16.Decode(name)), nil
+		}
+		name = append(name, binary.LittleEndian.Uint16(char))
+	}
+	return "", fmt.Errorf("CLUSTER_BATCH_COMMAND name longer than %d characters: %w", maxBatchNameLength, errors.ERROR_INVALID_DATA)
+}
+
+// RegistryChange is a decoded command of a batch notification
+type RegistryChange struct {
+	Command ClusterRegCommand
+	// KeyPath is the key the change applies to, relative to the key
+	// the notification port was created on, "" is that key itself
+	KeyPath   string
+	ValueName string
+	DwType    uint32
+	Data      []byte
+}
+
+// BatchNotification is one committed batch, Err is set on
+// the last notification if watching failed
+type BatchNotification struct {
+	Changes []RegistryChange
+	Err     error
+}
+
+// parseBatchCommands decodes the commands of one notification.
+// CLUSREG_CREATE_KEY selects the key of the following value commands
+// and is reported as a change of that key, other commands are passed
+// through with only Command, ValueName and Data set
+func parseBatchCommands(commands []BatchCommand) []RegistryChange {
+	changes := make([]RegistryChange, 0, len(commands))
+	keyPath := ""
+	for _, command := range commands {
+		change := RegistryChange{Command: command.Command, KeyPath: keyPath}
+		switch command.Command {
+		case CLUSREG_CREATE_KEY:
+			keyPath = command.Name
+			change.KeyPath = keyPath
+		case CLUSREG_DELETE_KEY:
+			change.KeyPath = command.Name
+		case CLUSREG_SET_VALUE:
+			change.ValueName = command.Name
+			change.DwType = command.Options
+			change.Data = append([]byte(nil), command.Data...)
+		case CLUSREG_DELETE_VALUE, CLUSREG_VALUE_DELETED:
+			change.ValueName = command.Name
+		default:
+			change = RegistryChange{Command: command.Command, ValueName: command.Name, Data: append([]byte(nil), command.Data...)}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
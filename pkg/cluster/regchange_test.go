@@ -0,0 +1,189 @@
+package cluster
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// batch notification fixtures, in the order ClusterRegBatchReadCommand returns them
+var (
+	fixtureSetValues = []BatchCommand{
+		{Command: CLUSREG_CREATE_KEY, Name: `Parameters`},
+		{Command: CLUSREG_SET_VALUE, Options: REG_SZ, Name: "Path", Data: []byte{'c', 0, 0, 0}},
+		{Command: CLUSREG_SET_VALUE, Options: REG_DWORD, Name: "Timeout", Data: []byte{30, 0, 0, 0}},
+	}
+	fixtureMixed = []BatchCommand{
+		{Command: CLUSREG_SET_VALUE, Options: REG_BINARY, Name: "guid", Data: []byte{1, 2}},
+		{Command: CLUSREG_CREATE_KEY, Name: `a\b`},
+		{Command: CLUSREG_DELETE_VALUE, Name: "old"},
+		{Command: CLUSREG_VALUE_DELETED, Name: "older"},
+		{Command: CLUSREG_DELETE_KEY, Name: `a\c`},
+		{Command: CLUSREG_SET_VALUE, Options: REG_QWORD, Name: "size", Data: []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	fixtureUnknown = []BatchCommand{
+		{Command: CLUSREG_CREATE_KEY, Name: `Parameters`},
+		{Command: CLUSREG_CONDITION_IS_EQUAL, Options: REG_SZ, Name: "Path", Data: []byte{'c', 0, 0, 0}},
+		{Command: CLUSREG_SET_KEY_SECURITY, Data: []byte{1, 0, 4, 0x80}},
+		{Command: CLUSREG_SET_VALUE, Options: REG_DWORD, Name: "Timeout", Data: []byte{30, 0, 0, 0}},
+	}
+)
+
+func TestParseBatchCommands(t *testing.T) {
+	changes := parseBatchCommands(fixtureSetValues)
+	assert.Equal(t, []RegistryChange{
+		{Command: CLUSREG_CREATE_KEY, KeyPath: "Parameters"},
+		{Command: CLUSREG_SET_VALUE, KeyPath: "Parameters", ValueName: "Path", DwType: REG_SZ, Data: []byte{'c', 0, 0, 0}},
+		{Command: CLUSREG_SET_VALUE, KeyPath: "Parameters", ValueName: "Timeout", DwType: REG_DWORD, Data: []byte{30, 0, 0, 0}},
+	}, changes)
+
+	changes = parseBatchCommands(fixtureMixed)
+	assert.Equal(t, []RegistryChange{
+		{Command: CLUSREG_SET_VALUE, KeyPath: "", ValueName: "guid", DwType: REG_BINARY, Data: []byte{1, 2}},
+		{Command: CLUSREG_CREATE_KEY, KeyPath: `a\b`},
+		{Command: CLUSREG_DELETE_VALUE, KeyPath: `a\b`, ValueName: "old"},
+		{Command: CLUSREG_VALUE_DELETED, KeyPath: `a\b`, ValueName: "older"},
+		{Command: CLUSREG_DELETE_KEY, KeyPath: `a\c`},
+		{Command: CLUSREG_SET_VALUE, KeyPath: `a\b`, ValueName: "size", DwType: REG_QWORD, Data: []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+	}, changes)
+
+	changes = parseBatchCommands(nil)
+	assert.Empty(t, changes)
+}
+
+func TestParseBatchCommandsUnknown(t *testing.T) {
+	changes := parseBatchCommands(fixtureUnknown)
+	assert.Equal(t, []RegistryChange{
+		{Command: CLUSREG_CREATE_KEY, KeyPath: "Parameters"},
+		{Command: CLUSREG_CONDITION_IS_EQUAL, ValueName: "Path", Data: []byte{'c', 0, 0, 0}},
+		{Command: CLUSREG_SET_KEY_SECURITY, Data: []byte{1, 0, 4, 0x80}},
+		{Command: CLUSREG_SET_VALUE, KeyPath: "Parameters", ValueName: "Timeout", DwType: REG_DWORD, Data: []byte{30, 0, 0, 0}},
+	}, changes)
+}
+
+// raw CLUSTER_BATCH_COMMAND fixtures laid out as ClusterRegBatchReadCommand
+// fills them in, fixtureMemory holds what their pointers refer to
+var (
+	fixtureRawCreateKey64 = []byte{
+		0x02, 0x00, 0x00, 0x00, // CLUSREG_CREATE_KEY
+		0x00, 0x00, 0x00, 0x00, // dwOptions
+		0x00, 0x10, 0xa1, 0xc5, 0x00, 0x00, 0x00, 0x00, // wzName
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // lpData
+		0x00, 0x00, 0x00, 0x00, // cbData
+		0x00, 0x00, 0x00, 0x00, // padding
+	}
+	fixtureRawSetValue64 = []byte{
+		0x01, 0x00, 0x00, 0x00, // CLUSREG_SET_VALUE
+		0x04, 0x00, 0x00, 0x00, // REG_DWORD
+		0x40, 0x10, 0xa1, 0xc5, 0x00, 0x00, 0x00, 0x00, // wzName
+		0x80, 0x10, 0xa1, 0xc5, 0x00, 0x00, 0x00, 0x00, // lpData
+		0x04, 0x00, 0x00, 0x00, // cbData
+		0xcc, 0xcc, 0xcc, 0xcc, // padding
+	}
+	fixtureRawConditionIsEqual64 = []byte{
+		0x0d, 0x00, 0x00, 0x00, // CLUSREG_CONDITION_IS_EQUAL
+		0x04, 0x00, 0x00, 0x00, // REG_DWORD
+		0x40, 0x10, 0xa1, 0xc5, 0x00, 0x00, 0x00, 0x00, // wzName
+		0x80, 0x10, 0xa1, 0xc5, 0x00, 0x00, 0x00, 0x00, // lpData
+		0x04, 0x00, 0x00, 0x00, // cbData
+		0x00, 0x00, 0x00, 0x00, // padding
+	}
+	fixtureRawDeleteValue32 = []byte{
+		0x04, 0x00, 0x00, 0x00, // CLUSREG_DELETE_VALUE
+		0x00, 0x00, 0x00, 0x00, // dwOptions
+		0xc0, 0x10, 0x5a, 0x00, // wzName
+		0x00, 0x00, 0x00, 0x00, // lpData
+		0x00, 0x00, 0x00, 0x00, // cbData
+	}
+	fixtureMemory = map[uintptr][]byte{
+		// Parameters\Pools
+		0xc5a11000: {'P', 0, 'a', 0, 'r', 0, 'a', 0, 'm', 0, 'e', 0, 't', 0, 'e', 0, 'r', 0, 's', 0, '\\', 0, 'P', 0, 'o', 0, 'o', 0, 'l', 0, 's', 0, 0, 0},
+		// Timeout
+		0xc5a11040: {'T', 0, 'i', 0, 'm', 0, 'e', 0, 'o', 0, 'u', 0, 't', 0, 0, 0},
+		// 30
+		0xc5a11080: {0x1e, 0x00, 0x00, 0x00},
+		// Größe
+		0x5a10c0: {'G', 0, 'r', 0, 0xf6, 0, 0xdf, 0, 'e', 0, 0, 0},
+	}
+)
+
+// readFixtureMemory is the batchMemory of fixtureMemory, reading past
+// the end of a region fails like a truncated buffer
+func readFixtureMemory(memory map[uintptr][]byte) batchMemory {
+	return func(address uintptr, size int) ([]byte, error) {
+		for start, region := range memory {
+			if address >= start && address < start+uintptr(len(region)) {
+				offset := int(address - start)
+				if offset+size > len(region) {
+					return nil, errors.ERROR_INVALID_DATA
+				}
+				return append([]byte(nil), region[offset:offset+size]...), nil
+			}
+		}
+		return nil, errors.ERROR_INVALID_DATA
+	}
+}
+
+func TestDecodeBatchCommand(t *testing.T) {
+	read := readFixtureMemory(fixtureMemory)
+
+	createKey, err := decodeBatchCommand(fixtureRawCreateKey64, 8, read)
+	assert.Nil(t, err)
+	assert.Equal(t, BatchCommand{Command: CLUSREG_CREATE_KEY, Name: `Parameters\Pools`, Data: []byte{}}, createKey)
+
+	setValue, err := decodeBatchCommand(fixtureRawSetValue64, 8, read)
+	assert.Nil(t, err)
+	assert.Equal(t, BatchCommand{Command: CLUSREG_SET_VALUE, Options: REG_DWORD, Name: "Timeout", Data: []byte{30, 0, 0, 0}}, setValue)
+
+	condition, err := decodeBatchCommand(fixtureRawConditionIsEqual64, 8, read)
+	assert.Nil(t, err)
+	assert.Equal(t, BatchCommand{Command: CLUSREG_CONDITION_IS_EQUAL, Options: REG_DWORD, Name: "Timeout", Data: []byte{30, 0, 0, 0}}, condition)
+
+	deleteValue, err := decodeBatchCommand(fixtureRawDeleteValue32, 4, read)
+	assert.Nil(t, err)
+	assert.Equal(t, BatchCommand{Command: CLUSREG_DELETE_VALUE, Name: "Größe", Data: []byte{}}, deleteValue)
+
+	changes := parseBatchCommands([]BatchCommand{createKey, setValue, condition, deleteValue})
+	assert.Equal(t, []RegistryChange{
+		{Command: CLUSREG_CREATE_KEY, KeyPath: `Parameters\Pools`},
+		{Command: CLUSREG_SET_VALUE, KeyPath: `Parameters\Pools`, ValueName: "Timeout", DwType: REG_DWORD, Data: []byte{30, 0, 0, 0}},
+		{Command: CLUSREG_CONDITION_IS_EQUAL, ValueName: "Timeout", Data: []byte{30, 0, 0, 0}},
+		{Command: CLUSREG_DELETE_VALUE, KeyPath: `Parameters\Pools`, ValueName: "Größe"},
+	}, changes)
+}
+
+func TestDecodeBatchCommandTruncated(t *testing.T) {
+	read := readFixtureMemory(fixtureMemory)
+
+	_, err := decodeBatchCommand(fixtureRawSetValue64[:27], 8, read)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA), err)
+	assert.Contains(t, err.Error(), "CLUSTER_BATCH_COMMAND of 27 bytes, 32 expected")
+
+	_, err = decodeBatchCommand(fixtureRawDeleteValue32[:19], 4, read)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA), err)
+
+	_, err = decodeBatchCommand(nil, 8, read)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA), err)
+
+	// the name misses its terminator and the data is short
+	truncatedMemory := map[uintptr][]byte{
+		0xc5a11040: {'T', 0, 'i', 0, 'm', 0},
+		0xc5a11080: {0x1e, 0x00},
+	}
+	_, err = decodeBatchCommand(fixtureRawSetValue64, 8, readFixtureMemory(truncatedMemory))
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA), err)
+
+	truncatedMemory[0xc5a11040] = fixtureMemory[0xc5a11040]
+	_, err = decodeBatchCommand(fixtureRawSetValue64, 8, readFixtureMemory(truncatedMemory))
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA), err)
+
+	nullData := append([]byte(nil), fixtureRawSetValue64...)
+	copy(nullData[16:24], make([]byte, 8))
+	_, err = decodeBatchCommand(nullData, 8, read)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_DATA), err)
+
+	_, err = decodeBatchCommand(fixtureRawSetValue64, 2, read)
+	assert.True(t, goerrors.Is(err, errors.ERROR_INVALID_PARAMETER), err)
+}
@@ -0,0 +1,133 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import (
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util/guid"
+)
+
+func (handle KeyHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetValue(value string, dwType uint32, data []byte) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetByteValue(value string, data []byte) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetGuidValue(value string, guid guid.GUID) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetStringValue(value string, data string) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetExpandStringValue(value string, data string) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetStringsValue(value string, data []string) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetDWordValue(value string, data uint32) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) SetQWordValue(value string, data uint64) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) Marshal(v interface{}) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) Unmarshal(v interface{}) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) CreateKey(keyName string, samDesired int) (key KeyHandle, created bool, err error) {
+	return 0, false, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) OpenKey(keyName string, samDesired int) (key KeyHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) EnumKeys() ([]string, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) DeleteKey(keyName string) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) DeleteTree(keyName string) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryInfo() (KeyInfo, error) {
+	return KeyInfo{}, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) LoadValues() (map[string]RegistryValue, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryValue(valueName string) (dwType uint32, data []byte, err error) {
+	return 0, nil, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryByteValue(valueName string) (data []byte, err error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryGuidValue(valueName string) (data guid.GUID, err error) {
+	return guid.GUID{}, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryStringValue(valueName string) (data string, err error) {
+	return "", errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryExpandStringValue(valueName string, expand bool) (data string, err error) {
+	return "", errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryStringsValue(valueName string) (data []string, err error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryDWordValue(valueName string) (data uint32, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) QueryQWordValue(valueName string) (data uint64, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) DeleteValue(valueName string) error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) CreateBatch() (RegBatchHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle KeyHandle) NewBatch() (*Batch, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle RegBatchHandle) BatchAddCommand(command ClusterRegCommand, value string, dwType uint32, data []byte) error {
+	return errors.ErrNotSupported
+}
+
+func (handle RegBatchHandle) CloseBatch(commit bool) (error, int) {
+	return errors.ErrNotSupported, 0
+}
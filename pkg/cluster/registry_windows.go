@@ -0,0 +1,607 @@
+package cluster
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/kernel32"
+	"github.com/KnicKnic/go-windows/pkg/util"
+	"github.com/KnicKnic/go-windows/pkg/util/guid"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procnativeClusterRegCreateKey = clusapi_dll.NewProc("ClusterRegCreateKey")
+	// procnativeClusterOpenCreateKey  = clusapi_dll.NewProc("ClusterOpenCreateKey")
+	procnativeClusterRegCloseKey        = clusapi_dll.NewProc("ClusterRegCloseKey")
+	procnativeClusterRegSetValue        = clusapi_dll.NewProc("ClusterRegSetValue")
+	procnativeClusterRegEnumValue       = clusapi_dll.NewProc("ClusterRegEnumValue")
+	procnativeClusterRegQueryValue      = clusapi_dll.NewProc("ClusterRegQueryValue")
+	procnativeClusterRegDeleteValue     = clusapi_dll.NewProc("ClusterRegDeleteValue")
+	procnativeClusterRegOpenKey         = clusapi_dll.NewProc("ClusterRegOpenKey")
+	procnativeClusterRegEnumKey         = clusapi_dll.NewProc("ClusterRegEnumKey")
+	procnativeClusterRegDeleteKey       = clusapi_dll.NewProc("ClusterRegDeleteKey")
+	procnativeClusterRegQueryInfoKey    = clusapi_dll.NewProc("ClusterRegQueryInfoKey")
+	procnativeClusterRegCreateBatch     = clusapi_dll.NewProc("ClusterRegCreateBatch")
+	procnativeClusterRegCloseBatch      = clusapi_dll.NewProc("ClusterRegCloseBatch")
+	procnativeClusterRegBatchAddCommand = clusapi_dll.NewProc("ClusterRegBatchAddCommand")
+)
+
+func closeClusterKey(handle KeyHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterRegCloseKey.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the handle, wrap it with Safe to close it at most once
+func (handle KeyHandle) Close() error {
+	err := handles.closed(keyHandleKind, uintptr(handle), func() error { return closeClusterKey(handle) })
+	return errors.Wrap(procnativeClusterRegCloseKey.Name, "", err)
+}
+
+func clusterRegSetValue(handle KeyHandle, lpszValueName *uint16, dwType uint32, data []byte) error {
+
+	var r0 uintptr
+	var dataSize uint32 = uint32(len(data))
+
+	if dataSize == 0 {
+		// use dataSize pointer as address for data because why not it won't be looked at
+		r0, _, _ = syscall.Syscall6(procnativeClusterRegSetValue.Addr(), 5, uintptr(handle), uintptr(unsafe.Pointer(lpszValueName)), uintptr(dwType), uintptr(unsafe.Pointer(&dataSize)), uintptr(dataSize), 0)
+	} else {
+		r0, _, _ = syscall.Syscall6(procnativeClusterRegSetValue.Addr(), 5, uintptr(handle), uintptr(unsafe.Pointer(lpszValueName)), uintptr(dwType), uintptr(unsafe.Pointer(&data[0])), uintptr(dataSize), 0)
+	}
+	lastError := syscall.Errno(r0)
+	return errors.NotZero(lastError)
+}
+
+// SetValue sets a value on a key
+// for dwType either see "golang.org/x/sys/windows/registry".BINARY (and other values)
+// or use syscall.REG_BINARY & other values
+func (handle KeyHandle) SetValue(value string, dwType uint32, data []byte) error {
+	vn, err := windows.UTF16PtrFromString(value)
+	if err == nil {
+		err = clusterRegSetValue(handle, vn, dwType, data)
+	}
+	return errors.Wrap(procnativeClusterRegSetValue.Name, value, err)
+}
+
+// SetByteValue sets a value on a key
+func (handle KeyHandle) SetByteValue(value string, data []byte) error {
+	return handle.SetValue(value, syscall.REG_BINARY, data)
+}
+
+// SetGuidValue sets a value on a key
+func (handle KeyHandle) SetGuidValue(value string, guid guid.GUID) error {
+	data, err := guid.ToByte()
+	if err != nil {
+		return errors.Wrap(procnativeClusterRegSetValue.Name, value, err)
+	}
+	return handle.SetByteValue(value, data)
+}
+
+// SetStringValue sets a REG_SZ value on a key
+func (handle KeyHandle) SetStringValue(value string, data string) error {
+	buf, err := util.StringToUTF16Bytes(data)
+	if err != nil {
+		return errors.Wrap(procnativeClusterRegSetValue.Name, value, err)
+	}
+	return handle.SetValue(value, REG_SZ, buf)
+}
+
+// SetExpandStringValue sets a REG_EXPAND_SZ value on a key
+// environment variables such as %SystemRoot% are stored unexpanded
+func (handle KeyHandle) SetExpandStringValue(value string, data string) error {
+	buf, err := util.StringToUTF16Bytes(data)
+	if err != nil {
+		return errors.Wrap(procnativeClusterRegSetValue.Name, value, err)
+	}
+	return handle.SetValue(value, REG_EXPAND_SZ, buf)
+}
+
+// SetStringsValue sets a REG_MULTI_SZ value on a key
+// the strings must not be empty
+func (handle KeyHandle) SetStringsValue(value string, data []string) error {
+	buf, err := util.StringsToUTF16Bytes(data)
+	if err != nil {
+		return errors.Wrap(procnativeClusterRegSetValue.Name, value, err)
+	}
+	return handle.SetValue(value, REG_MULTI_SZ, buf)
+}
+
+// SetDWordValue sets a REG_DWORD value on a key
+func (handle KeyHandle) SetDWordValue(value string, data uint32) error {
+	return handle.SetValue(value, REG_DWORD, util.Uint32ToByte(data))
+}
+
+// SetQWordValue sets a REG_QWORD value on a key
+func (handle KeyHandle) SetQWordValue(value string, data uint64) error {
+	return handle.SetValue(value, REG_QWORD, util.Uint64ToByte(data))
+}
+
+// Marshal writes the fields of the struct v to the key, see cluster.Marshal
+func (handle KeyHandle) Marshal(v interface{}) error {
+	return Marshal(NativeKey(handle), v)
+}
+
+// Unmarshal reads the key into the struct pointed to by v, see cluster.Unmarshal
+func (handle KeyHandle) Unmarshal(v interface{}) error {
+	return Unmarshal(NativeKey(handle), v)
+}
+
+func clusterRegCreateKey(handle KeyHandle, lpszKeyName *uint16, samDesired int) (KeyHandle, bool, error) {
+
+	var r0 uintptr
+	var disposition uint32
+
+	var keyHandle uintptr
+
+	r0, _, _ = syscall.Syscall9(procnativeClusterRegCreateKey.Addr(),
+		7,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(lpszKeyName)),
+		uintptr(0), /*REG_OPTION_NON_VOLATILE*/
+		uintptr(samDesired),
+		uintptr(0),
+		uintptr(unsafe.Pointer(&keyHandle)),
+		uintptr(unsafe.Pointer(&disposition)),
+		0,
+		0)
+
+	lastError := syscall.Errno(r0)
+	created := disposition == REG_CREATED_NEW_KEY
+	return KeyHandle(keyHandle), created, handles.opened(keyHandleKind, keyHandle, errors.NotZero(lastError))
+}
+
+// CreateKey creates a subkey
+// for samDesired use syscall.KEY_ALL_ACCESS KEY_READ KEY_WRITE KEY_SET_VALUE
+func (handle KeyHandle) CreateKey(keyName string, samDesired int) (key KeyHandle, created bool, err error) {
+	defer func() { err = errors.Wrap(procnativeClusterRegCreateKey.Name, keyName, err) }()
+	kn, err := windows.UTF16PtrFromString(keyName)
+	if err != nil {
+		return
+	}
+	key, created, err = clusterRegCreateKey(handle, kn, samDesired)
+	return
+}
+
+func clusterRegOpenKey(handle KeyHandle, lpszSubKey *uint16, samDesired int) (KeyHandle, error) {
+	var keyHandle uintptr
+
+	r0, _, _ := syscall.Syscall6(procnativeClusterRegOpenKey.Addr(),
+		4,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(lpszSubKey)),
+		uintptr(samDesired),
+		uintptr(unsafe.Pointer(&keyHandle)),
+		0,
+		0)
+
+	return KeyHandle(keyHandle), handles.opened(keyHandleKind, keyHandle, errors.NotZero(syscall.Errno(r0)))
+}
+
+// OpenKey opens an existing subkey, unlike CreateKey it does not create it
+// returns syscall.ERROR_FILE_NOT_FOUND if the subkey does not exist
+// for samDesired use syscall.KEY_ALL_ACCESS KEY_READ KEY_WRITE KEY_SET_VALUE
+func (handle KeyHandle) OpenKey(keyName string, samDesired int) (key KeyHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeClusterRegOpenKey.Name, keyName, err) }()
+	kn, err := windows.UTF16PtrFromString(keyName)
+	if err != nil {
+		return
+	}
+	key, err = clusterRegOpenKey(handle, kn, samDesired)
+	return
+}
+
+// clusterRegEnumKey
+func clusterRegEnumKey(handle KeyHandle, index uint32) (keyName string, lastWriteTime time.Time, err error) {
+
+	nameCCh := uint32(50)
+
+	var keyNameArr []uint16
+	var filetime windows.Filetime
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nameCCh += 2
+		keyNameArr = make([]uint16, nameCCh)
+		r0, _, _ := syscall.Syscall6(procnativeClusterRegEnumKey.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&keyNameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			uintptr(unsafe.Pointer(&filetime)),
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+	// add 1 for null
+	keyNameArr = append([]uint16(nil), keyNameArr[:nameCCh+1]...)
+
+	keyName = windows.UTF16ToString(keyNameArr)
+	lastWriteTime = time.Unix(0, filetime.Nanoseconds())
+
+	return
+}
+
+// EnumKeys returns the names of the subkeys of a key
+func (handle KeyHandle) EnumKeys() ([]string, error) {
+	names := []string{}
+
+	for index := uint32(0); ; index++ {
+		name, _, err := clusterRegEnumKey(handle, index)
+		if err == ERROR_NO_MORE_ITEMS {
+			return names, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(procnativeClusterRegEnumKey.Name, "", err)
+		}
+		names = append(names, name)
+	}
+}
+
+func clusterRegDeleteKey(handle KeyHandle, lpszSubKey *uint16) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterRegDeleteKey.Addr(),
+		2,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(lpszSubKey)),
+		0)
+
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// DeleteKey deletes the subkey keyName, the subkey must not have subkeys
+// use DeleteTree to delete a subkey and everything under it
+func (handle KeyHandle) DeleteKey(keyName string) error {
+	kn, err := windows.UTF16PtrFromString(keyName)
+	if err == nil {
+		err = clusterRegDeleteKey(handle, kn)
+	}
+	return errors.Wrap(procnativeClusterRegDeleteKey.Name, keyName, err)
+}
+
+// DeleteTree deletes the subkey keyName with all of its subkeys and values
+// if keyName is "" the subkeys and values of the key itself are deleted
+func (handle KeyHandle) DeleteTree(keyName string) error {
+	return deleteTree(NativeKey(handle), keyName)
+}
+
+func clusterRegQueryInfoKey(handle KeyHandle) (info KeyInfo, err error) {
+	var filetime windows.Filetime
+
+	r0, _, _ := syscall.Syscall9(procnativeClusterRegQueryInfoKey.Addr(),
+		8,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(&info.SubKeys)),
+		uintptr(unsafe.Pointer(&info.MaxSubKeyLen)),
+		uintptr(unsafe.Pointer(&info.Values)),
+		uintptr(unsafe.Pointer(&info.MaxValueNameLen)),
+		uintptr(unsafe.Pointer(&info.MaxValueLen)),
+		uintptr(unsafe.Pointer(&info.SecurityDescriptorLen)),
+		uintptr(unsafe.Pointer(&filetime)),
+		0)
+
+	err = errors.NotZero(syscall.Errno(r0))
+	if err != nil {
+		return
+	}
+	info.LastWriteTime = time.Unix(0, filetime.Nanoseconds())
+	return
+}
+
+// QueryInfo returns the number of subkeys and values of a key,
+// the longest names and data and the last write time
+func (handle KeyHandle) QueryInfo() (KeyInfo, error) {
+	info, err := clusterRegQueryInfoKey(handle)
+	return info, errors.Wrap(procnativeClusterRegQueryInfoKey.Name, "", err)
+}
+
+// clusterRegEnumValue
+func clusterRegEnumValue(handle KeyHandle, index uint32) (keyName string, dwType uint32, data []byte, err error) {
+
+	nameCCh := uint32(50)
+	dataCB := uint32(248)
+
+	var keyNameArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nameCCh += 2
+		dataCB += 8
+		data = make([]byte, dataCB)
+		keyNameArr = make([]uint16, nameCCh)
+		r0, _, _ := syscall.Syscall9(procnativeClusterRegEnumValue.Addr(),
+			7,
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&keyNameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			uintptr(unsafe.Pointer(&dwType)),
+			uintptr(unsafe.Pointer(&data[0])),
+			uintptr(unsafe.Pointer(&dataCB)),
+			0,
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+	// resize arrays to appropriate return sizes
+	data = append([]byte(nil), data[:dataCB]...)
+	// add 1 for null
+	keyNameArr = append([]uint16(nil), keyNameArr[:nameCCh+1]...)
+
+	keyName = windows.UTF16ToString(keyNameArr)
+
+	return
+}
+
+// LoadValues loads the values and data of a key into a map
+func (handle KeyHandle) LoadValues() (map[string]RegistryValue, error) {
+	loaded := make(map[string]RegistryValue)
+
+	for index := uint32(0); ; index++ {
+		id, dwType, data, err := clusterRegEnumValue(handle, index)
+		if err == ERROR_NO_MORE_ITEMS {
+			return loaded, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(procnativeClusterRegEnumValue.Name, "", err)
+		}
+		loaded[id] = RegistryValue{
+			Data:   data,
+			DwType: dwType,
+		}
+	}
+}
+
+// also need to add batches
+// Test if batch returns error when violate a condition
+
+// need query value
+
+func clusterRegQueryValue(handle KeyHandle, value *uint16) (dwType uint32, data []byte, err error) {
+
+	dataCB := uint32(248)
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		dataCB += 8
+		data = make([]byte, dataCB)
+		r0, _, _ := syscall.Syscall6(procnativeClusterRegQueryValue.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(value)),
+			uintptr(unsafe.Pointer(&dwType)),
+			uintptr(unsafe.Pointer(&data[0])),
+			uintptr(unsafe.Pointer(&dataCB)),
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+	// resize arrays to appropriate return sizes
+	data = append([]byte(nil), data[:dataCB]...)
+
+	return
+}
+
+// QueryValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+// for dwType either see "golang.org/x/sys/windows/registry".BINARY (and other values)
+// or use syscall.REG_BINARY & other values
+func (handle KeyHandle) QueryValue(valueName string) (dwType uint32, data []byte, err error) {
+	defer func() { err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, err) }()
+	vn, err := windows.UTF16PtrFromString(valueName)
+	if err != nil {
+		return
+	}
+
+	dwType, data, err = clusterRegQueryValue(handle, vn)
+	return
+}
+
+// QueryByteValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+func (handle KeyHandle) QueryByteValue(valueName string) (data []byte, err error) {
+	dwType, data, err := handle.QueryValue(valueName)
+	if err != nil {
+		return
+	}
+	if dwType != syscall.REG_BINARY {
+		err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, errors.ERROR_INVALID_DATA)
+		return
+	}
+	return
+}
+
+// QueryGuidValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+func (handle KeyHandle) QueryGuidValue(valueName string) (data guid.GUID, err error) {
+	dataBuf, err := handle.QueryByteValue(valueName)
+	if err != nil {
+		return
+	}
+	if len(dataBuf) != int(unsafe.Sizeof(data)) {
+		err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, errors.ERROR_INVALID_DATA)
+		return
+	}
+
+	data, err = guid.FromBytes(dataBuf)
+	err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, err)
+	return
+}
+
+// QueryStringValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+// REG_EXPAND_SZ values are returned unexpanded
+func (handle KeyHandle) QueryStringValue(valueName string) (data string, err error) {
+	dwType, buf, err := handle.QueryValue(valueName)
+	if err != nil {
+		return
+	}
+	data, err = decodeString(dwType, buf)
+	err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, err)
+	return
+}
+
+// QueryExpandStringValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+// if expand is true environment variables in the value are expanded
+func (handle KeyHandle) QueryExpandStringValue(valueName string, expand bool) (data string, err error) {
+	data, err = handle.QueryStringValue(valueName)
+	if err != nil || !expand {
+		return
+	}
+	data, err = kernel32.ExpandEnvironmentStrings(data)
+	err = errors.Wrap("ExpandEnvironmentStrings", valueName, err)
+	return
+}
+
+// QueryStringsValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+func (handle KeyHandle) QueryStringsValue(valueName string) (data []string, err error) {
+	dwType, buf, err := handle.QueryValue(valueName)
+	if err != nil {
+		return
+	}
+	data, err = decodeStrings(dwType, buf)
+	err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, err)
+	return
+}
+
+// QueryDWordValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+func (handle KeyHandle) QueryDWordValue(valueName string) (data uint32, err error) {
+	dwType, buf, err := handle.QueryValue(valueName)
+	if err != nil {
+		return
+	}
+	data, err = decodeDWord(dwType, buf)
+	err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, err)
+	return
+}
+
+// QueryQWordValue returns syscall.ERROR_FILE_NOT_FOUND if value does not exist
+func (handle KeyHandle) QueryQWordValue(valueName string) (data uint64, err error) {
+	dwType, buf, err := handle.QueryValue(valueName)
+	if err != nil {
+		return
+	}
+	data, err = decodeQWord(dwType, buf)
+	err = errors.Wrap(procnativeClusterRegQueryValue.Name, valueName, err)
+	return
+}
+
+func clusterRegDeleteValue(handle KeyHandle, lpszValueName *uint16) error {
+	var r0 uintptr
+	r0, _, _ = syscall.Syscall(procnativeClusterRegDeleteValue.Addr(),
+		2,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(lpszValueName)),
+		0)
+
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// DeleteValue deletes the value specified by valueName from a key
+func (handle KeyHandle) DeleteValue(valueName string) error {
+	vn, err := windows.UTF16PtrFromString(valueName)
+	if err == nil {
+		err = clusterRegDeleteValue(handle, vn)
+	}
+	return errors.Wrap(procnativeClusterRegDeleteValue.Name, valueName, err)
+}
+
+func clusterRegCreateBatch(handle KeyHandle) (RegBatchHandle, error) {
+	var r0 uintptr
+	var batchHandle uintptr
+	r0, _, _ = syscall.Syscall(procnativeClusterRegCreateBatch.Addr(),
+		2,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(&batchHandle)),
+		0)
+
+	return RegBatchHandle(batchHandle), errors.NotZero(syscall.Errno(r0))
+}
+
+func (handle KeyHandle) CreateBatch() (RegBatchHandle, error) {
+	batch, err := clusterRegCreateBatch(handle)
+	return batch, errors.Wrap(procnativeClusterRegCreateBatch.Name, "", err)
+}
+
+// NewBatch creates a Batch on the key, see cluster.Batch
+func (handle KeyHandle) NewBatch() (*Batch, error) {
+	return NewBatch(NativeKey(handle))
+}
+
+func clusterRegBatchAddCommand(handle RegBatchHandle, command ClusterRegCommand, wzName *uint16, dwType uint32, data []byte) error {
+	var r0 uintptr
+	var dataSize uint32 = uint32(len(data))
+
+	if dataSize == 0 {
+		// use dataSize pointer as address for data because why not it won't be looked at
+		r0, _, _ = syscall.Syscall6(procnativeClusterRegBatchAddCommand.Addr(),
+			6,
+			uintptr(handle),
+			uintptr(uint32(command)),
+			uintptr(unsafe.Pointer(wzName)),
+			uintptr(dwType),
+			uintptr(unsafe.Pointer(&dataSize)),
+			uintptr(dataSize),
+		)
+	} else {
+		r0, _, _ = syscall.Syscall6(procnativeClusterRegBatchAddCommand.Addr(),
+			6,
+			uintptr(handle),
+			uintptr(uint32(command)),
+			uintptr(unsafe.Pointer(wzName)),
+			uintptr(dwType),
+			uintptr(unsafe.Pointer(&data[0])),
+			uintptr(dataSize),
+		)
+	}
+
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// BatchAddCommand adds a command to a batch
+// If data is non-nil dwType should be one of the standard registry value
+// types (REG_*) defined in golang.org/x/sys/windows/types_windows.go
+func (handle RegBatchHandle) BatchAddCommand(command ClusterRegCommand, value string, dwType uint32, data []byte) error {
+	vn, err := windows.UTF16PtrFromString(value)
+	if err == nil {
+		err = clusterRegBatchAddCommand(handle, command, vn, dwType, data)
+	}
+	return errors.Wrap(procnativeClusterRegBatchAddCommand.Name, value, err)
+}
+
+func clusterRegCloseBatch(handle RegBatchHandle, commit bool) (error, int) {
+	var r0 uintptr
+	var failedCommandNumber uintptr
+	var commitUInt uint = 0
+	if commit {
+		commitUInt = 1
+	}
+
+	r0, _, _ = syscall.Syscall(procnativeClusterRegCloseBatch.Addr(),
+		3,
+		uintptr(handle),
+		uintptr(commitUInt),
+		uintptr(unsafe.Pointer(&failedCommandNumber)))
+
+	err := errors.NotZero(syscall.Errno(r0))
+	if err == nil {
+		// if err is nil, there is no valid value in failedCommandNumber
+		return nil, 0
+	}
+
+	// otherwise, cast to an int, must be signed since failedCommand
+	// can be -1 if the batch execution failed before any operations took place
+	return err, int(failedCommandNumber)
+}
+
+// CloseBatch closes the batch, either executing or discarding it based on the value of commit
+// The second return value is the number of the failed command
+// It should only be used if error is not nil
+func (handle RegBatchHandle) CloseBatch(commit bool) (error, int) {
+	err, failedCommand := clusterRegCloseBatch(handle, commit)
+	return errors.Wrap(procnativeClusterRegCloseBatch.Name, "", err), failedCommand
+}
@@ -1,12 +1,16 @@
 package cluster
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"reflect"
 	"syscall"
 	"testing"
+	"time"
+	"unsafe"
 
+	"github.com/KnicKnic/go-windows/pkg/errors"
 	"github.com/KnicKnic/go-windows/pkg/util/guid"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,7 +36,12 @@ func TestQueryInvalidValue(t *testing.T) {
 	_, err = key.QueryGuidValue("nonExistant")
 	fmt.Println(err)
 	assert.NotNil(t, err)
-	assert.Equal(t, syscall.ERROR_FILE_NOT_FOUND, err)
+	assert.True(t, errors.Is(err, syscall.ERROR_FILE_NOT_FOUND))
+	var clusterErr *errors.ClusterError
+	assert.True(t, errors.As(err, &clusterErr))
+	assert.Equal(t, "ClusterRegQueryValue", clusterErr.API)
+	assert.Equal(t, "nonExistant", clusterErr.Object)
+	assert.Equal(t, syscall.ERROR_FILE_NOT_FOUND, clusterErr.Code)
 
 	myGuidStr := "206994D6-C7B7-ABDB-D89E-AB9CBF3853C4"
 	myGuid, err := guid.FromString(myGuidStr)
@@ -294,3 +303,162 @@ func BatchTestCloseWithoutExecuting(t *testing.T, key KeyHandle) {
 		}
 	}
 }
+
+func TestTypedValues(t *testing.T) {
+	clusterHandle, err := OpenCluster()
+	assert.Nil(t, err)
+	defer clusterHandle.Close()
+
+	// open resource
+	resourceHandle, err := clusterHandle.OpenResource(validResourceName)
+	assert.Nil(t, err)
+	defer resourceHandle.Close()
+
+	// open the root key of the registry
+	rootKeyHandle, err := resourceHandle.GetKey(syscall.KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	defer rootKeyHandle.Close()
+
+	// Create a test key
+	key, _, err := rootKeyHandle.CreateKey("test", syscall.KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	defer key.Close()
+
+	err = key.SetStringValue("string", "value")
+	assert.Nil(t, err)
+	str, err := key.QueryStringValue("string")
+	assert.Nil(t, err)
+	assert.Equal(t, "value", str)
+
+	err = key.SetExpandStringValue("expand", "%SystemRoot%")
+	assert.Nil(t, err)
+	str, err = key.QueryExpandStringValue("expand", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "%SystemRoot%", str)
+	str, err = key.QueryExpandStringValue("expand", true)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "%SystemRoot%", str)
+
+	err = key.SetStringsValue("strings", []string{"a", "b"})
+	assert.Nil(t, err)
+	strs, err := key.QueryStringsValue("strings")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, strs)
+
+	err = key.SetDWordValue("dword", 42)
+	assert.Nil(t, err)
+	dword, err := key.QueryDWordValue("dword")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(42), dword)
+
+	err = key.SetQWordValue("qword", 1<<40)
+	assert.Nil(t, err)
+	qword, err := key.QueryQWordValue("qword")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1<<40), qword)
+
+	// type mismatch
+	_, err = key.QueryDWordValue("string")
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_DATA))
+
+	for _, value := range []string{"string", "expand", "strings", "dword", "qword"} {
+		err = key.DeleteValue(value)
+		assert.Nil(t, err)
+	}
+}
+
+func TestKeyTree(t *testing.T) {
+	clusterHandle, err := OpenCluster()
+	assert.Nil(t, err)
+	defer clusterHandle.Close()
+
+	// open resource
+	resourceHandle, err := clusterHandle.OpenResource(validResourceName)
+	assert.Nil(t, err)
+	defer resourceHandle.Close()
+
+	// open the root key of the registry
+	rootKeyHandle, err := resourceHandle.GetKey(syscall.KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	defer rootKeyHandle.Close()
+
+	_, err = rootKeyHandle.OpenKey("test-tree", syscall.KEY_READ)
+	assert.True(t, errors.Is(err, syscall.ERROR_FILE_NOT_FOUND))
+
+	// Create a test tree
+	key, _, err := rootKeyHandle.CreateKey(`test-tree\a\b`, syscall.KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	err = key.SetDWordValue("value", 1)
+	assert.Nil(t, err)
+	key.Close()
+
+	key, err = rootKeyHandle.OpenKey("test-tree", syscall.KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	defer key.Close()
+
+	names, err := key.EnumKeys()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a"}, names)
+
+	info, err := key.QueryInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), info.SubKeys)
+	assert.Equal(t, uint32(0), info.Values)
+
+	err = rootKeyHandle.DeleteTree("test-tree")
+	assert.Nil(t, err)
+
+	_, err = rootKeyHandle.OpenKey("test-tree", syscall.KEY_READ)
+	assert.True(t, errors.Is(err, syscall.ERROR_FILE_NOT_FOUND))
+}
+
+func TestWatchBatches(t *testing.T) {
+	clusterHandle, err := OpenCluster()
+	assert.Nil(t, err)
+	defer clusterHandle.Close()
+
+	// open resource
+	resourceHandle, err := clusterHandle.OpenResource(validResourceName)
+	assert.Nil(t, err)
+	defer resourceHandle.Close()
+
+	// open the root key of the registry
+	rootKeyHandle, err := resourceHandle.GetKey(syscall.KEY_ALL_ACCESS)
+	assert.Nil(t, err)
+	defer rootKeyHandle.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	notifications, err := rootKeyHandle.WatchBatches(ctx)
+	assert.Nil(t, err)
+
+	batch, err := rootKeyHandle.NewBatch()
+	assert.Nil(t, err)
+	err = batch.Set("watched", uint32(1)).Commit()
+	assert.Nil(t, err)
+
+	notification, ok := <-notifications
+	assert.True(t, ok)
+	assert.Nil(t, notification.Err)
+
+	found := false
+	for _, change := range notification.Changes {
+		if change.Command == CLUSREG_SET_VALUE && change.ValueName == "watched" {
+			found = true
+			assert.Equal(t, uint32(syscall.REG_DWORD), change.DwType)
+		}
+	}
+	assert.True(t, found)
+
+	cancel()
+	for range notifications {
+	}
+
+	err = rootKeyHandle.DeleteValue("watched")
+	assert.Nil(t, err)
+}
+
+func TestClusterBatchCommandSize(t *testing.T) {
+	assert.Equal(t, batchCommandSize(int(unsafe.Sizeof(uintptr(0)))), int(unsafe.Sizeof(clusterBatchCommand{})))
+}
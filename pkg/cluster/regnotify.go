@@ -0,0 +1,6 @@
+package cluster
+
+type (
+	RegBatchPortHandle         uintptr
+	RegBatchNotificationHandle uintptr
+)
@@ -0,0 +1,38 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+func (handle KeyHandle) CreateBatchNotifyPort() (RegBatchPortHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle RegBatchPortHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle RegBatchPortHandle) GetBatchNotification() (RegBatchNotificationHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle RegBatchNotificationHandle) ReadCommand() (BatchCommand, error) {
+	return BatchCommand{}, errors.ErrNotSupported
+}
+
+func (handle RegBatchNotificationHandle) ReadChanges() ([]RegistryChange, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle RegBatchNotificationHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle KeyHandle) WatchBatches(ctx context.Context) (<-chan BatchNotification, error) {
+	return nil, errors.ErrNotSupported
+}
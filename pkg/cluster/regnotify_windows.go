@@ -0,0 +1,195 @@
+package cluster
+
+import (
+	"context"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/ntdll"
+)
+
+// clusterBatchCommand is CLUSTER_BATCH_COMMAND
+type clusterBatchCommand struct {
+	Command   ClusterRegCommand
+	dwOptions uint32
+	wzName    *uint16
+	lpData    uintptr
+	cbData    uint32
+}
+
+var (
+	procnativeClusterRegCreateBatchNotifyPort  = clusapi_dll.NewProc("ClusterRegCreateBatchNotifyPort")
+	procnativeClusterRegCloseBatchNotifyPort   = clusapi_dll.NewProc("ClusterRegCloseBatchNotifyPort")
+	procnativeClusterRegGetBatchNotification   = clusapi_dll.NewProc("ClusterRegGetBatchNotification")
+	procnativeClusterRegBatchReadCommand       = clusapi_dll.NewProc("ClusterRegBatchReadCommand")
+	procnativeClusterRegBatchCloseNotification = clusapi_dll.NewProc("ClusterRegBatchCloseNotification")
+)
+
+func clusterRegCreateBatchNotifyPort(handle KeyHandle) (RegBatchPortHandle, error) {
+	var portHandle uintptr
+	r0, _, _ := syscall.Syscall(procnativeClusterRegCreateBatchNotifyPort.Addr(),
+		2,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(&portHandle)),
+		0)
+
+	return RegBatchPortHandle(portHandle), handles.opened(regBatchPortHandleKind, portHandle, errors.NotZero(syscall.Errno(r0)))
+}
+
+// CreateBatchNotifyPort creates a port that receives every batch
+// committed to the key or its subkeys
+func (handle KeyHandle) CreateBatchNotifyPort() (RegBatchPortHandle, error) {
+	port, err := clusterRegCreateBatchNotifyPort(handle)
+	return port, errors.Wrap(procnativeClusterRegCreateBatchNotifyPort.Name, "", err)
+}
+
+func clusterRegCloseBatchNotifyPort(handle RegBatchPortHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterRegCloseBatchNotifyPort.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the port, a blocked GetBatchNotification returns an error.
+// It must not be closed twice
+func (handle RegBatchPortHandle) Close() error {
+	err := handles.closed(regBatchPortHandleKind, uintptr(handle), func() error { return clusterRegCloseBatchNotifyPort(handle) })
+	return errors.Wrap(procnativeClusterRegCloseBatchNotifyPort.Name, "", err)
+}
+
+func clusterRegGetBatchNotification(handle RegBatchPortHandle) (RegBatchNotificationHandle, error) {
+	var notification uintptr
+	r0, _, _ := syscall.Syscall(procnativeClusterRegGetBatchNotification.Addr(),
+		2,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(&notification)),
+		0)
+
+	return RegBatchNotificationHandle(notification), handles.opened(regBatchNotificationHandleKind, notification, errors.NotZero(syscall.Errno(r0)))
+}
+
+// GetBatchNotification blocks until a batch is committed
+func (handle RegBatchPortHandle) GetBatchNotification() (RegBatchNotificationHandle, error) {
+	notification, err := clusterRegGetBatchNotification(handle)
+	return notification, errors.Wrap(procnativeClusterRegGetBatchNotification.Name, "", err)
+}
+
+func clusterRegBatchReadCommand(handle RegBatchNotificationHandle) (BatchCommand, error) {
+	var native clusterBatchCommand
+	r0, _, _ := syscall.Syscall(procnativeClusterRegBatchReadCommand.Addr(),
+		2,
+		uintptr(handle),
+		uintptr(unsafe.Pointer(&native)),
+		0)
+
+	if err := errors.NotZero(syscall.Errno(r0)); err != nil {
+		return BatchCommand{}, err
+	}
+
+	raw := (*[unsafe.Sizeof(native)]byte)(unsafe.Pointer(&native))
+	return decodeBatchCommand(raw[:], int(unsafe.Sizeof(uintptr(0))), readNativeMemory)
+}
+
+// readNativeMemory is the batchMemory of memory owned by the api
+func readNativeMemory(address uintptr, size int) ([]byte, error) {
+	data := make([]byte, size)
+	ntdll.MemcpySrcC(data, address, uint64(size))
+	return data, nil
+}
+
+// ReadCommand returns the next command of the notification
+// returns ERROR_NO_MORE_ITEMS after the last command
+func (handle RegBatchNotificationHandle) ReadCommand() (BatchCommand, error) {
+	command, err := clusterRegBatchReadCommand(handle)
+	return command, wrapEnumError(procnativeClusterRegBatchReadCommand.Name, err)
+}
+
+// ReadChanges reads and decodes every command of the notification
+func (handle RegBatchNotificationHandle) ReadChanges() ([]RegistryChange, error) {
+	var commands []BatchCommand
+	for {
+		command, err := handle.ReadCommand()
+		if err == ERROR_NO_MORE_ITEMS {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+	return parseBatchCommands(commands), nil
+}
+
+func clusterRegBatchCloseNotification(handle RegBatchNotificationHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterRegBatchCloseNotification.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle RegBatchNotificationHandle) Close() error {
+	err := handles.closed(regBatchNotificationHandleKind, uintptr(handle), func() error { return clusterRegBatchCloseNotification(handle) })
+	return errors.Wrap(procnativeClusterRegBatchCloseNotification.Name, "", err)
+}
+
+// WatchBatches delivers every batch committed to the key or its subkeys
+// until ctx is done, the channel is closed afterwards. If reading fails
+// the last notification carries the error
+func (handle KeyHandle) WatchBatches(ctx context.Context) (<-chan BatchNotification, error) {
+	port, err := handle.CreateBatchNotifyPort()
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make(chan BatchNotification)
+	done := make(chan struct{})
+
+	// closing the port unblocks GetBatchNotification
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		port.Close()
+	}()
+
+	go func() {
+		defer close(notifications)
+		defer close(done)
+		for {
+			changes, err := readBatchNotification(port)
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case notifications <- BatchNotification{Changes: changes, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return notifications, nil
+}
+
+func readBatchNotification(port RegBatchPortHandle) ([]RegistryChange, error) {
+	notification, err := port.GetBatchNotification()
+	if err != nil {
+		return nil, err
+	}
+	defer notification.Close()
+	return notification.ReadChanges()
+}
+
+// utf16PtrToString converts a null terminated string owned by the api
+func utf16PtrToString(ptr *uint16) string {
+	if ptr == nil {
+		return ""
+	}
+	length := 0
+	for p := unsafe.Pointer(ptr); *(*uint16)(p) != 0; p = unsafe.Pointer(uintptr(p) + 2) {
+		length++
+	}
+	return string(utf16.Decode((*[1 << 29]uint16)(unsafe.Pointer(ptr))[:length:length]))
+}
@@ -0,0 +1,139 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/KnicKnic/go-windows/pkg/cluster/clusprop"
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+func (cluster ClusterHandle) OpenResource(resourceName string) (handle ResourceHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) GetKey(samDesired int) (KeyHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Online() error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Offline() error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Fail() error {
+	return errors.ErrNotSupported
+}
+
+func (group GroupHandle) CreateResource(resourceName string, resourceType string, flags uint32) (handle ResourceHandle, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Delete() error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) ChangeGroup(group GroupHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) State() (state ClusterResourceState, ownerNode string, groupName string, err error) {
+	return 0, "", "", errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) WaitSettled(ctx context.Context) (ClusterResourceState, string, error) {
+	return 0, "", errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) PrivateProperties() (clusprop.PropertyList, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) GetPrivateProperties(v interface{}) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) SetPrivateProperties(v interface{}) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) CommonProperties() (clusprop.PropertyList, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) GetCommonProperties(v interface{}) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) SetCommonProperties(v interface{}) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) AddDependency(dependsOn ResourceHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) RemoveDependency(dependsOn ResourceHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) DependencyExpression() (string, error) {
+	return "", errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) SetDependencyExpression(expression string) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Dependencies() (DependencyExpression, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) SetDependencies(expression DependencyExpression) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) OpenEnum(enumType ClusterResourceEnumType) (ResourceEnumHandle, error) {
+	return 0, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) Enumerate(enumType ClusterResourceEnumType) (*ClusterIterator, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle ResourceEnumHandle) Count() uint32 {
+	return 0
+}
+
+func (handle ResourceEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	return ClusterEnumItem{}, errors.ErrNotSupported
+}
+
+func (handle ResourceEnumHandle) Close() error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) AddPossibleOwner(node NodeHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) RemovePossibleOwner(node NodeHandle) error {
+	return errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) PossibleOwners() ([]string, error) {
+	return nil, errors.ErrNotSupported
+}
+
+func (handle ResourceHandle) SyncPossibleOwners(cluster ClusterHandle, desired []string) error {
+	return errors.ErrNotSupported
+}
@@ -0,0 +1,466 @@
+package cluster
+
+import (
+	"context"
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/cluster/clusprop"
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procnativeOpenClusterResource        = clusapi_dll.NewProc("OpenClusterResource")
+	procnativeCloseClusterResource       = clusapi_dll.NewProc("CloseClusterResource")
+	procnativeGetClusterResourceKey      = clusapi_dll.NewProc("GetClusterResourceKey")
+	procnativeOnlineClusterResource      = clusapi_dll.NewProc("OnlineClusterResource")
+	procnativeOfflineClusterResource     = clusapi_dll.NewProc("OfflineClusterResource")
+	procnativeFailClusterResource        = clusapi_dll.NewProc("FailClusterResource")
+	procnativeCreateClusterResource      = clusapi_dll.NewProc("CreateClusterResource")
+	procnativeDeleteClusterResource      = clusapi_dll.NewProc("DeleteClusterResource")
+	procnativeChangeClusterResourceGroup = clusapi_dll.NewProc("ChangeClusterResourceGroup")
+	procnativeGetClusterResourceState    = clusapi_dll.NewProc("GetClusterResourceState")
+
+	procnativeAddClusterResourceDependency           = clusapi_dll.NewProc("AddClusterResourceDependency")
+	procnativeRemoveClusterResourceDependency        = clusapi_dll.NewProc("RemoveClusterResourceDependency")
+	procnativeGetClusterResourceDependencyExpression = clusapi_dll.NewProc("GetClusterResourceDependencyExpression")
+	procnativeSetClusterResourceDependencyExpression = clusapi_dll.NewProc("SetClusterResourceDependencyExpression")
+
+	procnativeAddClusterResourceNode      = clusapi_dll.NewProc("AddClusterResourceNode")
+	procnativeRemoveClusterResourceNode   = clusapi_dll.NewProc("RemoveClusterResourceNode")
+	procnativeClusterResourceOpenEnum     = clusapi_dll.NewProc("ClusterResourceOpenEnum")
+	procnativeClusterResourceGetEnumCount = clusapi_dll.NewProc("ClusterResourceGetEnumCount")
+	procnativeClusterResourceEnum         = clusapi_dll.NewProc("ClusterResourceEnum")
+	procnativeClusterResourceCloseEnum    = clusapi_dll.NewProc("ClusterResourceCloseEnum")
+)
+
+func openClusterResource(cluster ClusterHandle, resourceName *uint16) (ResourceHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeOpenClusterResource.Addr(), 2, uintptr(cluster), uintptr(unsafe.Pointer(resourceName)), 0)
+	handle := ResourceHandle(r0)
+	return handle, handles.opened(resourceHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+func (cluster ClusterHandle) OpenResource(resourceName string) (handle ResourceHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeOpenClusterResource.Name, resourceName, err) }()
+	rn, err := windows.UTF16PtrFromString(resourceName)
+	if err != nil {
+		return
+	}
+	handle, err = openClusterResource(cluster, rn)
+	return
+}
+
+func closeClusterResource(handle ResourceHandle) error {
+	r0, _, lastError := syscall.Syscall(procnativeCloseClusterResource.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotNill(r0, lastError)
+}
+
+// Close closes the handle, wrap it with Safe to close it at most once
+func (handle ResourceHandle) Close() error {
+	err := handles.closed(resourceHandleKind, uintptr(handle), func() error { return closeClusterResource(handle) })
+	return errors.Wrap(procnativeCloseClusterResource.Name, "", err)
+}
+
+// GetKey gets a cluster registry key for the resource
+// for samDesired use syscall.KEY_ALL_ACCESS KEY_READ KEY_WRITE KEY_SET_VALUE
+func (handle ResourceHandle) GetKey(samDesired int) (KeyHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeGetClusterResourceKey.Addr(), 2, uintptr(handle), uintptr(samDesired), 0)
+	key := KeyHandle(r0)
+	err := handles.opened(keyHandleKind, r0, errors.NotNill(r0, lastError))
+	return key, errors.Wrap(procnativeGetClusterResourceKey.Name, "", err)
+}
+
+func onlineClusterResource(handle ResourceHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeOnlineClusterResource.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Online brings the resource online, returns ERROR_IO_PENDING
+// while the resource is still coming online, see WaitSettled
+func (handle ResourceHandle) Online() error {
+	return errors.Wrap(procnativeOnlineClusterResource.Name, "", onlineClusterResource(handle))
+}
+
+func offlineClusterResource(handle ResourceHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeOfflineClusterResource.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Offline takes the resource offline, returns ERROR_IO_PENDING
+// while the resource is still going offline, see WaitSettled
+func (handle ResourceHandle) Offline() error {
+	return errors.Wrap(procnativeOfflineClusterResource.Name, "", offlineClusterResource(handle))
+}
+
+func failClusterResource(handle ResourceHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeFailClusterResource.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Fail initiates a failure of the resource
+func (handle ResourceHandle) Fail() error {
+	return errors.Wrap(procnativeFailClusterResource.Name, "", failClusterResource(handle))
+}
+
+func createClusterResource(group GroupHandle, resourceName *uint16, resourceType *uint16, flags uint32) (ResourceHandle, error) {
+	r0, _, lastError := syscall.Syscall6(procnativeCreateClusterResource.Addr(),
+		4,
+		uintptr(group),
+		uintptr(unsafe.Pointer(resourceName)),
+		uintptr(unsafe.Pointer(resourceType)),
+		uintptr(flags),
+		0,
+		0)
+	handle := ResourceHandle(r0)
+	return handle, handles.opened(resourceHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// CreateResource creates a resource of resourceType in the group, the resource is offline
+// for flags use CLUSTER_RESOURCE_DEFAULT_MONITOR or CLUSTER_RESOURCE_SEPARATE_MONITOR
+func (group GroupHandle) CreateResource(resourceName string, resourceType string, flags uint32) (handle ResourceHandle, err error) {
+	defer func() { err = errors.Wrap(procnativeCreateClusterResource.Name, resourceName, err) }()
+	rn, err := windows.UTF16PtrFromString(resourceName)
+	if err != nil {
+		return
+	}
+	rt, err := windows.UTF16PtrFromString(resourceType)
+	if err != nil {
+		return
+	}
+	handle, err = createClusterResource(group, rn, rt, flags)
+	return
+}
+
+func deleteClusterResource(handle ResourceHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeDeleteClusterResource.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Delete deletes the offline resource, the handle still needs to be closed
+func (handle ResourceHandle) Delete() error {
+	return errors.Wrap(procnativeDeleteClusterResource.Name, "", deleteClusterResource(handle))
+}
+
+func changeClusterResourceGroup(handle ResourceHandle, group GroupHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeChangeClusterResourceGroup.Addr(), 2, uintptr(handle), uintptr(group), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// ChangeGroup moves the resource to group
+func (handle ResourceHandle) ChangeGroup(group GroupHandle) error {
+	return errors.Wrap(procnativeChangeClusterResourceGroup.Name, "", changeClusterResourceGroup(handle, group))
+}
+
+func getClusterResourceState(handle ResourceHandle) (state ClusterResourceState, nodeName string, groupName string, err error) {
+	nodeCCh := uint32(50)
+	groupCCh := uint32(50)
+
+	var nodeNameArr []uint16
+	var groupNameArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nodeCCh += 2
+		groupCCh += 2
+		nodeNameArr = make([]uint16, nodeCCh)
+		groupNameArr = make([]uint16, groupCCh)
+		r0, _, lastError := syscall.Syscall6(procnativeGetClusterResourceState.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&nodeNameArr[0])),
+			uintptr(unsafe.Pointer(&nodeCCh)),
+			uintptr(unsafe.Pointer(&groupNameArr[0])),
+			uintptr(unsafe.Pointer(&groupCCh)),
+			0)
+		state = ClusterResourceState(r0)
+		if state != ClusterResourceStateUnknown {
+			return 0
+		}
+		return lastError
+	})
+	if err != nil {
+		return
+	}
+	if state == ClusterResourceStateUnknown {
+		// the api failed without setting a last error
+		err = errors.ERROR_INVALID_STATE
+		return
+	}
+
+	nodeName = syscall.UTF16ToString(nodeNameArr)
+	groupName = syscall.UTF16ToString(groupNameArr)
+	return
+}
+
+// State returns the state of the resource and the node and group that own it,
+// ERROR_INVALID_STATE if the state is unknown without an error from the api
+func (handle ResourceHandle) State() (state ClusterResourceState, ownerNode string, groupName string, err error) {
+	state, ownerNode, groupName, err = getClusterResourceState(handle)
+	err = errors.Wrap(procnativeGetClusterResourceState.Name, "", err)
+	return
+}
+
+// WaitSettled polls the resource until it is no longer pending or ctx is done
+// and returns the last state and owner node, use it after Online or Offline
+// return ERROR_IO_PENDING
+func (handle ResourceHandle) WaitSettled(ctx context.Context) (ClusterResourceState, string, error) {
+	state, ownerNode, err := waitSettled(ctx, func() (ClusterResourceState, string, error) {
+		state, ownerNode, _, err := handle.State()
+		return state, ownerNode, err
+	}, ResourcePollInterval)
+	return state, ownerNode, errors.Wrap(procnativeGetClusterResourceState.Name, "", err)
+}
+
+func (handle ResourceHandle) control(code ControlCode, in []byte) ([]byte, error) {
+	return handle.Control(0, code, in)
+}
+
+// PrivateProperties returns the private properties of the resource, the
+// properties the resource DLL keeps under its Parameters key
+func (handle ResourceHandle) PrivateProperties() (clusprop.PropertyList, error) {
+	list, err := getProperties(handle.control, CLCTL_GET_PRIVATE_PROPERTIES)
+	return list, errors.Wrap(procnativeClusterResourceControl.Name, "", err)
+}
+
+// GetPrivateProperties reads the private properties into the struct pointed to by v,
+// see clusprop.Unmarshal for the struct tags
+func (handle ResourceHandle) GetPrivateProperties(v interface{}) error {
+	err := getPropertiesInto(handle.control, CLCTL_GET_PRIVATE_PROPERTIES, v)
+	return errors.Wrap(procnativeClusterResourceControl.Name, "", err)
+}
+
+// SetPrivateProperties validates and then sets the private properties in v, a
+// clusprop.PropertyList or a struct with clusprop tags. Unlike writing the
+// Parameters key directly the resource DLL checks the values and is told about
+// the change. errors.ERROR_RESOURCE_PROPERTIES_STORED means the properties
+// were saved but take effect the next time the resource comes online
+func (handle ResourceHandle) SetPrivateProperties(v interface{}) error {
+	err := setProperties(handle.control, CLCTL_VALIDATE_PRIVATE_PROPERTIES, CLCTL_SET_PRIVATE_PROPERTIES, v)
+	return errors.Wrap(procnativeClusterResourceControl.Name, "", err)
+}
+
+// CommonProperties returns the common properties of the resource such as
+// Description, RestartAction and PendingTimeout
+func (handle ResourceHandle) CommonProperties() (clusprop.PropertyList, error) {
+	list, err := getProperties(handle.control, CLCTL_GET_COMMON_PROPERTIES)
+	return list, errors.Wrap(procnativeClusterResourceControl.Name, "", err)
+}
+
+// GetCommonProperties reads the common properties into the struct pointed to by v
+func (handle ResourceHandle) GetCommonProperties(v interface{}) error {
+	err := getPropertiesInto(handle.control, CLCTL_GET_COMMON_PROPERTIES, v)
+	return errors.Wrap(procnativeClusterResourceControl.Name, "", err)
+}
+
+// SetCommonProperties validates and then sets the common properties in v,
+// a clusprop.PropertyList or a struct with clusprop tags
+func (handle ResourceHandle) SetCommonProperties(v interface{}) error {
+	err := setProperties(handle.control, CLCTL_VALIDATE_COMMON_PROPERTIES, CLCTL_SET_COMMON_PROPERTIES, v)
+	return errors.Wrap(procnativeClusterResourceControl.Name, "", err)
+}
+
+func addClusterResourceDependency(handle ResourceHandle, dependsOn ResourceHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeAddClusterResourceDependency.Addr(), 2, uintptr(handle), uintptr(dependsOn), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// AddDependency makes the resource depend on dependsOn, it is added
+// to the dependency expression with and
+func (handle ResourceHandle) AddDependency(dependsOn ResourceHandle) error {
+	return errors.Wrap(procnativeAddClusterResourceDependency.Name, "", addClusterResourceDependency(handle, dependsOn))
+}
+
+func removeClusterResourceDependency(handle ResourceHandle, dependsOn ResourceHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeRemoveClusterResourceDependency.Addr(), 2, uintptr(handle), uintptr(dependsOn), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// RemoveDependency removes dependsOn from the dependencies of the resource
+func (handle ResourceHandle) RemoveDependency(dependsOn ResourceHandle) error {
+	return errors.Wrap(procnativeRemoveClusterResourceDependency.Name, "", removeClusterResourceDependency(handle, dependsOn))
+}
+
+func getClusterResourceDependencyExpression(handle ResourceHandle) (expression string, err error) {
+	cch := uint32(100)
+	var buffer []uint16
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		cch += 2
+		buffer = make([]uint16, cch)
+		r0, _, _ := syscall.Syscall(procnativeGetClusterResourceDependencyExpression.Addr(),
+			3,
+			uintptr(handle),
+			uintptr(unsafe.Pointer(&buffer[0])),
+			uintptr(unsafe.Pointer(&cch)))
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+	expression = syscall.UTF16ToString(buffer)
+	return
+}
+
+// DependencyExpression returns the raw dependency expression of the resource
+func (handle ResourceHandle) DependencyExpression() (string, error) {
+	expression, err := getClusterResourceDependencyExpression(handle)
+	return expression, errors.Wrap(procnativeGetClusterResourceDependencyExpression.Name, "", err)
+}
+
+func setClusterResourceDependencyExpression(handle ResourceHandle, expression *uint16) error {
+	r0, _, _ := syscall.Syscall(procnativeSetClusterResourceDependencyExpression.Addr(), 2, uintptr(handle), uintptr(unsafe.Pointer(expression)), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// SetDependencyExpression replaces the dependencies of the resource with the raw expression
+func (handle ResourceHandle) SetDependencyExpression(expression string) error {
+	e, err := windows.UTF16PtrFromString(expression)
+	if err == nil {
+		err = setClusterResourceDependencyExpression(handle, e)
+	}
+	return errors.Wrap(procnativeSetClusterResourceDependencyExpression.Name, expression, err)
+}
+
+// Dependencies returns the parsed dependency expression of the resource,
+// nil when the resource has no dependencies
+func (handle ResourceHandle) Dependencies() (DependencyExpression, error) {
+	expression, err := handle.DependencyExpression()
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := ParseDependencyExpression(expression)
+	return dependencies, errors.Wrap(procnativeGetClusterResourceDependencyExpression.Name, expression, err)
+}
+
+// SetDependencies replaces the dependencies of the resource with expression,
+// nil removes every dependency
+func (handle ResourceHandle) SetDependencies(expression DependencyExpression) error {
+	return handle.SetDependencyExpression(FormatDependencyExpression(expression))
+}
+
+func clusterResourceOpenEnum(handle ResourceHandle, enumType ClusterResourceEnumType) (ResourceEnumHandle, error) {
+	r0, _, lastError := syscall.Syscall(procnativeClusterResourceOpenEnum.Addr(), 2, uintptr(handle), uintptr(enumType), 0)
+	enum := ResourceEnumHandle(r0)
+	return enum, handles.opened(resourceEnumHandleKind, r0, errors.NotNill(r0, lastError))
+}
+
+// OpenEnum opens an enumeration of the dependencies, dependents
+// or possible owner nodes of the resource
+func (handle ResourceHandle) OpenEnum(enumType ClusterResourceEnumType) (ResourceEnumHandle, error) {
+	enum, err := clusterResourceOpenEnum(handle, enumType)
+	return enum, errors.Wrap(procnativeClusterResourceOpenEnum.Name, "", err)
+}
+
+// Enumerate iterates the resource objects of enumType
+func (handle ResourceHandle) Enumerate(enumType ClusterResourceEnumType) (*ClusterIterator, error) {
+	enum, err := handle.OpenEnum(enumType)
+	if err != nil {
+		return nil, err
+	}
+	return newClusterIterator(enum), nil
+}
+
+// Count returns the number of objects in the enumeration
+func (handle ResourceEnumHandle) Count() uint32 {
+	r0, _, _ := syscall.Syscall(procnativeClusterResourceGetEnumCount.Addr(), 1, uintptr(handle), 0, 0)
+	return uint32(r0)
+}
+
+func clusterResourceEnum(handle ResourceEnumHandle, index uint32) (item ClusterEnumItem, err error) {
+	nameCCh := uint32(50)
+
+	var dwType uint32
+	var nameArr []uint16
+
+	err = retryMoreData(func() syscall.Errno {
+		// increase values to ensure not zero & space for extra nulls
+		nameCCh += 2
+		nameArr = make([]uint16, nameCCh)
+		r0, _, _ := syscall.Syscall6(procnativeClusterResourceEnum.Addr(),
+			5,
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&dwType)),
+			uintptr(unsafe.Pointer(&nameArr[0])),
+			uintptr(unsafe.Pointer(&nameCCh)),
+			0)
+		return syscall.Errno(r0)
+	})
+	if err != nil {
+		return
+	}
+
+	item.Type = ClusterResourceEnumType(dwType).ClusterEnumType()
+	item.ObjectEnumType = dwType
+	item.Name = syscall.UTF16ToString(nameArr[:nameCCh])
+	return
+}
+
+// Enum returns the object at index, Type is CLUSTER_ENUM_RESOURCE or CLUSTER_ENUM_NODE
+// and ObjectEnumType the ClusterResourceEnumType, returns ERROR_NO_MORE_ITEMS after the last object
+func (handle ResourceEnumHandle) Enum(index uint32) (ClusterEnumItem, error) {
+	item, err := clusterResourceEnum(handle, index)
+	return item, wrapEnumError(procnativeClusterResourceEnum.Name, err)
+}
+
+func (handle ResourceEnumHandle) item(index uint32) (ClusterEnumItem, error) {
+	return handle.Enum(index)
+}
+
+func clusterResourceCloseEnum(handle ResourceEnumHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeClusterResourceCloseEnum.Addr(), 1, uintptr(handle), 0, 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// Close closes the handle, it must not be closed twice
+func (handle ResourceEnumHandle) Close() error {
+	err := handles.closed(resourceEnumHandleKind, uintptr(handle), func() error { return clusterResourceCloseEnum(handle) })
+	return errors.Wrap(procnativeClusterResourceCloseEnum.Name, "", err)
+}
+
+func addClusterResourceNode(handle ResourceHandle, node NodeHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeAddClusterResourceNode.Addr(), 2, uintptr(handle), uintptr(node), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// AddPossibleOwner adds node to the nodes that can host the resource
+func (handle ResourceHandle) AddPossibleOwner(node NodeHandle) error {
+	return errors.Wrap(procnativeAddClusterResourceNode.Name, "", addClusterResourceNode(handle, node))
+}
+
+func removeClusterResourceNode(handle ResourceHandle, node NodeHandle) error {
+	r0, _, _ := syscall.Syscall(procnativeRemoveClusterResourceNode.Addr(), 2, uintptr(handle), uintptr(node), 0)
+	return errors.NotZero(syscall.Errno(r0))
+}
+
+// RemovePossibleOwner removes node from the nodes that can host the resource
+func (handle ResourceHandle) RemovePossibleOwner(node NodeHandle) error {
+	return errors.Wrap(procnativeRemoveClusterResourceNode.Name, "", removeClusterResourceNode(handle, node))
+}
+
+// PossibleOwners returns the names of the nodes that can host the resource
+func (handle ResourceHandle) PossibleOwners() ([]string, error) {
+	return itemNames(handle.Enumerate(CLUSTER_RESOURCE_ENUM_NODES))
+}
+
+// SyncPossibleOwners makes the possible owners of the resource the nodes in
+// desired, only the missing nodes are added and the extra nodes removed
+func (handle ResourceHandle) SyncPossibleOwners(cluster ClusterHandle, desired []string) error {
+	current, err := handle.PossibleOwners()
+	if err != nil {
+		return err
+	}
+	ownerFunc := func(change func(NodeHandle) error) func(string) error {
+		return func(name string) error {
+			node, err := cluster.OpenNode(name)
+			if err != nil {
+				return err
+			}
+			defer node.Close()
+			return change(node)
+		}
+	}
+	err = syncPossibleOwners(current, desired, ownerFunc(handle.AddPossibleOwner), ownerFunc(handle.RemovePossibleOwner))
+	return errors.Wrap(procnativeAddClusterResourceNode.Name, "", err)
+}
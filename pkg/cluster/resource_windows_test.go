@@ -0,0 +1,172 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceState(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	resource, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	defer resource.Close()
+
+	state, node, group, err := resource.State()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEqual(t, ClusterResourceStateUnknown, state)
+	assert.NotEmpty(t, node)
+	assert.NotEmpty(t, group)
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	existing, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	_, _, groupName, err := existing.State()
+	existing.Close()
+	assert.Nil(t, err, "error should be null")
+
+	group, err := cluster.OpenGroup(groupName)
+	assert.Nil(t, err, "error should be null")
+	defer group.Close()
+
+	resource, err := group.CreateResource("go-windows-lifecycle", "Generic Service", CLUSTER_RESOURCE_DEFAULT_MONITOR)
+	assert.Nil(t, err, "error should be null")
+	defer resource.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	state, _, _, err := resource.State()
+	assert.Nil(t, err, "error should be null")
+	assert.Equal(t, ClusterResourceOffline, state)
+
+	err = resource.Offline()
+	if errors.Is(err, errors.ERROR_IO_PENDING) {
+		state, _, err = resource.WaitSettled(ctx)
+		assert.Equal(t, ClusterResourceOffline, state)
+	}
+	assert.Nil(t, err, "error should be null")
+
+	assert.Nil(t, resource.Delete(), "error should be null")
+}
+
+func TestResourceCommonProperties(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	resource, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	defer resource.Close()
+
+	type commonProperties struct {
+		Description    string
+		PendingTimeout uint32
+	}
+	var original commonProperties
+	err = resource.GetCommonProperties(&original)
+	assert.Nil(t, err, "error should be null")
+	assert.NotZero(t, original.PendingTimeout)
+
+	err = resource.SetCommonProperties(struct{ Description string }{"go-windows"})
+	assert.Nil(t, err, "error should be null")
+	defer resource.SetCommonProperties(struct{ Description string }{original.Description})
+
+	list, err := resource.CommonProperties()
+	assert.Nil(t, err, "error should be null")
+	description, err := list.Str("Description")
+	assert.Nil(t, err, "error should be null")
+	assert.Equal(t, "go-windows", description)
+
+	_, err = resource.PrivateProperties()
+	assert.Nil(t, err, "error should be null")
+}
+
+func TestResourceDependencies(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	resource, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	defer resource.Close()
+
+	raw, err := resource.DependencyExpression()
+	assert.Nil(t, err, "error should be null")
+
+	expression, err := resource.Dependencies()
+	assert.Nil(t, err, "error should be null")
+	if raw == "" {
+		assert.Nil(t, expression)
+	} else {
+		assert.NotEmpty(t, expression.Resources())
+	}
+}
+
+func TestResourcePossibleOwners(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	resource, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	defer resource.Close()
+
+	owners, err := resource.PossibleOwners()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEmpty(t, owners)
+
+	// syncing to the current owners changes nothing
+	err = resource.SyncPossibleOwners(cluster, owners)
+	assert.Nil(t, err, "error should be null")
+	after, err := resource.PossibleOwners()
+	assert.Nil(t, err, "error should be null")
+	assert.ElementsMatch(t, owners, after)
+}
+
+func TestClusterDependencyGraph(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	graph, err := cluster.DependencyGraph()
+	assert.Nil(t, err, "error should be null")
+
+	_, ok := graph.Resource(validResourceName)
+	assert.True(t, ok)
+	order, err := graph.StartOrder()
+	assert.Nil(t, err, "error should be null")
+	assert.Len(t, order, len(graph.Resources()))
+}
+
+func TestResourceEnumerateAll(t *testing.T) {
+	cluster, err := OpenCluster()
+	assert.Nil(t, err, "error should be null")
+	defer cluster.Close()
+
+	resource, err := cluster.OpenResource(validResourceName)
+	assert.Nil(t, err, "error should be null")
+	defer resource.Close()
+
+	it, err := resource.Enumerate(CLUSTER_RESOURCE_ENUM_ALL)
+	assert.Nil(t, err, "error should be null")
+	items, err := it.All()
+	assert.Nil(t, err, "error should be null")
+	assert.NotEmpty(t, items, "a resource has possible owners")
+	for _, item := range items {
+		enumType := ClusterResourceEnumType(item.ObjectEnumType)
+		assert.Contains(t, []ClusterResourceEnumType{CLUSTER_RESOURCE_ENUM_DEPENDS, CLUSTER_RESOURCE_ENUM_PROVIDES, CLUSTER_RESOURCE_ENUM_NODES}, enumType)
+		assert.Equal(t, enumType.ClusterEnumType(), item.Type)
+	}
+}
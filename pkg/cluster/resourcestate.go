@@ -0,0 +1,110 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ClusterResourceState is CLUSTER_RESOURCE_STATE
+type ClusterResourceState int32
+
+const (
+	ClusterResourceStateUnknown   ClusterResourceState = -1
+	ClusterResourceInherited      ClusterResourceState = 0
+	ClusterResourceInitializing   ClusterResourceState = 1
+	ClusterResourceOnline         ClusterResourceState = 2
+	ClusterResourceOffline        ClusterResourceState = 3
+	ClusterResourceFailed         ClusterResourceState = 4
+	ClusterResourcePending        ClusterResourceState = 128
+	ClusterResourceOnlinePending  ClusterResourceState = 129
+	ClusterResourceOfflinePending ClusterResourceState = 130
+)
+
+// CreateResource flags
+const (
+	CLUSTER_RESOURCE_DEFAULT_MONITOR  uint32 = 0
+	CLUSTER_RESOURCE_SEPARATE_MONITOR uint32 = 1
+)
+
+// ClusterResourceEnumType selects what ResourceHandle.OpenEnum enumerates
+type ClusterResourceEnumType uint32
+
+const (
+	CLUSTER_RESOURCE_ENUM_DEPENDS  ClusterResourceEnumType = 0x00000001
+	CLUSTER_RESOURCE_ENUM_PROVIDES ClusterResourceEnumType = 0x00000002
+	CLUSTER_RESOURCE_ENUM_NODES    ClusterResourceEnumType = 0x00000004
+	CLUSTER_RESOURCE_ENUM_ALL      ClusterResourceEnumType = CLUSTER_RESOURCE_ENUM_DEPENDS | CLUSTER_RESOURCE_ENUM_PROVIDES | CLUSTER_RESOURCE_ENUM_NODES
+)
+
+// ClusterEnumType maps the resource enumeration type onto the cluster one
+// so resource enumerations return the same ClusterEnumItem, dependencies and
+// dependents are CLUSTER_ENUM_RESOURCE and possible owners CLUSTER_ENUM_NODE
+func (enumType ClusterResourceEnumType) ClusterEnumType() ClusterEnumType {
+	var mapped ClusterEnumType
+	if enumType&(CLUSTER_RESOURCE_ENUM_DEPENDS|CLUSTER_RESOURCE_ENUM_PROVIDES) != 0 {
+		mapped |= CLUSTER_ENUM_RESOURCE
+	}
+	if enumType&CLUSTER_RESOURCE_ENUM_NODES != 0 {
+		mapped |= CLUSTER_ENUM_NODE
+	}
+	return mapped
+}
+
+var clusterResourceStateNames = map[ClusterResourceState]string{
+	ClusterResourceStateUnknown:   "ClusterResourceStateUnknown",
+	ClusterResourceInherited:      "ClusterResourceInherited",
+	ClusterResourceInitializing:   "ClusterResourceInitializing",
+	ClusterResourceOnline:         "ClusterResourceOnline",
+	ClusterResourceOffline:        "ClusterResourceOffline",
+	ClusterResourceFailed:         "ClusterResourceFailed",
+	ClusterResourcePending:        "ClusterResourcePending",
+	ClusterResourceOnlinePending:  "ClusterResourceOnlinePending",
+	ClusterResourceOfflinePending: "ClusterResourceOfflinePending",
+}
+
+func (state ClusterResourceState) String() string {
+	if name, ok := clusterResourceStateNames[state]; ok {
+		return name
+	}
+	return fmt.Sprintf("ClusterResourceState(%d)", int32(state))
+}
+
+// Settled is false while the resource is initializing or pending
+func (state ClusterResourceState) Settled() bool {
+	return state != ClusterResourceInitializing && state < ClusterResourcePending
+}
+
+// ResourcePollInterval is how often WaitSettled queries the resource or group state
+var ResourcePollInterval = 250 * time.Millisecond
+
+// resourceStateFunc returns the state and owner node of a resource
+type resourceStateFunc func() (state ClusterResourceState, ownerNode string, err error)
+
+// waitSettled polls getState until the state is settled or ctx is done
+func waitSettled(ctx context.Context, getState resourceStateFunc, interval time.Duration) (state ClusterResourceState, ownerNode string, err error) {
+	err = pollUntil(ctx, interval, func() (bool, error) {
+		var stateErr error
+		state, ownerNode, stateErr = getState()
+		return state.Settled(), stateErr
+	})
+	return
+}
+
+// pollUntil calls check every interval until it returns true,
+// an error or ctx is done
+func pollUntil(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// stateSequence returns each state in turn and then repeats the last one
+func stateSequence(states ...ClusterResourceState) (resourceStateFunc, *int) {
+	calls := 0
+	return func() (ClusterResourceState, string, error) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		return state, "node1", nil
+	}, &calls
+}
+
+func TestClusterResourceState(t *testing.T) {
+	assert.Equal(t, "ClusterResourceOnlinePending", ClusterResourceOnlinePending.String())
+	assert.Equal(t, "ClusterResourceState(7)", ClusterResourceState(7).String())
+
+	assert.True(t, ClusterResourceOnline.Settled())
+	assert.True(t, ClusterResourceFailed.Settled())
+	assert.False(t, ClusterResourceInitializing.Settled())
+	assert.False(t, ClusterResourcePending.Settled())
+	assert.False(t, ClusterResourceOfflinePending.Settled())
+}
+
+func TestWaitSettled(t *testing.T) {
+	getState, calls := stateSequence(ClusterResourceOnlinePending, ClusterResourceOnlinePending, ClusterResourceOnline)
+	state, node, err := waitSettled(context.Background(), getState, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, ClusterResourceOnline, state)
+	assert.Equal(t, "node1", node)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestWaitSettledContext(t *testing.T) {
+	getState, _ := stateSequence(ClusterResourceOfflinePending)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	state, _, err := waitSettled(ctx, getState, time.Millisecond)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, ClusterResourceOfflinePending, state)
+}
+
+func TestWaitSettledError(t *testing.T) {
+	state, _, err := waitSettled(context.Background(), func() (ClusterResourceState, string, error) {
+		return ClusterResourceStateUnknown, "", errors.ERROR_RESOURCE_NOT_FOUND
+	}, time.Millisecond)
+	assert.Equal(t, errors.ERROR_RESOURCE_NOT_FOUND, err)
+	assert.Equal(t, ClusterResourceStateUnknown, state)
+}
+
+func TestClusterResourceEnumType(t *testing.T) {
+	assert.Equal(t, CLUSTER_ENUM_RESOURCE, CLUSTER_RESOURCE_ENUM_DEPENDS.ClusterEnumType())
+	assert.Equal(t, CLUSTER_ENUM_RESOURCE, CLUSTER_RESOURCE_ENUM_PROVIDES.ClusterEnumType())
+	assert.Equal(t, CLUSTER_ENUM_NODE, CLUSTER_RESOURCE_ENUM_NODES.ClusterEnumType())
+	assert.Equal(t, CLUSTER_ENUM_NODE|CLUSTER_ENUM_RESOURCE, CLUSTER_RESOURCE_ENUM_ALL.ClusterEnumType())
+}
@@ -0,0 +1,147 @@
+package cluster
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+)
+
+// safeHandle closes a handle at most once. A safeHandle that becomes
+// unreachable while open is closed by its finalizer and reported as a leak
+type safeHandle struct {
+	mu      sync.Mutex
+	tracker *handleTracker
+	kind    string
+	value   uintptr
+	closed  bool
+	close   func() error
+}
+
+// newSafeHandle wraps value, close must not refer to the returned safeHandle
+// or the finalizer never runs
+func newSafeHandle(tracker *handleTracker, kind string, value uintptr, close func() error) *safeHandle {
+	safe := &safeHandle{tracker: tracker, kind: kind, value: value, close: close}
+	runtime.SetFinalizer(safe, (*safeHandle).finalize)
+	return safe
+}
+
+// get returns the handle, ERROR_INVALID_HANDLE once it is closed
+func (safe *safeHandle) get() (uintptr, error) {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	if safe.closed {
+		return 0, fmt.Errorf("%s 0x%x is closed: %w", safe.kind, safe.value, errors.ERROR_INVALID_HANDLE)
+	}
+	return safe.value, nil
+}
+
+// closeOnce closes the handle, closing it again returns ERROR_INVALID_HANDLE
+// without calling close
+func (safe *safeHandle) closeOnce() error {
+	safe.mu.Lock()
+	defer safe.mu.Unlock()
+	if safe.closed {
+		return fmt.Errorf("%s 0x%x is already closed: %w", safe.kind, safe.value, errors.ERROR_INVALID_HANDLE)
+	}
+	safe.closed = true
+	runtime.SetFinalizer(safe, nil)
+	return safe.close()
+}
+
+// finalize closes a handle that leaked and reports it, the stack is
+// looked up first as closing forgets it
+func (safe *safeHandle) finalize() {
+	leak := HandleLeak{Kind: safe.kind, Handle: safe.value, Stack: safe.tracker.stack(safe.kind, safe.value)}
+	_ = safe.closeOnce()
+	safe.tracker.reportLeak(leak)
+}
+
+// SafeClusterHandle is a ClusterHandle that is closed at most once,
+// the garbage collector closes it when it leaks
+type SafeClusterHandle struct {
+	safe *safeHandle
+}
+
+// Safe wraps handle, close the SafeClusterHandle instead of handle
+func (handle ClusterHandle) Safe() *SafeClusterHandle {
+	return &SafeClusterHandle{newSafeHandle(handles, clusterHandleKind, uintptr(handle), handle.Close)}
+}
+
+// Handle returns the wrapped handle, ERROR_INVALID_HANDLE once it is closed
+func (handle *SafeClusterHandle) Handle() (ClusterHandle, error) {
+	value, err := handle.safe.get()
+	return ClusterHandle(value), err
+}
+
+// Close closes the handle, closing it again returns ERROR_INVALID_HANDLE
+func (handle *SafeClusterHandle) Close() error {
+	return handle.safe.closeOnce()
+}
+
+// SafeResourceHandle is a ResourceHandle that is closed at most once,
+// the garbage collector closes it when it leaks
+type SafeResourceHandle struct {
+	safe *safeHandle
+}
+
+// Safe wraps handle, close the SafeResourceHandle instead of handle
+func (handle ResourceHandle) Safe() *SafeResourceHandle {
+	return &SafeResourceHandle{newSafeHandle(handles, resourceHandleKind, uintptr(handle), handle.Close)}
+}
+
+// Handle returns the wrapped handle, ERROR_INVALID_HANDLE once it is closed
+func (handle *SafeResourceHandle) Handle() (ResourceHandle, error) {
+	value, err := handle.safe.get()
+	return ResourceHandle(value), err
+}
+
+// Close closes the handle, closing it again returns ERROR_INVALID_HANDLE
+func (handle *SafeResourceHandle) Close() error {
+	return handle.safe.closeOnce()
+}
+
+// SafeKeyHandle is a KeyHandle that is closed at most once,
+// the garbage collector closes it when it leaks
+type SafeKeyHandle struct {
+	safe *safeHandle
+}
+
+// Safe wraps handle, close the SafeKeyHandle instead of handle
+func (handle KeyHandle) Safe() *SafeKeyHandle {
+	return &SafeKeyHandle{newSafeHandle(handles, keyHandleKind, uintptr(handle), handle.Close)}
+}
+
+// Handle returns the wrapped handle, ERROR_INVALID_HANDLE once it is closed
+func (handle *SafeKeyHandle) Handle() (KeyHandle, error) {
+	value, err := handle.safe.get()
+	return KeyHandle(value), err
+}
+
+// Close closes the handle, closing it again returns ERROR_INVALID_HANDLE
+func (handle *SafeKeyHandle) Close() error {
+	return handle.safe.closeOnce()
+}
+
+// SafeCryptProvider is a HCLUSCRYPTPROVIDER that is closed at most once,
+// the garbage collector closes it when it leaks
+type SafeCryptProvider struct {
+	safe *safeHandle
+}
+
+// Safe wraps handle, close the SafeCryptProvider instead of handle
+func (handle HCLUSCRYPTPROVIDER) Safe() *SafeCryptProvider {
+	return &SafeCryptProvider{newSafeHandle(handles, cryptProviderHandleKind, uintptr(handle), handle.Close)}
+}
+
+// Handle returns the wrapped provider, ERROR_INVALID_HANDLE once it is closed
+func (handle *SafeCryptProvider) Handle() (HCLUSCRYPTPROVIDER, error) {
+	value, err := handle.safe.get()
+	return HCLUSCRYPTPROVIDER(value), err
+}
+
+// Close closes the provider, closing it again returns ERROR_INVALID_HANDLE
+func (handle *SafeCryptProvider) Close() error {
+	return handle.safe.closeOnce()
+}
@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeHandleClose(t *testing.T) {
+	tracker := newHandleTracker()
+	assert.Nil(t, tracker.opened(clusterHandleKind, 0x10, nil))
+
+	calls := 0
+	safe := newSafeHandle(tracker, clusterHandleKind, 0x10, func() error {
+		calls++
+		return tracker.closed(clusterHandleKind, 0x10, func() error { return nil })
+	})
+	value, err := safe.get()
+	assert.Nil(t, err)
+	assert.Equal(t, uintptr(0x10), value)
+
+	assert.Nil(t, safe.closeOnce())
+	err = safe.closeOnce()
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_HANDLE), err)
+	assert.Equal(t, "ClusterHandle 0x10 is already closed: "+errors.ERROR_INVALID_HANDLE.Error(), err.Error())
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, tracker.leaks())
+
+	_, err = safe.get()
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_HANDLE), err)
+}
+
+func TestSafeHandleCloseError(t *testing.T) {
+	safe := newSafeHandle(newHandleTracker(), keyHandleKind, 0x20, func() error { return errors.ERROR_ACCESS_DENIED })
+	assert.Equal(t, errors.ERROR_ACCESS_DENIED, safe.closeOnce())
+	assert.True(t, errors.Is(safe.closeOnce(), errors.ERROR_INVALID_HANDLE))
+}
+
+// leakSafeHandle opens a safe handle and drops it
+func leakSafeHandle(tracker *handleTracker, closed chan<- uintptr) {
+	_ = tracker.opened(resourceHandleKind, 0x30, nil)
+	newSafeHandle(tracker, resourceHandleKind, 0x30, func() error {
+		closed <- 0x30
+		return tracker.closed(resourceHandleKind, 0x30, func() error { return nil })
+	})
+}
+
+func TestSafeHandleFinalizer(t *testing.T) {
+	tracker := newHandleTracker()
+	tracker.setDebug(true)
+	leaks := make(chan HandleLeak, 1)
+	tracker.leaked = func(leak HandleLeak) { leaks <- leak }
+	closed := make(chan uintptr, 1)
+	leakSafeHandle(tracker, closed)
+
+	deadline := time.After(10 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case leak := <-leaks:
+			assert.Equal(t, uintptr(0x30), <-closed)
+			assert.Equal(t, resourceHandleKind, leak.Kind)
+			assert.Equal(t, uintptr(0x30), leak.Handle)
+			assert.True(t, strings.Contains(leak.Stack, "leakSafeHandle"), leak.Stack)
+			assert.Empty(t, tracker.leaks())
+			return
+		case <-deadline:
+			t.Fatal("the finalizer did not close the leaked handle")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestSafeHandleFinalizerNoDebug(t *testing.T) {
+	tracker := newHandleTracker()
+	tracker.leaked = func(leak HandleLeak) { t.Errorf("reported %s without handle debugging", leak) }
+	closed := make(chan uintptr, 1)
+	leakSafeHandle(tracker, closed)
+
+	deadline := time.After(10 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case value := <-closed:
+			assert.Equal(t, uintptr(0x30), value)
+			return
+		case <-deadline:
+			t.Fatal("the finalizer did not close the leaked handle")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
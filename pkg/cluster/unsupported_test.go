@@ -0,0 +1,120 @@
+//go:build !windows
+// +build !windows
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportedFuncs returns the signatures of the exported functions and methods
+// of the files matching pattern, keyed by receiver type and name
+func exportedFuncs(t *testing.T, pattern string) map[string]string {
+	paths, err := filepath.Glob(pattern)
+	assert.Nil(t, err)
+
+	fset := token.NewFileSet()
+	funcs := map[string]string{}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() {
+				continue
+			}
+			name := fn.Name.Name
+			if fn.Recv != nil {
+				var recv bytes.Buffer
+				printer.Fprint(&recv, fset, fn.Recv.List[0].Type)
+				name = recv.String() + "." + name
+			}
+			var signature bytes.Buffer
+			printer.Fprint(&signature, fset, fn.Type)
+			funcs[name] = signature.String()
+		}
+	}
+	return funcs
+}
+
+// TestUnsupportedMatchesWindows checks every exported function of the Windows
+// files has a stub with the same signature on the other platforms
+func TestUnsupportedMatchesWindows(t *testing.T) {
+	for _, dir := range []string{".", "../kernel32", "../ntdll"} {
+		t.Run(dir, func(t *testing.T) {
+			windows := exportedFuncs(t, filepath.Join(dir, "*_windows.go"))
+			unsupported := exportedFuncs(t, filepath.Join(dir, "*_unsupported.go"))
+			assert.NotEmpty(t, windows)
+			assert.Equal(t, windows, unsupported)
+		})
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	_, err := OpenCluster()
+	assert.Equal(t, errors.ErrNotSupported, err)
+
+	_, err = NativeBackend.OpenRemoteCluster("cluster1")
+	assert.Equal(t, errors.ErrNotSupported, err)
+
+	_, err = OpenClusterCryptProvider("R1", MS_ENH_RSA_AES_PROV, PROV_RSA_AES, CLUS_CREATE_CRYPT_NONE)
+	assert.Equal(t, errors.ErrNotSupported, err)
+
+	_, err = OpenClusterCryptProviderEx(WithCryptResource("R1"), WithCryptKeyName("tenant1"))
+	assert.Equal(t, errors.ErrNotSupported, err)
+
+	_, err = EnumCryptProviders()
+	assert.Equal(t, errors.ErrNotSupported, err)
+
+	_, err = KeyHandle(0).QueryStringValue("Name")
+	assert.Equal(t, errors.ErrNotSupported, err)
+
+	_, err = OpenClient(context.Background(), NativeBackend, RetryPolicy{MaxAttempts: 1})
+	assert.Equal(t, errors.ErrNotSupported, err)
+}
+
+func TestSafeWrappers(t *testing.T) {
+	cluster := ClusterHandle(0x10).Safe()
+	handle, err := cluster.Handle()
+	assert.Nil(t, err)
+	assert.Equal(t, ClusterHandle(0x10), handle)
+
+	resource := ResourceHandle(0x20).Safe()
+	resourceHandle, err := resource.Handle()
+	assert.Nil(t, err)
+	assert.Equal(t, ResourceHandle(0x20), resourceHandle)
+
+	key := KeyHandle(0x30).Safe()
+	keyHandle, err := key.Handle()
+	assert.Nil(t, err)
+	assert.Equal(t, KeyHandle(0x30), keyHandle)
+
+	provider := HCLUSCRYPTPROVIDER(0x40).Safe()
+	providerHandle, err := provider.Handle()
+	assert.Nil(t, err)
+	assert.Equal(t, HCLUSCRYPTPROVIDER(0x40), providerHandle)
+
+	// the wrappers close the plain handle once
+	for _, closer := range []interface{ Close() error }{cluster, resource, key, provider} {
+		assert.Equal(t, errors.ErrNotSupported, closer.Close())
+		assert.True(t, errors.Is(closer.Close(), errors.ERROR_INVALID_HANDLE))
+	}
+	_, err = cluster.Handle()
+	assert.True(t, errors.Is(err, errors.ERROR_INVALID_HANDLE))
+}
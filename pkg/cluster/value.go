@@ -0,0 +1,68 @@
+package cluster
+
+import (
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/KnicKnic/go-windows/pkg/util"
+)
+
+// Registry value types, these match the REG_* values
+// the syscall package only defines on windows
+const (
+	REG_NONE                       uint32 = 0
+	REG_SZ                         uint32 = 1
+	REG_EXPAND_SZ                  uint32 = 2
+	REG_BINARY                     uint32 = 3
+	REG_DWORD                      uint32 = 4
+	REG_DWORD_BIG_ENDIAN           uint32 = 5
+	REG_LINK                       uint32 = 6
+	REG_MULTI_SZ                   uint32 = 7
+	REG_RESOURCE_LIST              uint32 = 8
+	REG_FULL_RESOURCE_DESCRIPTOR   uint32 = 9
+	REG_RESOURCE_REQUIREMENTS_LIST uint32 = 10
+	REG_QWORD                      uint32 = 11
+)
+
+// Registry key access rights for samDesired, these match the KEY_* values
+// the syscall package only defines on windows
+const (
+	KEY_QUERY_VALUE        = 0x0001
+	KEY_SET_VALUE          = 0x0002
+	KEY_CREATE_SUB_KEY     = 0x0004
+	KEY_ENUMERATE_SUB_KEYS = 0x0008
+	KEY_NOTIFY             = 0x0010
+	KEY_READ               = 0x20019
+	KEY_WRITE              = 0x20006
+	KEY_ALL_ACCESS         = 0xf003f
+)
+
+// decodeString decodes REG_SZ and REG_EXPAND_SZ data
+func decodeString(dwType uint32, data []byte) (string, error) {
+	if dwType != REG_SZ && dwType != REG_EXPAND_SZ {
+		return "", errors.ERROR_INVALID_DATA
+	}
+	return util.UTF16BytesToString(data), nil
+}
+
+// decodeStrings decodes REG_MULTI_SZ data
+func decodeStrings(dwType uint32, data []byte) ([]string, error) {
+	if dwType != REG_MULTI_SZ {
+		return nil, errors.ERROR_INVALID_DATA
+	}
+	return util.UTF16BytesToStrings(data), nil
+}
+
+// decodeDWord decodes REG_DWORD data
+func decodeDWord(dwType uint32, data []byte) (uint32, error) {
+	if dwType != REG_DWORD || len(data) != 4 {
+		return 0, errors.ERROR_INVALID_DATA
+	}
+	return util.ByteToUint32(data), nil
+}
+
+// decodeQWord decodes REG_QWORD data
+func decodeQWord(dwType uint32, data []byte) (uint64, error) {
+	if dwType != REG_QWORD || len(data) != 8 {
+		return 0, errors.ERROR_INVALID_DATA
+	}
+	return util.ByteToUint64(data), nil
+}
@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeValues(t *testing.T) {
+	str, err := decodeString(REG_SZ, []byte{'a', 0, 'b', 0})
+	assert.Nil(t, err)
+	assert.Equal(t, "ab", str)
+
+	str, err = decodeString(REG_EXPAND_SZ, []byte{'%', 0, 0, 0})
+	assert.Nil(t, err)
+	assert.Equal(t, "%", str)
+
+	_, err = decodeString(REG_BINARY, []byte{'a', 0})
+	assert.Equal(t, errors.ERROR_INVALID_DATA, err)
+
+	strs, err := decodeStrings(REG_MULTI_SZ, []byte{'a', 0, 0, 0, 'b', 0, 0, 0, 0, 0})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, strs)
+
+	_, err = decodeStrings(REG_SZ, []byte{'a', 0})
+	assert.Equal(t, errors.ERROR_INVALID_DATA, err)
+
+	dword, err := decodeDWord(REG_DWORD, []byte{1, 2, 0, 0})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0x201), dword)
+
+	_, err = decodeDWord(REG_DWORD, []byte{1, 2, 0})
+	assert.Equal(t, errors.ERROR_INVALID_DATA, err)
+	_, err = decodeDWord(REG_QWORD, []byte{1, 2, 0, 0})
+	assert.Equal(t, errors.ERROR_INVALID_DATA, err)
+
+	qword, err := decodeQWord(REG_QWORD, []byte{1, 0, 0, 0, 0, 0, 0, 1})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0x0100000000000001), qword)
+
+	_, err = decodeQWord(REG_DWORD, []byte{1, 0, 0, 0})
+	assert.Equal(t, errors.ERROR_INVALID_DATA, err)
+}
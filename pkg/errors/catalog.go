@@ -0,0 +1,112 @@
+package errors
+
+import (
+	goerrors "errors"
+	"strconv"
+	"syscall"
+)
+
+//go:generate go run ./internal/gencodes -in codes.txt -out zcodes.go
+
+// Category groups the error codes by how a caller reacts to them,
+// retrying, creating the object, fixing permissions or changing its state
+type Category int
+
+const (
+	// CategoryNone is a code in none of the categories or not in the catalog
+	CategoryNone Category = iota
+	// CategoryTransient is a failure that can succeed when retried later,
+	// such as an unreachable node or a group that is moving
+	CategoryTransient
+	// CategoryNotFound is a missing object, such as an unknown resource or node
+	CategoryNotFound
+	// CategoryAccess is a failure of permissions or credentials
+	CategoryAccess
+	// CategoryStateConflict is an operation the object's state does not allow,
+	// such as an object that already exists or a resource that is online
+	CategoryStateConflict
+)
+
+var categoryNames = [...]string{
+	CategoryNone:          "none",
+	CategoryTransient:     "transient",
+	CategoryNotFound:      "not-found",
+	CategoryAccess:        "access",
+	CategoryStateConflict: "state-conflict",
+}
+
+func (category Category) String() string {
+	if category < 0 || int(category) >= len(categoryNames) {
+		return "Category(" + strconv.Itoa(int(category)) + ")"
+	}
+	return categoryNames[category]
+}
+
+// catalogEntry is a code of codes.txt, err is its preallocated boxed value
+type catalogEntry struct {
+	code     syscall.Errno
+	name     string
+	category Category
+	err      error
+}
+
+var catalogByCode = make(map[syscall.Errno]*catalogEntry, len(catalog))
+
+func init() {
+	for i := range catalog {
+		catalogByCode[catalog[i].code] = &catalog[i]
+	}
+}
+
+// lookup returns the catalog entry of the syscall.Errno in err's chain
+func lookup(err error) (*catalogEntry, bool) {
+	var code syscall.Errno
+	if err == nil || !goerrors.As(err, &code) {
+		return nil, false
+	}
+	entry, ok := catalogByCode[code]
+	return entry, ok
+}
+
+// Name returns the winerror.h name of the code err holds, such as
+// ERROR_CLUSTER_NODE_NOT_FOUND. It is empty when err holds no syscall.Errno
+// or the code is not in the catalog
+func Name(err error) string {
+	if entry, ok := lookup(err); ok {
+		return entry.name
+	}
+	return ""
+}
+
+// CategoryOf returns the category of the code err holds,
+// CategoryNone when err holds no syscall.Errno or the code is not in the catalog
+func CategoryOf(err error) Category {
+	if entry, ok := lookup(err); ok {
+		return entry.category
+	}
+	return CategoryNone
+}
+
+// IsTransient reports whether err can succeed when retried later,
+// such as RPC_S_SERVER_UNAVAILABLE or ERROR_CLUSTER_NODE_DOWN
+func IsTransient(err error) bool {
+	return CategoryOf(err) == CategoryTransient
+}
+
+// IsNotFound reports whether err is a missing object,
+// such as ERROR_RESOURCE_NOT_FOUND or ERROR_CLUSTER_NODE_NOT_FOUND
+func IsNotFound(err error) bool {
+	return CategoryOf(err) == CategoryNotFound
+}
+
+// IsAccessDenied reports whether err is a failure of permissions or credentials,
+// such as ERROR_ACCESS_DENIED
+func IsAccessDenied(err error) bool {
+	return CategoryOf(err) == CategoryAccess
+}
+
+// IsStateConflict reports whether the object's state does not allow the operation,
+// such as ERROR_ALREADY_EXISTS or ERROR_RESOURCE_ONLINE
+func IsStateConflict(err error) bool {
+	return CategoryOf(err) == CategoryStateConflict
+}
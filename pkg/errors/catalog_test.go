@@ -0,0 +1,137 @@
+package errors
+
+import (
+	"bufio"
+	goerrors "errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCatalogMatchesTable fails when zcodes.go was not regenerated after codes.txt changed
+func TestCatalogMatchesTable(t *testing.T) {
+	file, err := os.Open("codes.txt")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer file.Close()
+
+	rows := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		rows++
+		value, err := strconv.ParseUint(fields[0], 10, 32)
+		assert.Nil(t, err)
+		entry, ok := catalogByCode[syscall.Errno(value)]
+		if !assert.True(t, ok, fields[1]) {
+			continue
+		}
+		category := fields[2]
+		if category == "-" {
+			category = CategoryNone.String()
+		}
+		assert.Equal(t, fields[1], entry.name)
+		assert.Equal(t, category, entry.category.String(), fields[1])
+		assert.Equal(t, syscall.Errno(value), entry.err)
+	}
+	assert.Nil(t, scanner.Err())
+	assert.Equal(t, rows, len(catalog))
+	assert.Equal(t, rows, len(catalogByCode))
+}
+
+func TestErrnoErrPreallocated(t *testing.T) {
+	assert.Nil(t, errnoErr(0))
+	assert.Equal(t, syscall.Errno(4242), errnoErr(4242))
+
+	var err error
+	allocs := testing.AllocsPerRun(100, func() {
+		err = errnoErr(5042)
+	})
+	assert.Equal(t, float64(0), allocs)
+	assert.Equal(t, ERROR_CLUSTER_NODE_NOT_FOUND, err)
+}
+
+func TestCodeValues(t *testing.T) {
+	assert.Equal(t, syscall.Errno(11), ERROR_BAD_FORMAT)
+	assert.Equal(t, syscall.Errno(13), ERROR_INVALID_DATA)
+	assert.Equal(t, syscall.Errno(1722), RPC_S_SERVER_UNAVAILABLE)
+	assert.Equal(t, syscall.Errno(1753), EPT_S_NOT_REGISTERED)
+	assert.Equal(t, syscall.Errno(5999), ERROR_CLUSTERSET_MANAGEMENT_CLUSTER_UNREACHABLE)
+}
+
+func TestCategoryOf(t *testing.T) {
+	tests := []struct {
+		err      error
+		category Category
+	}{
+		{RPC_S_SERVER_UNAVAILABLE, CategoryTransient},
+		{EPT_S_NOT_REGISTERED, CategoryTransient},
+		{WAIT_TIMEOUT, CategoryTransient},
+		{ERROR_CLUSTER_NODE_DOWN, CategoryTransient},
+		{ERROR_CLUSTER_GROUP_MOVING, CategoryTransient},
+		{ERROR_FILE_NOT_FOUND, CategoryNotFound},
+		{ERROR_RESOURCE_NOT_FOUND, CategoryNotFound},
+		{ERROR_CLUSTER_NODE_NOT_FOUND, CategoryNotFound},
+		{ERROR_ACCESS_DENIED, CategoryAccess},
+		{RPC_S_PROXY_ACCESS_DENIED, CategoryAccess},
+		{ERROR_ALREADY_EXISTS, CategoryStateConflict},
+		{ERROR_RESOURCE_ONLINE, CategoryStateConflict},
+		{ERROR_INVALID_STATE, CategoryStateConflict},
+		{ERROR_INVALID_PARAMETER, CategoryNone},
+		{ERROR_MORE_DATA, CategoryNone},
+		{syscall.Errno(4242), CategoryNone},
+		{goerrors.New("not an errno"), CategoryNone},
+		{nil, CategoryNone},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.err), func(t *testing.T) {
+			assert.Equal(t, test.category, CategoryOf(test.err))
+		})
+	}
+}
+
+func TestClassifyWrapped(t *testing.T) {
+	err := fmt.Errorf("move group: %w", Wrap("OpenClusterNode", "node1", ERROR_CLUSTER_NODE_NOT_FOUND))
+
+	assert.Equal(t, "ERROR_CLUSTER_NODE_NOT_FOUND", Name(err))
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsTransient(err))
+	assert.False(t, IsAccessDenied(err))
+	assert.False(t, IsStateConflict(err))
+
+	assert.True(t, IsTransient(Wrap("OpenCluster", "cluster1", RPC_S_SERVER_UNAVAILABLE)))
+	assert.True(t, IsAccessDenied(Wrap("OpenCluster", "cluster1", ERROR_ACCESS_DENIED)))
+	assert.True(t, IsStateConflict(Wrap("DeleteClusterResource", "R1", ERROR_RESOURCE_ONLINE)))
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "WAIT_TIMEOUT", Name(WAIT_TIMEOUT))
+	assert.Equal(t, "ERROR_INVALID_DATA", Name(syscall.Errno(13)))
+	assert.Equal(t, "", Name(syscall.Errno(4242)))
+	assert.Equal(t, "", Name(goerrors.New("not an errno")))
+	assert.Equal(t, "", Name(nil))
+}
+
+func TestCategoryString(t *testing.T) {
+	assert.Equal(t, "none", CategoryNone.String())
+	assert.Equal(t, "transient", CategoryTransient.String())
+	assert.Equal(t, "not-found", CategoryNotFound.String())
+	assert.Equal(t, "access", CategoryAccess.String())
+	assert.Equal(t, "state-conflict", CategoryStateConflict.String())
+	assert.Equal(t, "Category(9)", Category(9).String())
+}
+
+func TestClusterErrorUnknownCode(t *testing.T) {
+	err := Wrap("OpenClusterNode", "node1", syscall.Errno(4242))
+
+	assert.True(t, strings.HasSuffix(err.Error(), " (4242)"))
+}
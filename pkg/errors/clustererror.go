@@ -0,0 +1,70 @@
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+	"syscall"
+)
+
+// ClusterError is a failed cluster api call, it unwraps to the error the call
+// returned so errors.Is works against the sentinels such as
+// ERROR_CLUSTER_NODE_NOT_FOUND and errors.As returns the ClusterError
+type ClusterError struct {
+	// API is the function that failed, such as OpenClusterResource
+	API string
+	// Object is the resource, group, node, key or value name the call was made with,
+	// it is empty when the call was made on a handle
+	Object string
+	// Code is the Win32 error code, 0 when Err is not a syscall.Errno,
+	// the message names it when it is in the catalog
+	Code syscall.Errno
+	// Err is the error returned by the call
+	Err error
+}
+
+func (err *ClusterError) Error() string {
+	code := ""
+	if name := Name(err.Code); name != "" {
+		code = fmt.Sprintf(" (%s %d)", name, uint32(err.Code))
+	} else if err.Code != 0 {
+		code = fmt.Sprintf(" (%d)", uint32(err.Code))
+	}
+	if err.Object == "" {
+		return fmt.Sprintf("%s: %v%s", err.API, err.Err, code)
+	}
+	return fmt.Sprintf("%s %q: %v%s", err.API, err.Object, err.Err, code)
+}
+
+func (err *ClusterError) Unwrap() error {
+	return err.Err
+}
+
+// Wrap returns err as a ClusterError of api and object, nil when err is nil.
+// An err that already holds a ClusterError is returned as is,
+// it names the call that failed
+func Wrap(api string, object string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var clusterErr *ClusterError
+	if goerrors.As(err, &clusterErr) {
+		return err
+	}
+	wrapped := &ClusterError{API: api, Object: object, Err: err}
+	var code syscall.Errno
+	if goerrors.As(err, &code) {
+		wrapped.Code = code
+	}
+	return wrapped
+}
+
+// Is is errors.Is from the standard library, so callers that import this
+// package can match the sentinels without a second errors import
+func Is(err error, target error) bool {
+	return goerrors.Is(err, target)
+}
+
+// As is errors.As from the standard library
+func As(err error, target interface{}) bool {
+	return goerrors.As(err, target)
+}
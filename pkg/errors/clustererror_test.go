@@ -0,0 +1,67 @@
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapNil(t *testing.T) {
+	assert.Nil(t, Wrap("OpenClusterResource", "R1", nil))
+}
+
+func TestWrapErrno(t *testing.T) {
+	err := Wrap("OpenClusterNode", "node1", ERROR_CLUSTER_NODE_NOT_FOUND)
+
+	assert.True(t, Is(err, ERROR_CLUSTER_NODE_NOT_FOUND))
+	assert.True(t, goerrors.Is(err, syscall.Errno(5042)))
+	assert.False(t, Is(err, ERROR_CLUSTER_NODE_DOWN))
+
+	var clusterErr *ClusterError
+	assert.True(t, As(err, &clusterErr))
+	assert.Equal(t, "OpenClusterNode", clusterErr.API)
+	assert.Equal(t, "node1", clusterErr.Object)
+	assert.Equal(t, syscall.Errno(5042), clusterErr.Code)
+	assert.True(t, strings.HasPrefix(err.Error(), `OpenClusterNode "node1": `))
+	assert.True(t, strings.HasSuffix(err.Error(), " (ERROR_CLUSTER_NODE_NOT_FOUND 5042)"))
+}
+
+func TestWrapWrappedErrno(t *testing.T) {
+	err := Wrap("CloseCluster", "", fmt.Errorf("ClusterHandle 0x10 is not open: %w", ERROR_INVALID_HANDLE))
+
+	var clusterErr *ClusterError
+	assert.True(t, As(err, &clusterErr))
+	assert.Equal(t, syscall.Errno(errnoERROR_INVALID_HANDLE), clusterErr.Code)
+	assert.True(t, Is(err, ERROR_INVALID_HANDLE))
+	assert.True(t, strings.HasPrefix(err.Error(), "CloseCluster: ClusterHandle 0x10 is not open: "))
+}
+
+func TestWrapNotErrno(t *testing.T) {
+	inner := goerrors.New("bad expression")
+	err := Wrap("GetClusterResourceDependencyExpression", "[A] or", inner)
+
+	var clusterErr *ClusterError
+	assert.True(t, As(err, &clusterErr))
+	assert.Equal(t, syscall.Errno(0), clusterErr.Code)
+	assert.True(t, Is(err, inner))
+	assert.Equal(t, `GetClusterResourceDependencyExpression "[A] or": bad expression`, err.Error())
+}
+
+func TestWrapKeepsClusterError(t *testing.T) {
+	inner := Wrap("ClusterRegOpenKey", "Parameters", ERROR_FILE_NOT_FOUND)
+	outer := fmt.Errorf("load: %w", inner)
+
+	assert.Equal(t, inner, Wrap("ClusterRegQueryValue", "", inner))
+	assert.Equal(t, outer, Wrap("ClusterRegQueryValue", "", outer))
+}
+
+func TestClusterSentinels(t *testing.T) {
+	assert.Equal(t, syscall.Errno(5022), ERROR_CLUSTER_SHUTTING_DOWN)
+	assert.Equal(t, syscall.Errno(5004), ERROR_RESOURCE_NOT_ONLINE)
+	assert.Equal(t, syscall.Errno(5925), ERROR_CLUSTER_NO_QUORUM)
+	assert.Equal(t, syscall.Errno(5998), ERROR_CLUSTER_INVALID_INFRASTRUCTURE_FILESERVER_NAME)
+}
@@ -1,49 +1,26 @@
 package errors
 
 import (
+	goerrors "errors"
+	"runtime"
 	"syscall"
 )
 
-// Do the interface allocations only once for common
-// Errno values.
-const (
-	errnoERROR_INVALID_DATA       = 11
-	errnoERROR_IO_PENDING         = 997
-	errnoRPC_S_SERVER_UNAVAILABLE = 1722
-	errnoEPT_S_NOT_REGISTERED     = 1753
-)
-
-var (
-	ERROR_INVALID_DATA       error = syscall.Errno(errnoERROR_INVALID_DATA)
-	ERROR_IO_PENDING         error = syscall.Errno(errnoERROR_IO_PENDING)
-	RPC_S_SERVER_UNAVAILABLE error = syscall.Errno(errnoRPC_S_SERVER_UNAVAILABLE)
-	EPT_S_NOT_REGISTERED     error = syscall.Errno(errnoEPT_S_NOT_REGISTERED)
-)
-
-// errnoErr returns common boxed Errno values, to prevent
+// errnoErr returns the boxed Errno values of the catalog, to prevent
 // allocations at runtime.
 func errnoErr(e syscall.Errno) error {
-	switch e {
-	case 0:
+	if e == 0 {
 		return nil
-	case errnoERROR_INVALID_DATA:
-		return ERROR_INVALID_DATA
-	case errnoERROR_IO_PENDING:
-		return ERROR_IO_PENDING
-	case errnoRPC_S_SERVER_UNAVAILABLE:
-		return RPC_S_SERVER_UNAVAILABLE
-	case errnoEPT_S_NOT_REGISTERED:
-		return EPT_S_NOT_REGISTERED
 	}
-	// TODO: add more here, after collecting data on the common
-	// error values see on Windows. (perhaps when running
-	// all.bat?)
+	if entry, ok := catalogByCode[e]; ok {
+		return entry.err
+	}
 	return e
 }
 
 func Ensure(lastError syscall.Errno) error {
 	if lastError != 0 {
-		return lastError
+		return errnoErr(lastError)
 	} else {
 		return syscall.EINVAL
 	}
@@ -63,3 +40,7 @@ func NotZero(lastError syscall.Errno) error {
 	}
 	return nil
 }
+
+// ErrNotSupported is returned by the Windows api wrappers when they are built
+// for another platform, the types, constants and pure helpers work everywhere
+var ErrNotSupported = goerrors.New("not supported on " + runtime.GOOS)
@@ -0,0 +1,127 @@
+// gencodes generates the error catalog of package errors from codes.txt,
+// run it with go generate in pkg/errors
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// categories maps the category column of codes.txt to the Category constant
+var categories = map[string]string{
+	"-":              "CategoryNone",
+	"transient":      "CategoryTransient",
+	"not-found":      "CategoryNotFound",
+	"access":         "CategoryAccess",
+	"state-conflict": "CategoryStateConflict",
+}
+
+type code struct {
+	value    uint32
+	name     string
+	category string
+}
+
+func main() {
+	in := flag.String("in", "codes.txt", "table of codes")
+	out := flag.String("out", "zcodes.go", "generated file")
+	flag.Parse()
+
+	file, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	codes, err := parse(file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("%s: %v", *in, err)
+	}
+
+	source, err := generate(codes, *in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse reads the lines of code, name and category, skipping blank lines
+// and # comments. The codes are returned sorted by value
+func parse(r io.Reader) ([]code, error) {
+	var codes []code
+	values := map[uint32]string{}
+	names := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: want code, name and category, got %q", line, text)
+		}
+		value, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		name := fields[1]
+		if _, ok := categories[fields[2]]; !ok {
+			return nil, fmt.Errorf("line %d: unknown category %q", line, fields[2])
+		}
+		if other, ok := values[uint32(value)]; ok {
+			return nil, fmt.Errorf("line %d: code %d of %s is also %s", line, value, name, other)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("line %d: duplicate name %s", line, name)
+		}
+		values[uint32(value)] = name
+		names[name] = true
+		codes = append(codes, code{uint32(value), name, categories[fields[2]]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i].value < codes[j].value })
+	return codes, nil
+}
+
+func generate(codes []code, table string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gencodes from %s; DO NOT EDIT.\n\n", table)
+	buf.WriteString("package errors\n\nimport \"syscall\"\n\n")
+
+	buf.WriteString("const (\n")
+	for _, c := range codes {
+		fmt.Fprintf(&buf, "errno%s = %d\n", c.name, c.value)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// Do the interface allocations only once for every Errno in the catalog.\n")
+	buf.WriteString("var (\n")
+	for _, c := range codes {
+		fmt.Fprintf(&buf, "%s error = syscall.Errno(errno%s)\n", c.name, c.name)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("var catalog = []catalogEntry{\n")
+	for _, c := range codes {
+		fmt.Fprintf(&buf, "{errno%s, %q, %s, %s},\n", c.name, c.name, c.category, c.name)
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
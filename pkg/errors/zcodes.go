@@ -0,0 +1,1095 @@
+// Code generated by gencodes from codes.txt; DO NOT EDIT.
+
+package errors
+
+import "syscall"
+
+const (
+	errnoERROR_INVALID_FUNCTION                                                   = 1
+	errnoERROR_FILE_NOT_FOUND                                                     = 2
+	errnoERROR_PATH_NOT_FOUND                                                     = 3
+	errnoERROR_TOO_MANY_OPEN_FILES                                                = 4
+	errnoERROR_ACCESS_DENIED                                                      = 5
+	errnoERROR_INVALID_HANDLE                                                     = 6
+	errnoERROR_NOT_ENOUGH_MEMORY                                                  = 8
+	errnoERROR_BAD_FORMAT                                                         = 11
+	errnoERROR_INVALID_ACCESS                                                     = 12
+	errnoERROR_INVALID_DATA                                                       = 13
+	errnoERROR_OUTOFMEMORY                                                        = 14
+	errnoERROR_NOT_READY                                                          = 21
+	errnoERROR_GEN_FAILURE                                                        = 31
+	errnoERROR_SHARING_VIOLATION                                                  = 32
+	errnoERROR_LOCK_VIOLATION                                                     = 33
+	errnoERROR_HANDLE_EOF                                                         = 38
+	errnoERROR_NOT_SUPPORTED                                                      = 50
+	errnoERROR_REM_NOT_LIST                                                       = 51
+	errnoERROR_BAD_NETPATH                                                        = 53
+	errnoERROR_UNEXP_NET_ERR                                                      = 59
+	errnoERROR_NETNAME_DELETED                                                    = 64
+	errnoERROR_NETWORK_ACCESS_DENIED                                              = 65
+	errnoERROR_BAD_NET_NAME                                                       = 67
+	errnoERROR_FILE_EXISTS                                                        = 80
+	errnoERROR_INVALID_PARAMETER                                                  = 87
+	errnoERROR_BROKEN_PIPE                                                        = 109
+	errnoERROR_BUFFER_OVERFLOW                                                    = 111
+	errnoERROR_DISK_FULL                                                          = 112
+	errnoERROR_CALL_NOT_IMPLEMENTED                                               = 120
+	errnoERROR_SEM_TIMEOUT                                                        = 121
+	errnoERROR_INSUFFICIENT_BUFFER                                                = 122
+	errnoERROR_INVALID_NAME                                                       = 123
+	errnoERROR_MOD_NOT_FOUND                                                      = 126
+	errnoERROR_PROC_NOT_FOUND                                                     = 127
+	errnoERROR_DIR_NOT_EMPTY                                                      = 145
+	errnoERROR_BUSY                                                               = 170
+	errnoERROR_ALREADY_EXISTS                                                     = 183
+	errnoERROR_FILENAME_EXCED_RANGE                                               = 206
+	errnoERROR_MORE_DATA                                                          = 234
+	errnoWAIT_TIMEOUT                                                             = 258
+	errnoERROR_NO_MORE_ITEMS                                                      = 259
+	errnoERROR_OPERATION_ABORTED                                                  = 995
+	errnoERROR_IO_PENDING                                                         = 997
+	errnoERROR_INVALID_FLAGS                                                      = 1004
+	errnoERROR_BADDB                                                              = 1009
+	errnoERROR_BADKEY                                                             = 1010
+	errnoERROR_CANTOPEN                                                           = 1011
+	errnoERROR_CANTREAD                                                           = 1012
+	errnoERROR_CANTWRITE                                                          = 1013
+	errnoERROR_REGISTRY_CORRUPT                                                   = 1015
+	errnoERROR_KEY_DELETED                                                        = 1018
+	errnoERROR_DEPENDENT_SERVICES_RUNNING                                         = 1051
+	errnoERROR_SERVICE_REQUEST_TIMEOUT                                            = 1053
+	errnoERROR_SERVICE_ALREADY_RUNNING                                            = 1056
+	errnoERROR_CIRCULAR_DEPENDENCY                                                = 1059
+	errnoERROR_SERVICE_DOES_NOT_EXIST                                             = 1060
+	errnoERROR_SERVICE_NOT_ACTIVE                                                 = 1062
+	errnoERROR_SHUTDOWN_IN_PROGRESS                                               = 1115
+	errnoERROR_NOT_FOUND                                                          = 1168
+	errnoERROR_NO_NETWORK                                                         = 1222
+	errnoERROR_CONNECTION_REFUSED                                                 = 1225
+	errnoERROR_NETWORK_UNREACHABLE                                                = 1231
+	errnoERROR_HOST_UNREACHABLE                                                   = 1232
+	errnoERROR_CONNECTION_ABORTED                                                 = 1236
+	errnoERROR_RETRY                                                              = 1237
+	errnoERROR_PRIVILEGE_NOT_HELD                                                 = 1314
+	errnoERROR_LOGON_FAILURE                                                      = 1326
+	errnoERROR_PASSWORD_EXPIRED                                                   = 1330
+	errnoERROR_ACCOUNT_DISABLED                                                   = 1331
+	errnoERROR_NONE_MAPPED                                                        = 1332
+	errnoERROR_NO_SYSTEM_RESOURCES                                                = 1450
+	errnoERROR_TIMEOUT                                                            = 1460
+	errnoRPC_S_INVALID_STRING_BINDING                                             = 1700
+	errnoRPC_S_WRONG_KIND_OF_BINDING                                              = 1701
+	errnoRPC_S_INVALID_BINDING                                                    = 1702
+	errnoRPC_S_PROTSEQ_NOT_SUPPORTED                                              = 1703
+	errnoRPC_S_INVALID_RPC_PROTSEQ                                                = 1704
+	errnoRPC_S_INVALID_STRING_UUID                                                = 1705
+	errnoRPC_S_INVALID_ENDPOINT_FORMAT                                            = 1706
+	errnoRPC_S_INVALID_NET_ADDR                                                   = 1707
+	errnoRPC_S_NO_ENDPOINT_FOUND                                                  = 1708
+	errnoRPC_S_INVALID_TIMEOUT                                                    = 1709
+	errnoRPC_S_OBJECT_NOT_FOUND                                                   = 1710
+	errnoRPC_S_ALREADY_REGISTERED                                                 = 1711
+	errnoRPC_S_TYPE_ALREADY_REGISTERED                                            = 1712
+	errnoRPC_S_ALREADY_LISTENING                                                  = 1713
+	errnoRPC_S_NO_PROTSEQS_REGISTERED                                             = 1714
+	errnoRPC_S_NOT_LISTENING                                                      = 1715
+	errnoRPC_S_UNKNOWN_MGR_TYPE                                                   = 1716
+	errnoRPC_S_UNKNOWN_IF                                                         = 1717
+	errnoRPC_S_NO_BINDINGS                                                        = 1718
+	errnoRPC_S_NO_PROTSEQS                                                        = 1719
+	errnoRPC_S_CANT_CREATE_ENDPOINT                                               = 1720
+	errnoRPC_S_OUT_OF_RESOURCES                                                   = 1721
+	errnoRPC_S_SERVER_UNAVAILABLE                                                 = 1722
+	errnoRPC_S_SERVER_TOO_BUSY                                                    = 1723
+	errnoRPC_S_INVALID_NETWORK_OPTIONS                                            = 1724
+	errnoRPC_S_NO_CALL_ACTIVE                                                     = 1725
+	errnoRPC_S_CALL_FAILED                                                        = 1726
+	errnoRPC_S_CALL_FAILED_DNE                                                    = 1727
+	errnoRPC_S_PROTOCOL_ERROR                                                     = 1728
+	errnoRPC_S_PROXY_ACCESS_DENIED                                                = 1729
+	errnoRPC_S_UNSUPPORTED_TRANS_SYN                                              = 1730
+	errnoRPC_S_UNSUPPORTED_TYPE                                                   = 1732
+	errnoRPC_S_INVALID_TAG                                                        = 1733
+	errnoRPC_S_INVALID_BOUND                                                      = 1734
+	errnoRPC_S_NO_ENTRY_NAME                                                      = 1735
+	errnoRPC_S_INVALID_NAME_SYNTAX                                                = 1736
+	errnoRPC_S_UNSUPPORTED_NAME_SYNTAX                                            = 1737
+	errnoRPC_S_UUID_NO_ADDRESS                                                    = 1739
+	errnoRPC_S_DUPLICATE_ENDPOINT                                                 = 1740
+	errnoRPC_S_UNKNOWN_AUTHN_TYPE                                                 = 1741
+	errnoRPC_S_MAX_CALLS_TOO_SMALL                                                = 1742
+	errnoRPC_S_STRING_TOO_LONG                                                    = 1743
+	errnoRPC_S_PROTSEQ_NOT_FOUND                                                  = 1744
+	errnoRPC_S_PROCNUM_OUT_OF_RANGE                                               = 1745
+	errnoRPC_S_BINDING_HAS_NO_AUTH                                                = 1746
+	errnoRPC_S_UNKNOWN_AUTHN_SERVICE                                              = 1747
+	errnoRPC_S_UNKNOWN_AUTHN_LEVEL                                                = 1748
+	errnoRPC_S_INVALID_AUTH_IDENTITY                                              = 1749
+	errnoRPC_S_UNKNOWN_AUTHZ_SERVICE                                              = 1750
+	errnoEPT_S_INVALID_ENTRY                                                      = 1751
+	errnoEPT_S_CANT_PERFORM_OP                                                    = 1752
+	errnoEPT_S_NOT_REGISTERED                                                     = 1753
+	errnoRPC_S_NOTHING_TO_EXPORT                                                  = 1754
+	errnoRPC_S_INCOMPLETE_NAME                                                    = 1755
+	errnoRPC_S_INVALID_VERS_OPTION                                                = 1756
+	errnoRPC_S_NO_MORE_MEMBERS                                                    = 1757
+	errnoRPC_S_NOT_ALL_OBJS_UNEXPORTED                                            = 1758
+	errnoRPC_S_INTERFACE_NOT_FOUND                                                = 1759
+	errnoRPC_S_ENTRY_ALREADY_EXISTS                                               = 1760
+	errnoRPC_S_ENTRY_NOT_FOUND                                                    = 1761
+	errnoRPC_S_NAME_SERVICE_UNAVAILABLE                                           = 1762
+	errnoRPC_S_INVALID_NAF_ID                                                     = 1763
+	errnoRPC_S_CANNOT_SUPPORT                                                     = 1764
+	errnoRPC_S_NO_CONTEXT_AVAILABLE                                               = 1765
+	errnoRPC_S_INTERNAL_ERROR                                                     = 1766
+	errnoRPC_S_ZERO_DIVIDE                                                        = 1767
+	errnoRPC_S_ADDRESS_ERROR                                                      = 1768
+	errnoRPC_S_FP_DIV_ZERO                                                        = 1769
+	errnoRPC_S_FP_UNDERFLOW                                                       = 1770
+	errnoRPC_S_FP_OVERFLOW                                                        = 1771
+	errnoRPC_X_NO_MORE_ENTRIES                                                    = 1772
+	errnoRPC_X_SS_CHAR_TRANS_OPEN_FAIL                                            = 1773
+	errnoRPC_X_SS_CHAR_TRANS_SHORT_FILE                                           = 1774
+	errnoRPC_X_SS_IN_NULL_CONTEXT                                                 = 1775
+	errnoRPC_X_SS_CONTEXT_DAMAGED                                                 = 1777
+	errnoRPC_X_SS_HANDLES_MISMATCH                                                = 1778
+	errnoRPC_X_SS_CANNOT_GET_CALL_HANDLE                                          = 1779
+	errnoRPC_X_NULL_REF_POINTER                                                   = 1780
+	errnoRPC_X_ENUM_VALUE_OUT_OF_RANGE                                            = 1781
+	errnoRPC_X_BYTE_COUNT_TOO_SMALL                                               = 1782
+	errnoRPC_X_BAD_STUB_DATA                                                      = 1783
+	errnoRPC_S_CALL_CANCELLED                                                     = 1818
+	errnoRPC_S_CALL_IN_PROGRESS                                                   = 1819
+	errnoRPC_S_NO_MORE_BINDINGS                                                   = 1820
+	errnoRPC_S_GROUP_MEMBER_NOT_FOUND                                             = 1821
+	errnoEPT_S_CANT_CREATE                                                        = 1822
+	errnoRPC_S_INVALID_OBJECT                                                     = 1823
+	errnoRPC_S_SEC_PKG_ERROR                                                      = 1825
+	errnoRPC_S_NOT_CANCELLED                                                      = 1826
+	errnoERROR_ACCOUNT_LOCKED_OUT                                                 = 1909
+	errnoERROR_DEPENDENT_RESOURCE_EXISTS                                          = 5001
+	errnoERROR_DEPENDENCY_NOT_FOUND                                               = 5002
+	errnoERROR_DEPENDENCY_ALREADY_EXISTS                                          = 5003
+	errnoERROR_RESOURCE_NOT_ONLINE                                                = 5004
+	errnoERROR_HOST_NODE_NOT_AVAILABLE                                            = 5005
+	errnoERROR_RESOURCE_NOT_AVAILABLE                                             = 5006
+	errnoERROR_RESOURCE_NOT_FOUND                                                 = 5007
+	errnoERROR_SHUTDOWN_CLUSTER                                                   = 5008
+	errnoERROR_CANT_EVICT_ACTIVE_NODE                                             = 5009
+	errnoERROR_OBJECT_ALREADY_EXISTS                                              = 5010
+	errnoERROR_OBJECT_IN_LIST                                                     = 5011
+	errnoERROR_GROUP_NOT_AVAILABLE                                                = 5012
+	errnoERROR_GROUP_NOT_FOUND                                                    = 5013
+	errnoERROR_GROUP_NOT_ONLINE                                                   = 5014
+	errnoERROR_HOST_NODE_NOT_RESOURCE_OWNER                                       = 5015
+	errnoERROR_HOST_NODE_NOT_GROUP_OWNER                                          = 5016
+	errnoERROR_RESMON_CREATE_FAILED                                               = 5017
+	errnoERROR_RESMON_ONLINE_FAILED                                               = 5018
+	errnoERROR_RESOURCE_ONLINE                                                    = 5019
+	errnoERROR_QUORUM_RESOURCE                                                    = 5020
+	errnoERROR_NOT_QUORUM_CAPABLE                                                 = 5021
+	errnoERROR_CLUSTER_SHUTTING_DOWN                                              = 5022
+	errnoERROR_INVALID_STATE                                                      = 5023
+	errnoERROR_RESOURCE_PROPERTIES_STORED                                         = 5024
+	errnoERROR_NOT_QUORUM_CLASS                                                   = 5025
+	errnoERROR_CORE_RESOURCE                                                      = 5026
+	errnoERROR_QUORUM_RESOURCE_ONLINE_FAILED                                      = 5027
+	errnoERROR_QUORUMLOG_OPEN_FAILED                                              = 5028
+	errnoERROR_CLUSTERLOG_CORRUPT                                                 = 5029
+	errnoERROR_CLUSTERLOG_RECORD_EXCEEDS_MAXSIZE                                  = 5030
+	errnoERROR_CLUSTERLOG_EXCEEDS_MAXSIZE                                         = 5031
+	errnoERROR_CLUSTERLOG_CHKPOINT_NOT_FOUND                                      = 5032
+	errnoERROR_CLUSTERLOG_NOT_ENOUGH_SPACE                                        = 5033
+	errnoERROR_QUORUM_OWNER_ALIVE                                                 = 5034
+	errnoERROR_NETWORK_NOT_AVAILABLE                                              = 5035
+	errnoERROR_NODE_NOT_AVAILABLE                                                 = 5036
+	errnoERROR_ALL_NODES_NOT_AVAILABLE                                            = 5037
+	errnoERROR_RESOURCE_FAILED                                                    = 5038
+	errnoERROR_CLUSTER_INVALID_NODE                                               = 5039
+	errnoERROR_CLUSTER_NODE_EXISTS                                                = 5040
+	errnoERROR_CLUSTER_JOIN_IN_PROGRESS                                           = 5041
+	errnoERROR_CLUSTER_NODE_NOT_FOUND                                             = 5042
+	errnoERROR_CLUSTER_LOCAL_NODE_NOT_FOUND                                       = 5043
+	errnoERROR_CLUSTER_NETWORK_EXISTS                                             = 5044
+	errnoERROR_CLUSTER_NETWORK_NOT_FOUND                                          = 5045
+	errnoERROR_CLUSTER_NETINTERFACE_EXISTS                                        = 5046
+	errnoERROR_CLUSTER_NETINTERFACE_NOT_FOUND                                     = 5047
+	errnoERROR_CLUSTER_INVALID_REQUEST                                            = 5048
+	errnoERROR_CLUSTER_INVALID_NETWORK_PROVIDER                                   = 5049
+	errnoERROR_CLUSTER_NODE_DOWN                                                  = 5050
+	errnoERROR_CLUSTER_NODE_UNREACHABLE                                           = 5051
+	errnoERROR_CLUSTER_NODE_NOT_MEMBER                                            = 5052
+	errnoERROR_CLUSTER_JOIN_NOT_IN_PROGRESS                                       = 5053
+	errnoERROR_CLUSTER_INVALID_NETWORK                                            = 5054
+	errnoERROR_CLUSTER_NODE_UP                                                    = 5056
+	errnoERROR_CLUSTER_IPADDR_IN_USE                                              = 5057
+	errnoERROR_CLUSTER_NODE_NOT_PAUSED                                            = 5058
+	errnoERROR_CLUSTER_NO_SECURITY_CONTEXT                                        = 5059
+	errnoERROR_CLUSTER_NETWORK_NOT_INTERNAL                                       = 5060
+	errnoERROR_CLUSTER_NODE_ALREADY_UP                                            = 5061
+	errnoERROR_CLUSTER_NODE_ALREADY_DOWN                                          = 5062
+	errnoERROR_CLUSTER_NETWORK_ALREADY_ONLINE                                     = 5063
+	errnoERROR_CLUSTER_NETWORK_ALREADY_OFFLINE                                    = 5064
+	errnoERROR_CLUSTER_NODE_ALREADY_MEMBER                                        = 5065
+	errnoERROR_CLUSTER_LAST_INTERNAL_NETWORK                                      = 5066
+	errnoERROR_CLUSTER_NETWORK_HAS_DEPENDENTS                                     = 5067
+	errnoERROR_INVALID_OPERATION_ON_QUORUM                                        = 5068
+	errnoERROR_DEPENDENCY_NOT_ALLOWED                                             = 5069
+	errnoERROR_CLUSTER_NODE_PAUSED                                                = 5070
+	errnoERROR_NODE_CANT_HOST_RESOURCE                                            = 5071
+	errnoERROR_CLUSTER_NODE_NOT_READY                                             = 5072
+	errnoERROR_CLUSTER_NODE_SHUTTING_DOWN                                         = 5073
+	errnoERROR_CLUSTER_JOIN_ABORTED                                               = 5074
+	errnoERROR_CLUSTER_INCOMPATIBLE_VERSIONS                                      = 5075
+	errnoERROR_CLUSTER_MAXNUM_OF_RESOURCES_EXCEEDED                               = 5076
+	errnoERROR_CLUSTER_SYSTEM_CONFIG_CHANGED                                      = 5077
+	errnoERROR_CLUSTER_RESOURCE_TYPE_NOT_FOUND                                    = 5078
+	errnoERROR_CLUSTER_RESTYPE_NOT_SUPPORTED                                      = 5079
+	errnoERROR_CLUSTER_RESNAME_NOT_FOUND                                          = 5080
+	errnoERROR_CLUSTER_NO_RPC_PACKAGES_REGISTERED                                 = 5081
+	errnoERROR_CLUSTER_OWNER_NOT_IN_PREFLIST                                      = 5082
+	errnoERROR_CLUSTER_DATABASE_SEQMISMATCH                                       = 5083
+	errnoERROR_RESMON_INVALID_STATE                                               = 5084
+	errnoERROR_CLUSTER_GUM_NOT_LOCKER                                             = 5085
+	errnoERROR_QUORUM_DISK_NOT_FOUND                                              = 5086
+	errnoERROR_DATABASE_BACKUP_CORRUPT                                            = 5087
+	errnoERROR_CLUSTER_NODE_ALREADY_HAS_DFS_ROOT                                  = 5088
+	errnoERROR_RESOURCE_PROPERTY_UNCHANGEABLE                                     = 5089
+	errnoERROR_CLUSTER_MEMBERSHIP_INVALID_STATE                                   = 5890
+	errnoERROR_CLUSTER_QUORUMLOG_NOT_FOUND                                        = 5891
+	errnoERROR_CLUSTER_MEMBERSHIP_HALT                                            = 5892
+	errnoERROR_CLUSTER_INSTANCE_ID_MISMATCH                                       = 5893
+	errnoERROR_CLUSTER_NETWORK_NOT_FOUND_FOR_IP                                   = 5894
+	errnoERROR_CLUSTER_PROPERTY_DATA_TYPE_MISMATCH                                = 5895
+	errnoERROR_CLUSTER_EVICT_WITHOUT_CLEANUP                                      = 5896
+	errnoERROR_CLUSTER_PARAMETER_MISMATCH                                         = 5897
+	errnoERROR_NODE_CANNOT_BE_CLUSTERED                                           = 5898
+	errnoERROR_CLUSTER_WRONG_OS_VERSION                                           = 5899
+	errnoERROR_CLUSTER_CANT_CREATE_DUP_CLUSTER_NAME                               = 5900
+	errnoERROR_CLUSCFG_ALREADY_COMMITTED                                          = 5901
+	errnoERROR_CLUSCFG_ROLLBACK_FAILED                                            = 5902
+	errnoERROR_CLUSCFG_SYSTEM_DISK_DRIVE_LETTER_CONFLICT                          = 5903
+	errnoERROR_CLUSTER_OLD_VERSION                                                = 5904
+	errnoERROR_CLUSTER_MISMATCHED_COMPUTER_ACCT_NAME                              = 5905
+	errnoERROR_CLUSTER_NO_NET_ADAPTERS                                            = 5906
+	errnoERROR_CLUSTER_POISONED                                                   = 5907
+	errnoERROR_CLUSTER_GROUP_MOVING                                               = 5908
+	errnoERROR_CLUSTER_RESOURCE_TYPE_BUSY                                         = 5909
+	errnoERROR_RESOURCE_CALL_TIMED_OUT                                            = 5910
+	errnoERROR_INVALID_CLUSTER_IPV6_ADDRESS                                       = 5911
+	errnoERROR_CLUSTER_INTERNAL_INVALID_FUNCTION                                  = 5912
+	errnoERROR_CLUSTER_PARAMETER_OUT_OF_BOUNDS                                    = 5913
+	errnoERROR_CLUSTER_PARTIAL_SEND                                               = 5914
+	errnoERROR_CLUSTER_REGISTRY_INVALID_FUNCTION                                  = 5915
+	errnoERROR_CLUSTER_INVALID_STRING_TERMINATION                                 = 5916
+	errnoERROR_CLUSTER_INVALID_STRING_FORMAT                                      = 5917
+	errnoERROR_CLUSTER_DATABASE_TRANSACTION_IN_PROGRESS                           = 5918
+	errnoERROR_CLUSTER_DATABASE_TRANSACTION_NOT_IN_PROGRESS                       = 5919
+	errnoERROR_CLUSTER_NULL_DATA                                                  = 5920
+	errnoERROR_CLUSTER_PARTIAL_READ                                               = 5921
+	errnoERROR_CLUSTER_PARTIAL_WRITE                                              = 5922
+	errnoERROR_CLUSTER_CANT_DESERIALIZE_DATA                                      = 5923
+	errnoERROR_DEPENDENT_RESOURCE_PROPERTY_CONFLICT                               = 5924
+	errnoERROR_CLUSTER_NO_QUORUM                                                  = 5925
+	errnoERROR_CLUSTER_INVALID_IPV6_NETWORK                                       = 5926
+	errnoERROR_CLUSTER_INVALID_IPV6_TUNNEL_NETWORK                                = 5927
+	errnoERROR_QUORUM_NOT_ALLOWED_IN_THIS_GROUP                                   = 5928
+	errnoERROR_DEPENDENCY_TREE_TOO_COMPLEX                                        = 5929
+	errnoERROR_EXCEPTION_IN_RESOURCE_CALL                                         = 5930
+	errnoERROR_CLUSTER_RHS_FAILED_INITIALIZATION                                  = 5931
+	errnoERROR_CLUSTER_NOT_INSTALLED                                              = 5932
+	errnoERROR_CLUSTER_RESOURCES_MUST_BE_ONLINE_ON_THE_SAME_NODE                  = 5933
+	errnoERROR_CLUSTER_MAX_NODES_IN_CLUSTER                                       = 5934
+	errnoERROR_CLUSTER_TOO_MANY_NODES                                             = 5935
+	errnoERROR_CLUSTER_OBJECT_ALREADY_USED                                        = 5936
+	errnoERROR_NONCORE_GROUPS_FOUND                                               = 5937
+	errnoERROR_FILE_SHARE_RESOURCE_CONFLICT                                       = 5938
+	errnoERROR_CLUSTER_EVICT_INVALID_REQUEST                                      = 5939
+	errnoERROR_CLUSTER_SINGLETON_RESOURCE                                         = 5940
+	errnoERROR_CLUSTER_GROUP_SINGLETON_RESOURCE                                   = 5941
+	errnoERROR_CLUSTER_RESOURCE_PROVIDER_FAILED                                   = 5942
+	errnoERROR_CLUSTER_RESOURCE_CONFIGURATION_ERROR                               = 5943
+	errnoERROR_CLUSTER_GROUP_BUSY                                                 = 5944
+	errnoERROR_CLUSTER_NOT_SHARED_VOLUME                                          = 5945
+	errnoERROR_CLUSTER_INVALID_SECURITY_DESCRIPTOR                                = 5946
+	errnoERROR_CLUSTER_SHARED_VOLUMES_IN_USE                                      = 5947
+	errnoERROR_CLUSTER_USE_SHARED_VOLUMES_API                                     = 5948
+	errnoERROR_CLUSTER_BACKUP_IN_PROGRESS                                         = 5949
+	errnoERROR_NON_CSV_PATH                                                       = 5950
+	errnoERROR_CSV_VOLUME_NOT_LOCAL                                               = 5951
+	errnoERROR_CLUSTER_WATCHDOG_TERMINATING                                       = 5952
+	errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_INCOMPATIBLE_NODES                    = 5953
+	errnoERROR_CLUSTER_INVALID_NODE_WEIGHT                                        = 5954
+	errnoERROR_CLUSTER_RESOURCE_VETOED_CALL                                       = 5955
+	errnoERROR_RESMON_SYSTEM_RESOURCES_LACKING                                    = 5956
+	errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_DESTINATION   = 5957
+	errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_SOURCE        = 5958
+	errnoERROR_CLUSTER_GROUP_QUEUED                                               = 5959
+	errnoERROR_CLUSTER_RESOURCE_LOCKED_STATUS                                     = 5960
+	errnoERROR_CLUSTER_SHARED_VOLUME_FAILOVER_NOT_ALLOWED                         = 5961
+	errnoERROR_CLUSTER_NODE_DRAIN_IN_PROGRESS                                     = 5962
+	errnoERROR_CLUSTER_DISK_NOT_CONNECTED                                         = 5963
+	errnoERROR_DISK_NOT_CSV_CAPABLE                                               = 5964
+	errnoERROR_RESOURCE_NOT_IN_AVAILABLE_STORAGE                                  = 5965
+	errnoERROR_CLUSTER_SHARED_VOLUME_REDIRECTED                                   = 5966
+	errnoERROR_CLUSTER_SHARED_VOLUME_NOT_REDIRECTED                               = 5967
+	errnoERROR_CLUSTER_CANNOT_RETURN_PROPERTIES                                   = 5968
+	errnoERROR_CLUSTER_RESOURCE_CONTAINS_UNSUPPORTED_DIFF_AREA_FOR_SHARED_VOLUMES = 5969
+	errnoERROR_CLUSTER_RESOURCE_IS_IN_MAINTENANCE_MODE                            = 5970
+	errnoERROR_CLUSTER_AFFINITY_CONFLICT                                          = 5971
+	errnoERROR_CLUSTER_RESOURCE_IS_REPLICA_VIRTUAL_MACHINE                        = 5972
+	errnoERROR_CLUSTER_UPGRADE_INCOMPATIBLE_VERSIONS                              = 5973
+	errnoERROR_CLUSTER_UPGRADE_FIX_QUORUM_NOT_SUPPORTED                           = 5974
+	errnoERROR_CLUSTER_UPGRADE_RESTART_REQUIRED                                   = 5975
+	errnoERROR_CLUSTER_UPGRADE_IN_PROGRESS                                        = 5976
+	errnoERROR_CLUSTER_UPGRADE_INCOMPLETE                                         = 5977
+	errnoERROR_CLUSTER_NODE_IN_GRACE_PERIOD                                       = 5978
+	errnoERROR_CLUSTER_CSV_IO_PAUSE_TIMEOUT                                       = 5979
+	errnoERROR_NODE_NOT_ACTIVE_CLUSTER_MEMBER                                     = 5980
+	errnoERROR_CLUSTER_RESOURCE_NOT_MONITORED                                     = 5981
+	errnoERROR_CLUSTER_RESOURCE_DOES_NOT_SUPPORT_UNMONITORED                      = 5982
+	errnoERROR_CLUSTER_RESOURCE_IS_REPLICATED                                     = 5983
+	errnoERROR_CLUSTER_NODE_ISOLATED                                              = 5984
+	errnoERROR_CLUSTER_NODE_QUARANTINED                                           = 5985
+	errnoERROR_CLUSTER_DATABASE_UPDATE_CONDITION_FAILED                           = 5986
+	errnoERROR_CLUSTER_SPACE_DEGRADED                                             = 5987
+	errnoERROR_CLUSTER_TOKEN_DELEGATION_NOT_SUPPORTED                             = 5988
+	errnoERROR_CLUSTER_CSV_INVALID_HANDLE                                         = 5989
+	errnoERROR_CLUSTER_CSV_SUPPORTED_ONLY_ON_COORDINATOR                          = 5990
+	errnoERROR_GROUPSET_NOT_AVAILABLE                                             = 5991
+	errnoERROR_GROUPSET_NOT_FOUND                                                 = 5992
+	errnoERROR_GROUPSET_CANT_PROVIDE                                              = 5993
+	errnoERROR_CLUSTER_FAULT_DOMAIN_PARENT_NOT_FOUND                              = 5994
+	errnoERROR_CLUSTER_FAULT_DOMAIN_INVALID_HIERARCHY                             = 5995
+	errnoERROR_CLUSTER_FAULT_DOMAIN_FAILED_S2D_VALIDATION                         = 5996
+	errnoERROR_CLUSTER_FAULT_DOMAIN_S2D_CONNECTIVITY_LOSS                         = 5997
+	errnoERROR_CLUSTER_INVALID_INFRASTRUCTURE_FILESERVER_NAME                     = 5998
+	errnoERROR_CLUSTERSET_MANAGEMENT_CLUSTER_UNREACHABLE                          = 5999
+)
+
+// Do the interface allocations only once for every Errno in the catalog.
+var (
+	ERROR_INVALID_FUNCTION                                                   error = syscall.Errno(errnoERROR_INVALID_FUNCTION)
+	ERROR_FILE_NOT_FOUND                                                     error = syscall.Errno(errnoERROR_FILE_NOT_FOUND)
+	ERROR_PATH_NOT_FOUND                                                     error = syscall.Errno(errnoERROR_PATH_NOT_FOUND)
+	ERROR_TOO_MANY_OPEN_FILES                                                error = syscall.Errno(errnoERROR_TOO_MANY_OPEN_FILES)
+	ERROR_ACCESS_DENIED                                                      error = syscall.Errno(errnoERROR_ACCESS_DENIED)
+	ERROR_INVALID_HANDLE                                                     error = syscall.Errno(errnoERROR_INVALID_HANDLE)
+	ERROR_NOT_ENOUGH_MEMORY                                                  error = syscall.Errno(errnoERROR_NOT_ENOUGH_MEMORY)
+	ERROR_BAD_FORMAT                                                         error = syscall.Errno(errnoERROR_BAD_FORMAT)
+	ERROR_INVALID_ACCESS                                                     error = syscall.Errno(errnoERROR_INVALID_ACCESS)
+	ERROR_INVALID_DATA                                                       error = syscall.Errno(errnoERROR_INVALID_DATA)
+	ERROR_OUTOFMEMORY                                                        error = syscall.Errno(errnoERROR_OUTOFMEMORY)
+	ERROR_NOT_READY                                                          error = syscall.Errno(errnoERROR_NOT_READY)
+	ERROR_GEN_FAILURE                                                        error = syscall.Errno(errnoERROR_GEN_FAILURE)
+	ERROR_SHARING_VIOLATION                                                  error = syscall.Errno(errnoERROR_SHARING_VIOLATION)
+	ERROR_LOCK_VIOLATION                                                     error = syscall.Errno(errnoERROR_LOCK_VIOLATION)
+	ERROR_HANDLE_EOF                                                         error = syscall.Errno(errnoERROR_HANDLE_EOF)
+	ERROR_NOT_SUPPORTED                                                      error = syscall.Errno(errnoERROR_NOT_SUPPORTED)
+	ERROR_REM_NOT_LIST                                                       error = syscall.Errno(errnoERROR_REM_NOT_LIST)
+	ERROR_BAD_NETPATH                                                        error = syscall.Errno(errnoERROR_BAD_NETPATH)
+	ERROR_UNEXP_NET_ERR                                                      error = syscall.Errno(errnoERROR_UNEXP_NET_ERR)
+	ERROR_NETNAME_DELETED                                                    error = syscall.Errno(errnoERROR_NETNAME_DELETED)
+	ERROR_NETWORK_ACCESS_DENIED                                              error = syscall.Errno(errnoERROR_NETWORK_ACCESS_DENIED)
+	ERROR_BAD_NET_NAME                                                       error = syscall.Errno(errnoERROR_BAD_NET_NAME)
+	ERROR_FILE_EXISTS                                                        error = syscall.Errno(errnoERROR_FILE_EXISTS)
+	ERROR_INVALID_PARAMETER                                                  error = syscall.Errno(errnoERROR_INVALID_PARAMETER)
+	ERROR_BROKEN_PIPE                                                        error = syscall.Errno(errnoERROR_BROKEN_PIPE)
+	ERROR_BUFFER_OVERFLOW                                                    error = syscall.Errno(errnoERROR_BUFFER_OVERFLOW)
+	ERROR_DISK_FULL                                                          error = syscall.Errno(errnoERROR_DISK_FULL)
+	ERROR_CALL_NOT_IMPLEMENTED                                               error = syscall.Errno(errnoERROR_CALL_NOT_IMPLEMENTED)
+	ERROR_SEM_TIMEOUT                                                        error = syscall.Errno(errnoERROR_SEM_TIMEOUT)
+	ERROR_INSUFFICIENT_BUFFER                                                error = syscall.Errno(errnoERROR_INSUFFICIENT_BUFFER)
+	ERROR_INVALID_NAME                                                       error = syscall.Errno(errnoERROR_INVALID_NAME)
+	ERROR_MOD_NOT_FOUND                                                      error = syscall.Errno(errnoERROR_MOD_NOT_FOUND)
+	ERROR_PROC_NOT_FOUND                                                     error = syscall.Errno(errnoERROR_PROC_NOT_FOUND)
+	ERROR_DIR_NOT_EMPTY                                                      error = syscall.Errno(errnoERROR_DIR_NOT_EMPTY)
+	ERROR_BUSY                                                               error = syscall.Errno(errnoERROR_BUSY)
+	ERROR_ALREADY_EXISTS                                                     error = syscall.Errno(errnoERROR_ALREADY_EXISTS)
+	ERROR_FILENAME_EXCED_RANGE                                               error = syscall.Errno(errnoERROR_FILENAME_EXCED_RANGE)
+	ERROR_MORE_DATA                                                          error = syscall.Errno(errnoERROR_MORE_DATA)
+	WAIT_TIMEOUT                                                             error = syscall.Errno(errnoWAIT_TIMEOUT)
+	ERROR_NO_MORE_ITEMS                                                      error = syscall.Errno(errnoERROR_NO_MORE_ITEMS)
+	ERROR_OPERATION_ABORTED                                                  error = syscall.Errno(errnoERROR_OPERATION_ABORTED)
+	ERROR_IO_PENDING                                                         error = syscall.Errno(errnoERROR_IO_PENDING)
+	ERROR_INVALID_FLAGS                                                      error = syscall.Errno(errnoERROR_INVALID_FLAGS)
+	ERROR_BADDB                                                              error = syscall.Errno(errnoERROR_BADDB)
+	ERROR_BADKEY                                                             error = syscall.Errno(errnoERROR_BADKEY)
+	ERROR_CANTOPEN                                                           error = syscall.Errno(errnoERROR_CANTOPEN)
+	ERROR_CANTREAD                                                           error = syscall.Errno(errnoERROR_CANTREAD)
+	ERROR_CANTWRITE                                                          error = syscall.Errno(errnoERROR_CANTWRITE)
+	ERROR_REGISTRY_CORRUPT                                                   error = syscall.Errno(errnoERROR_REGISTRY_CORRUPT)
+	ERROR_KEY_DELETED                                                        error = syscall.Errno(errnoERROR_KEY_DELETED)
+	ERROR_DEPENDENT_SERVICES_RUNNING                                         error = syscall.Errno(errnoERROR_DEPENDENT_SERVICES_RUNNING)
+	ERROR_SERVICE_REQUEST_TIMEOUT                                            error = syscall.Errno(errnoERROR_SERVICE_REQUEST_TIMEOUT)
+	ERROR_SERVICE_ALREADY_RUNNING                                            error = syscall.Errno(errnoERROR_SERVICE_ALREADY_RUNNING)
+	ERROR_CIRCULAR_DEPENDENCY                                                error = syscall.Errno(errnoERROR_CIRCULAR_DEPENDENCY)
+	ERROR_SERVICE_DOES_NOT_EXIST                                             error = syscall.Errno(errnoERROR_SERVICE_DOES_NOT_EXIST)
+	ERROR_SERVICE_NOT_ACTIVE                                                 error = syscall.Errno(errnoERROR_SERVICE_NOT_ACTIVE)
+	ERROR_SHUTDOWN_IN_PROGRESS                                               error = syscall.Errno(errnoERROR_SHUTDOWN_IN_PROGRESS)
+	ERROR_NOT_FOUND                                                          error = syscall.Errno(errnoERROR_NOT_FOUND)
+	ERROR_NO_NETWORK                                                         error = syscall.Errno(errnoERROR_NO_NETWORK)
+	ERROR_CONNECTION_REFUSED                                                 error = syscall.Errno(errnoERROR_CONNECTION_REFUSED)
+	ERROR_NETWORK_UNREACHABLE                                                error = syscall.Errno(errnoERROR_NETWORK_UNREACHABLE)
+	ERROR_HOST_UNREACHABLE                                                   error = syscall.Errno(errnoERROR_HOST_UNREACHABLE)
+	ERROR_CONNECTION_ABORTED                                                 error = syscall.Errno(errnoERROR_CONNECTION_ABORTED)
+	ERROR_RETRY                                                              error = syscall.Errno(errnoERROR_RETRY)
+	ERROR_PRIVILEGE_NOT_HELD                                                 error = syscall.Errno(errnoERROR_PRIVILEGE_NOT_HELD)
+	ERROR_LOGON_FAILURE                                                      error = syscall.Errno(errnoERROR_LOGON_FAILURE)
+	ERROR_PASSWORD_EXPIRED                                                   error = syscall.Errno(errnoERROR_PASSWORD_EXPIRED)
+	ERROR_ACCOUNT_DISABLED                                                   error = syscall.Errno(errnoERROR_ACCOUNT_DISABLED)
+	ERROR_NONE_MAPPED                                                        error = syscall.Errno(errnoERROR_NONE_MAPPED)
+	ERROR_NO_SYSTEM_RESOURCES                                                error = syscall.Errno(errnoERROR_NO_SYSTEM_RESOURCES)
+	ERROR_TIMEOUT                                                            error = syscall.Errno(errnoERROR_TIMEOUT)
+	RPC_S_INVALID_STRING_BINDING                                             error = syscall.Errno(errnoRPC_S_INVALID_STRING_BINDING)
+	RPC_S_WRONG_KIND_OF_BINDING                                              error = syscall.Errno(errnoRPC_S_WRONG_KIND_OF_BINDING)
+	RPC_S_INVALID_BINDING                                                    error = syscall.Errno(errnoRPC_S_INVALID_BINDING)
+	RPC_S_PROTSEQ_NOT_SUPPORTED                                              error = syscall.Errno(errnoRPC_S_PROTSEQ_NOT_SUPPORTED)
+	RPC_S_INVALID_RPC_PROTSEQ                                                error = syscall.Errno(errnoRPC_S_INVALID_RPC_PROTSEQ)
+	RPC_S_INVALID_STRING_UUID                                                error = syscall.Errno(errnoRPC_S_INVALID_STRING_UUID)
+	RPC_S_INVALID_ENDPOINT_FORMAT                                            error = syscall.Errno(errnoRPC_S_INVALID_ENDPOINT_FORMAT)
+	RPC_S_INVALID_NET_ADDR                                                   error = syscall.Errno(errnoRPC_S_INVALID_NET_ADDR)
+	RPC_S_NO_ENDPOINT_FOUND                                                  error = syscall.Errno(errnoRPC_S_NO_ENDPOINT_FOUND)
+	RPC_S_INVALID_TIMEOUT                                                    error = syscall.Errno(errnoRPC_S_INVALID_TIMEOUT)
+	RPC_S_OBJECT_NOT_FOUND                                                   error = syscall.Errno(errnoRPC_S_OBJECT_NOT_FOUND)
+	RPC_S_ALREADY_REGISTERED                                                 error = syscall.Errno(errnoRPC_S_ALREADY_REGISTERED)
+	RPC_S_TYPE_ALREADY_REGISTERED                                            error = syscall.Errno(errnoRPC_S_TYPE_ALREADY_REGISTERED)
+	RPC_S_ALREADY_LISTENING                                                  error = syscall.Errno(errnoRPC_S_ALREADY_LISTENING)
+	RPC_S_NO_PROTSEQS_REGISTERED                                             error = syscall.Errno(errnoRPC_S_NO_PROTSEQS_REGISTERED)
+	RPC_S_NOT_LISTENING                                                      error = syscall.Errno(errnoRPC_S_NOT_LISTENING)
+	RPC_S_UNKNOWN_MGR_TYPE                                                   error = syscall.Errno(errnoRPC_S_UNKNOWN_MGR_TYPE)
+	RPC_S_UNKNOWN_IF                                                         error = syscall.Errno(errnoRPC_S_UNKNOWN_IF)
+	RPC_S_NO_BINDINGS                                                        error = syscall.Errno(errnoRPC_S_NO_BINDINGS)
+	RPC_S_NO_PROTSEQS                                                        error = syscall.Errno(errnoRPC_S_NO_PROTSEQS)
+	RPC_S_CANT_CREATE_ENDPOINT                                               error = syscall.Errno(errnoRPC_S_CANT_CREATE_ENDPOINT)
+	RPC_S_OUT_OF_RESOURCES                                                   error = syscall.Errno(errnoRPC_S_OUT_OF_RESOURCES)
+	RPC_S_SERVER_UNAVAILABLE                                                 error = syscall.Errno(errnoRPC_S_SERVER_UNAVAILABLE)
+	RPC_S_SERVER_TOO_BUSY                                                    error = syscall.Errno(errnoRPC_S_SERVER_TOO_BUSY)
+	RPC_S_INVALID_NETWORK_OPTIONS                                            error = syscall.Errno(errnoRPC_S_INVALID_NETWORK_OPTIONS)
+	RPC_S_NO_CALL_ACTIVE                                                     error = syscall.Errno(errnoRPC_S_NO_CALL_ACTIVE)
+	RPC_S_CALL_FAILED                                                        error = syscall.Errno(errnoRPC_S_CALL_FAILED)
+	RPC_S_CALL_FAILED_DNE                                                    error = syscall.Errno(errnoRPC_S_CALL_FAILED_DNE)
+	RPC_S_PROTOCOL_ERROR                                                     error = syscall.Errno(errnoRPC_S_PROTOCOL_ERROR)
+	RPC_S_PROXY_ACCESS_DENIED                                                error = syscall.Errno(errnoRPC_S_PROXY_ACCESS_DENIED)
+	RPC_S_UNSUPPORTED_TRANS_SYN                                              error = syscall.Errno(errnoRPC_S_UNSUPPORTED_TRANS_SYN)
+	RPC_S_UNSUPPORTED_TYPE                                                   error = syscall.Errno(errnoRPC_S_UNSUPPORTED_TYPE)
+	RPC_S_INVALID_TAG                                                        error = syscall.Errno(errnoRPC_S_INVALID_TAG)
+	RPC_S_INVALID_BOUND                                                      error = syscall.Errno(errnoRPC_S_INVALID_BOUND)
+	RPC_S_NO_ENTRY_NAME                                                      error = syscall.Errno(errnoRPC_S_NO_ENTRY_NAME)
+	RPC_S_INVALID_NAME_SYNTAX                                                error = syscall.Errno(errnoRPC_S_INVALID_NAME_SYNTAX)
+	RPC_S_UNSUPPORTED_NAME_SYNTAX                                            error = syscall.Errno(errnoRPC_S_UNSUPPORTED_NAME_SYNTAX)
+	RPC_S_UUID_NO_ADDRESS                                                    error = syscall.Errno(errnoRPC_S_UUID_NO_ADDRESS)
+	RPC_S_DUPLICATE_ENDPOINT                                                 error = syscall.Errno(errnoRPC_S_DUPLICATE_ENDPOINT)
+	RPC_S_UNKNOWN_AUTHN_TYPE                                                 error = syscall.Errno(errnoRPC_S_UNKNOWN_AUTHN_TYPE)
+	RPC_S_MAX_CALLS_TOO_SMALL                                                error = syscall.Errno(errnoRPC_S_MAX_CALLS_TOO_SMALL)
+	RPC_S_STRING_TOO_LONG                                                    error = syscall.Errno(errnoRPC_S_STRING_TOO_LONG)
+	RPC_S_PROTSEQ_NOT_FOUND                                                  error = syscall.Errno(errnoRPC_S_PROTSEQ_NOT_FOUND)
+	RPC_S_PROCNUM_OUT_OF_RANGE                                               error = syscall.Errno(errnoRPC_S_PROCNUM_OUT_OF_RANGE)
+	RPC_S_BINDING_HAS_NO_AUTH                                                error = syscall.Errno(errnoRPC_S_BINDING_HAS_NO_AUTH)
+	RPC_S_UNKNOWN_AUTHN_SERVICE                                              error = syscall.Errno(errnoRPC_S_UNKNOWN_AUTHN_SERVICE)
+	RPC_S_UNKNOWN_AUTHN_LEVEL                                                error = syscall.Errno(errnoRPC_S_UNKNOWN_AUTHN_LEVEL)
+	RPC_S_INVALID_AUTH_IDENTITY                                              error = syscall.Errno(errnoRPC_S_INVALID_AUTH_IDENTITY)
+	RPC_S_UNKNOWN_AUTHZ_SERVICE                                              error = syscall.Errno(errnoRPC_S_UNKNOWN_AUTHZ_SERVICE)
+	EPT_S_INVALID_ENTRY                                                      error = syscall.Errno(errnoEPT_S_INVALID_ENTRY)
+	EPT_S_CANT_PERFORM_OP                                                    error = syscall.Errno(errnoEPT_S_CANT_PERFORM_OP)
+	EPT_S_NOT_REGISTERED                                                     error = syscall.Errno(errnoEPT_S_NOT_REGISTERED)
+	RPC_S_NOTHING_TO_EXPORT                                                  error = syscall.Errno(errnoRPC_S_NOTHING_TO_EXPORT)
+	RPC_S_INCOMPLETE_NAME                                                    error = syscall.Errno(errnoRPC_S_INCOMPLETE_NAME)
+	RPC_S_INVALID_VERS_OPTION                                                error = syscall.Errno(errnoRPC_S_INVALID_VERS_OPTION)
+	RPC_S_NO_MORE_MEMBERS                                                    error = syscall.Errno(errnoRPC_S_NO_MORE_MEMBERS)
+	RPC_S_NOT_ALL_OBJS_UNEXPORTED                                            error = syscall.Errno(errnoRPC_S_NOT_ALL_OBJS_UNEXPORTED)
+	RPC_S_INTERFACE_NOT_FOUND                                                error = syscall.Errno(errnoRPC_S_INTERFACE_NOT_FOUND)
+	RPC_S_ENTRY_ALREADY_EXISTS                                               error = syscall.Errno(errnoRPC_S_ENTRY_ALREADY_EXISTS)
+	RPC_S_ENTRY_NOT_FOUND                                                    error = syscall.Errno(errnoRPC_S_ENTRY_NOT_FOUND)
+	RPC_S_NAME_SERVICE_UNAVAILABLE                                           error = syscall.Errno(errnoRPC_S_NAME_SERVICE_UNAVAILABLE)
+	RPC_S_INVALID_NAF_ID                                                     error = syscall.Errno(errnoRPC_S_INVALID_NAF_ID)
+	RPC_S_CANNOT_SUPPORT                                                     error = syscall.Errno(errnoRPC_S_CANNOT_SUPPORT)
+	RPC_S_NO_CONTEXT_AVAILABLE                                               error = syscall.Errno(errnoRPC_S_NO_CONTEXT_AVAILABLE)
+	RPC_S_INTERNAL_ERROR                                                     error = syscall.Errno(errnoRPC_S_INTERNAL_ERROR)
+	RPC_S_ZERO_DIVIDE                                                        error = syscall.Errno(errnoRPC_S_ZERO_DIVIDE)
+	RPC_S_ADDRESS_ERROR                                                      error = syscall.Errno(errnoRPC_S_ADDRESS_ERROR)
+	RPC_S_FP_DIV_ZERO                                                        error = syscall.Errno(errnoRPC_S_FP_DIV_ZERO)
+	RPC_S_FP_UNDERFLOW                                                       error = syscall.Errno(errnoRPC_S_FP_UNDERFLOW)
+	RPC_S_FP_OVERFLOW                                                        error = syscall.Errno(errnoRPC_S_FP_OVERFLOW)
+	RPC_X_NO_MORE_ENTRIES                                                    error = syscall.Errno(errnoRPC_X_NO_MORE_ENTRIES)
+	RPC_X_SS_CHAR_TRANS_OPEN_FAIL                                            error = syscall.Errno(errnoRPC_X_SS_CHAR_TRANS_OPEN_FAIL)
+	RPC_X_SS_CHAR_TRANS_SHORT_FILE                                           error = syscall.Errno(errnoRPC_X_SS_CHAR_TRANS_SHORT_FILE)
+	RPC_X_SS_IN_NULL_CONTEXT                                                 error = syscall.Errno(errnoRPC_X_SS_IN_NULL_CONTEXT)
+	RPC_X_SS_CONTEXT_DAMAGED                                                 error = syscall.Errno(errnoRPC_X_SS_CONTEXT_DAMAGED)
+	RPC_X_SS_HANDLES_MISMATCH                                                error = syscall.Errno(errnoRPC_X_SS_HANDLES_MISMATCH)
+	RPC_X_SS_CANNOT_GET_CALL_HANDLE                                          error = syscall.Errno(errnoRPC_X_SS_CANNOT_GET_CALL_HANDLE)
+	RPC_X_NULL_REF_POINTER                                                   error = syscall.Errno(errnoRPC_X_NULL_REF_POINTER)
+	RPC_X_ENUM_VALUE_OUT_OF_RANGE                                            error = syscall.Errno(errnoRPC_X_ENUM_VALUE_OUT_OF_RANGE)
+	RPC_X_BYTE_COUNT_TOO_SMALL                                               error = syscall.Errno(errnoRPC_X_BYTE_COUNT_TOO_SMALL)
+	RPC_X_BAD_STUB_DATA                                                      error = syscall.Errno(errnoRPC_X_BAD_STUB_DATA)
+	RPC_S_CALL_CANCELLED                                                     error = syscall.Errno(errnoRPC_S_CALL_CANCELLED)
+	RPC_S_CALL_IN_PROGRESS                                                   error = syscall.Errno(errnoRPC_S_CALL_IN_PROGRESS)
+	RPC_S_NO_MORE_BINDINGS                                                   error = syscall.Errno(errnoRPC_S_NO_MORE_BINDINGS)
+	RPC_S_GROUP_MEMBER_NOT_FOUND                                             error = syscall.Errno(errnoRPC_S_GROUP_MEMBER_NOT_FOUND)
+	EPT_S_CANT_CREATE                                                        error = syscall.Errno(errnoEPT_S_CANT_CREATE)
+	RPC_S_INVALID_OBJECT                                                     error = syscall.Errno(errnoRPC_S_INVALID_OBJECT)
+	RPC_S_SEC_PKG_ERROR                                                      error = syscall.Errno(errnoRPC_S_SEC_PKG_ERROR)
+	RPC_S_NOT_CANCELLED                                                      error = syscall.Errno(errnoRPC_S_NOT_CANCELLED)
+	ERROR_ACCOUNT_LOCKED_OUT                                                 error = syscall.Errno(errnoERROR_ACCOUNT_LOCKED_OUT)
+	ERROR_DEPENDENT_RESOURCE_EXISTS                                          error = syscall.Errno(errnoERROR_DEPENDENT_RESOURCE_EXISTS)
+	ERROR_DEPENDENCY_NOT_FOUND                                               error = syscall.Errno(errnoERROR_DEPENDENCY_NOT_FOUND)
+	ERROR_DEPENDENCY_ALREADY_EXISTS                                          error = syscall.Errno(errnoERROR_DEPENDENCY_ALREADY_EXISTS)
+	ERROR_RESOURCE_NOT_ONLINE                                                error = syscall.Errno(errnoERROR_RESOURCE_NOT_ONLINE)
+	ERROR_HOST_NODE_NOT_AVAILABLE                                            error = syscall.Errno(errnoERROR_HOST_NODE_NOT_AVAILABLE)
+	ERROR_RESOURCE_NOT_AVAILABLE                                             error = syscall.Errno(errnoERROR_RESOURCE_NOT_AVAILABLE)
+	ERROR_RESOURCE_NOT_FOUND                                                 error = syscall.Errno(errnoERROR_RESOURCE_NOT_FOUND)
+	ERROR_SHUTDOWN_CLUSTER                                                   error = syscall.Errno(errnoERROR_SHUTDOWN_CLUSTER)
+	ERROR_CANT_EVICT_ACTIVE_NODE                                             error = syscall.Errno(errnoERROR_CANT_EVICT_ACTIVE_NODE)
+	ERROR_OBJECT_ALREADY_EXISTS                                              error = syscall.Errno(errnoERROR_OBJECT_ALREADY_EXISTS)
+	ERROR_OBJECT_IN_LIST                                                     error = syscall.Errno(errnoERROR_OBJECT_IN_LIST)
+	ERROR_GROUP_NOT_AVAILABLE                                                error = syscall.Errno(errnoERROR_GROUP_NOT_AVAILABLE)
+	ERROR_GROUP_NOT_FOUND                                                    error = syscall.Errno(errnoERROR_GROUP_NOT_FOUND)
+	ERROR_GROUP_NOT_ONLINE                                                   error = syscall.Errno(errnoERROR_GROUP_NOT_ONLINE)
+	ERROR_HOST_NODE_NOT_RESOURCE_OWNER                                       error = syscall.Errno(errnoERROR_HOST_NODE_NOT_RESOURCE_OWNER)
+	ERROR_HOST_NODE_NOT_GROUP_OWNER                                          error = syscall.Errno(errnoERROR_HOST_NODE_NOT_GROUP_OWNER)
+	ERROR_RESMON_CREATE_FAILED                                               error = syscall.Errno(errnoERROR_RESMON_CREATE_FAILED)
+	ERROR_RESMON_ONLINE_FAILED                                               error = syscall.Errno(errnoERROR_RESMON_ONLINE_FAILED)
+	ERROR_RESOURCE_ONLINE                                                    error = syscall.Errno(errnoERROR_RESOURCE_ONLINE)
+	ERROR_QUORUM_RESOURCE                                                    error = syscall.Errno(errnoERROR_QUORUM_RESOURCE)
+	ERROR_NOT_QUORUM_CAPABLE                                                 error = syscall.Errno(errnoERROR_NOT_QUORUM_CAPABLE)
+	ERROR_CLUSTER_SHUTTING_DOWN                                              error = syscall.Errno(errnoERROR_CLUSTER_SHUTTING_DOWN)
+	ERROR_INVALID_STATE                                                      error = syscall.Errno(errnoERROR_INVALID_STATE)
+	ERROR_RESOURCE_PROPERTIES_STORED                                         error = syscall.Errno(errnoERROR_RESOURCE_PROPERTIES_STORED)
+	ERROR_NOT_QUORUM_CLASS                                                   error = syscall.Errno(errnoERROR_NOT_QUORUM_CLASS)
+	ERROR_CORE_RESOURCE                                                      error = syscall.Errno(errnoERROR_CORE_RESOURCE)
+	ERROR_QUORUM_RESOURCE_ONLINE_FAILED                                      error = syscall.Errno(errnoERROR_QUORUM_RESOURCE_ONLINE_FAILED)
+	ERROR_QUORUMLOG_OPEN_FAILED                                              error = syscall.Errno(errnoERROR_QUORUMLOG_OPEN_FAILED)
+	ERROR_CLUSTERLOG_CORRUPT                                                 error = syscall.Errno(errnoERROR_CLUSTERLOG_CORRUPT)
+	ERROR_CLUSTERLOG_RECORD_EXCEEDS_MAXSIZE                                  error = syscall.Errno(errnoERROR_CLUSTERLOG_RECORD_EXCEEDS_MAXSIZE)
+	ERROR_CLUSTERLOG_EXCEEDS_MAXSIZE                                         error = syscall.Errno(errnoERROR_CLUSTERLOG_EXCEEDS_MAXSIZE)
+	ERROR_CLUSTERLOG_CHKPOINT_NOT_FOUND                                      error = syscall.Errno(errnoERROR_CLUSTERLOG_CHKPOINT_NOT_FOUND)
+	ERROR_CLUSTERLOG_NOT_ENOUGH_SPACE                                        error = syscall.Errno(errnoERROR_CLUSTERLOG_NOT_ENOUGH_SPACE)
+	ERROR_QUORUM_OWNER_ALIVE                                                 error = syscall.Errno(errnoERROR_QUORUM_OWNER_ALIVE)
+	ERROR_NETWORK_NOT_AVAILABLE                                              error = syscall.Errno(errnoERROR_NETWORK_NOT_AVAILABLE)
+	ERROR_NODE_NOT_AVAILABLE                                                 error = syscall.Errno(errnoERROR_NODE_NOT_AVAILABLE)
+	ERROR_ALL_NODES_NOT_AVAILABLE                                            error = syscall.Errno(errnoERROR_ALL_NODES_NOT_AVAILABLE)
+	ERROR_RESOURCE_FAILED                                                    error = syscall.Errno(errnoERROR_RESOURCE_FAILED)
+	ERROR_CLUSTER_INVALID_NODE                                               error = syscall.Errno(errnoERROR_CLUSTER_INVALID_NODE)
+	ERROR_CLUSTER_NODE_EXISTS                                                error = syscall.Errno(errnoERROR_CLUSTER_NODE_EXISTS)
+	ERROR_CLUSTER_JOIN_IN_PROGRESS                                           error = syscall.Errno(errnoERROR_CLUSTER_JOIN_IN_PROGRESS)
+	ERROR_CLUSTER_NODE_NOT_FOUND                                             error = syscall.Errno(errnoERROR_CLUSTER_NODE_NOT_FOUND)
+	ERROR_CLUSTER_LOCAL_NODE_NOT_FOUND                                       error = syscall.Errno(errnoERROR_CLUSTER_LOCAL_NODE_NOT_FOUND)
+	ERROR_CLUSTER_NETWORK_EXISTS                                             error = syscall.Errno(errnoERROR_CLUSTER_NETWORK_EXISTS)
+	ERROR_CLUSTER_NETWORK_NOT_FOUND                                          error = syscall.Errno(errnoERROR_CLUSTER_NETWORK_NOT_FOUND)
+	ERROR_CLUSTER_NETINTERFACE_EXISTS                                        error = syscall.Errno(errnoERROR_CLUSTER_NETINTERFACE_EXISTS)
+	ERROR_CLUSTER_NETINTERFACE_NOT_FOUND                                     error = syscall.Errno(errnoERROR_CLUSTER_NETINTERFACE_NOT_FOUND)
+	ERROR_CLUSTER_INVALID_REQUEST                                            error = syscall.Errno(errnoERROR_CLUSTER_INVALID_REQUEST)
+	ERROR_CLUSTER_INVALID_NETWORK_PROVIDER                                   error = syscall.Errno(errnoERROR_CLUSTER_INVALID_NETWORK_PROVIDER)
+	ERROR_CLUSTER_NODE_DOWN                                                  error = syscall.Errno(errnoERROR_CLUSTER_NODE_DOWN)
+	ERROR_CLUSTER_NODE_UNREACHABLE                                           error = syscall.Errno(errnoERROR_CLUSTER_NODE_UNREACHABLE)
+	ERROR_CLUSTER_NODE_NOT_MEMBER                                            error = syscall.Errno(errnoERROR_CLUSTER_NODE_NOT_MEMBER)
+	ERROR_CLUSTER_JOIN_NOT_IN_PROGRESS                                       error = syscall.Errno(errnoERROR_CLUSTER_JOIN_NOT_IN_PROGRESS)
+	ERROR_CLUSTER_INVALID_NETWORK                                            error = syscall.Errno(errnoERROR_CLUSTER_INVALID_NETWORK)
+	ERROR_CLUSTER_NODE_UP                                                    error = syscall.Errno(errnoERROR_CLUSTER_NODE_UP)
+	ERROR_CLUSTER_IPADDR_IN_USE                                              error = syscall.Errno(errnoERROR_CLUSTER_IPADDR_IN_USE)
+	ERROR_CLUSTER_NODE_NOT_PAUSED                                            error = syscall.Errno(errnoERROR_CLUSTER_NODE_NOT_PAUSED)
+	ERROR_CLUSTER_NO_SECURITY_CONTEXT                                        error = syscall.Errno(errnoERROR_CLUSTER_NO_SECURITY_CONTEXT)
+	ERROR_CLUSTER_NETWORK_NOT_INTERNAL                                       error = syscall.Errno(errnoERROR_CLUSTER_NETWORK_NOT_INTERNAL)
+	ERROR_CLUSTER_NODE_ALREADY_UP                                            error = syscall.Errno(errnoERROR_CLUSTER_NODE_ALREADY_UP)
+	ERROR_CLUSTER_NODE_ALREADY_DOWN                                          error = syscall.Errno(errnoERROR_CLUSTER_NODE_ALREADY_DOWN)
+	ERROR_CLUSTER_NETWORK_ALREADY_ONLINE                                     error = syscall.Errno(errnoERROR_CLUSTER_NETWORK_ALREADY_ONLINE)
+	ERROR_CLUSTER_NETWORK_ALREADY_OFFLINE                                    error = syscall.Errno(errnoERROR_CLUSTER_NETWORK_ALREADY_OFFLINE)
+	ERROR_CLUSTER_NODE_ALREADY_MEMBER                                        error = syscall.Errno(errnoERROR_CLUSTER_NODE_ALREADY_MEMBER)
+	ERROR_CLUSTER_LAST_INTERNAL_NETWORK                                      error = syscall.Errno(errnoERROR_CLUSTER_LAST_INTERNAL_NETWORK)
+	ERROR_CLUSTER_NETWORK_HAS_DEPENDENTS                                     error = syscall.Errno(errnoERROR_CLUSTER_NETWORK_HAS_DEPENDENTS)
+	ERROR_INVALID_OPERATION_ON_QUORUM                                        error = syscall.Errno(errnoERROR_INVALID_OPERATION_ON_QUORUM)
+	ERROR_DEPENDENCY_NOT_ALLOWED                                             error = syscall.Errno(errnoERROR_DEPENDENCY_NOT_ALLOWED)
+	ERROR_CLUSTER_NODE_PAUSED                                                error = syscall.Errno(errnoERROR_CLUSTER_NODE_PAUSED)
+	ERROR_NODE_CANT_HOST_RESOURCE                                            error = syscall.Errno(errnoERROR_NODE_CANT_HOST_RESOURCE)
+	ERROR_CLUSTER_NODE_NOT_READY                                             error = syscall.Errno(errnoERROR_CLUSTER_NODE_NOT_READY)
+	ERROR_CLUSTER_NODE_SHUTTING_DOWN                                         error = syscall.Errno(errnoERROR_CLUSTER_NODE_SHUTTING_DOWN)
+	ERROR_CLUSTER_JOIN_ABORTED                                               error = syscall.Errno(errnoERROR_CLUSTER_JOIN_ABORTED)
+	ERROR_CLUSTER_INCOMPATIBLE_VERSIONS                                      error = syscall.Errno(errnoERROR_CLUSTER_INCOMPATIBLE_VERSIONS)
+	ERROR_CLUSTER_MAXNUM_OF_RESOURCES_EXCEEDED                               error = syscall.Errno(errnoERROR_CLUSTER_MAXNUM_OF_RESOURCES_EXCEEDED)
+	ERROR_CLUSTER_SYSTEM_CONFIG_CHANGED                                      error = syscall.Errno(errnoERROR_CLUSTER_SYSTEM_CONFIG_CHANGED)
+	ERROR_CLUSTER_RESOURCE_TYPE_NOT_FOUND                                    error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_TYPE_NOT_FOUND)
+	ERROR_CLUSTER_RESTYPE_NOT_SUPPORTED                                      error = syscall.Errno(errnoERROR_CLUSTER_RESTYPE_NOT_SUPPORTED)
+	ERROR_CLUSTER_RESNAME_NOT_FOUND                                          error = syscall.Errno(errnoERROR_CLUSTER_RESNAME_NOT_FOUND)
+	ERROR_CLUSTER_NO_RPC_PACKAGES_REGISTERED                                 error = syscall.Errno(errnoERROR_CLUSTER_NO_RPC_PACKAGES_REGISTERED)
+	ERROR_CLUSTER_OWNER_NOT_IN_PREFLIST                                      error = syscall.Errno(errnoERROR_CLUSTER_OWNER_NOT_IN_PREFLIST)
+	ERROR_CLUSTER_DATABASE_SEQMISMATCH                                       error = syscall.Errno(errnoERROR_CLUSTER_DATABASE_SEQMISMATCH)
+	ERROR_RESMON_INVALID_STATE                                               error = syscall.Errno(errnoERROR_RESMON_INVALID_STATE)
+	ERROR_CLUSTER_GUM_NOT_LOCKER                                             error = syscall.Errno(errnoERROR_CLUSTER_GUM_NOT_LOCKER)
+	ERROR_QUORUM_DISK_NOT_FOUND                                              error = syscall.Errno(errnoERROR_QUORUM_DISK_NOT_FOUND)
+	ERROR_DATABASE_BACKUP_CORRUPT                                            error = syscall.Errno(errnoERROR_DATABASE_BACKUP_CORRUPT)
+	ERROR_CLUSTER_NODE_ALREADY_HAS_DFS_ROOT                                  error = syscall.Errno(errnoERROR_CLUSTER_NODE_ALREADY_HAS_DFS_ROOT)
+	ERROR_RESOURCE_PROPERTY_UNCHANGEABLE                                     error = syscall.Errno(errnoERROR_RESOURCE_PROPERTY_UNCHANGEABLE)
+	ERROR_CLUSTER_MEMBERSHIP_INVALID_STATE                                   error = syscall.Errno(errnoERROR_CLUSTER_MEMBERSHIP_INVALID_STATE)
+	ERROR_CLUSTER_QUORUMLOG_NOT_FOUND                                        error = syscall.Errno(errnoERROR_CLUSTER_QUORUMLOG_NOT_FOUND)
+	ERROR_CLUSTER_MEMBERSHIP_HALT                                            error = syscall.Errno(errnoERROR_CLUSTER_MEMBERSHIP_HALT)
+	ERROR_CLUSTER_INSTANCE_ID_MISMATCH                                       error = syscall.Errno(errnoERROR_CLUSTER_INSTANCE_ID_MISMATCH)
+	ERROR_CLUSTER_NETWORK_NOT_FOUND_FOR_IP                                   error = syscall.Errno(errnoERROR_CLUSTER_NETWORK_NOT_FOUND_FOR_IP)
+	ERROR_CLUSTER_PROPERTY_DATA_TYPE_MISMATCH                                error = syscall.Errno(errnoERROR_CLUSTER_PROPERTY_DATA_TYPE_MISMATCH)
+	ERROR_CLUSTER_EVICT_WITHOUT_CLEANUP                                      error = syscall.Errno(errnoERROR_CLUSTER_EVICT_WITHOUT_CLEANUP)
+	ERROR_CLUSTER_PARAMETER_MISMATCH                                         error = syscall.Errno(errnoERROR_CLUSTER_PARAMETER_MISMATCH)
+	ERROR_NODE_CANNOT_BE_CLUSTERED                                           error = syscall.Errno(errnoERROR_NODE_CANNOT_BE_CLUSTERED)
+	ERROR_CLUSTER_WRONG_OS_VERSION                                           error = syscall.Errno(errnoERROR_CLUSTER_WRONG_OS_VERSION)
+	ERROR_CLUSTER_CANT_CREATE_DUP_CLUSTER_NAME                               error = syscall.Errno(errnoERROR_CLUSTER_CANT_CREATE_DUP_CLUSTER_NAME)
+	ERROR_CLUSCFG_ALREADY_COMMITTED                                          error = syscall.Errno(errnoERROR_CLUSCFG_ALREADY_COMMITTED)
+	ERROR_CLUSCFG_ROLLBACK_FAILED                                            error = syscall.Errno(errnoERROR_CLUSCFG_ROLLBACK_FAILED)
+	ERROR_CLUSCFG_SYSTEM_DISK_DRIVE_LETTER_CONFLICT                          error = syscall.Errno(errnoERROR_CLUSCFG_SYSTEM_DISK_DRIVE_LETTER_CONFLICT)
+	ERROR_CLUSTER_OLD_VERSION                                                error = syscall.Errno(errnoERROR_CLUSTER_OLD_VERSION)
+	ERROR_CLUSTER_MISMATCHED_COMPUTER_ACCT_NAME                              error = syscall.Errno(errnoERROR_CLUSTER_MISMATCHED_COMPUTER_ACCT_NAME)
+	ERROR_CLUSTER_NO_NET_ADAPTERS                                            error = syscall.Errno(errnoERROR_CLUSTER_NO_NET_ADAPTERS)
+	ERROR_CLUSTER_POISONED                                                   error = syscall.Errno(errnoERROR_CLUSTER_POISONED)
+	ERROR_CLUSTER_GROUP_MOVING                                               error = syscall.Errno(errnoERROR_CLUSTER_GROUP_MOVING)
+	ERROR_CLUSTER_RESOURCE_TYPE_BUSY                                         error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_TYPE_BUSY)
+	ERROR_RESOURCE_CALL_TIMED_OUT                                            error = syscall.Errno(errnoERROR_RESOURCE_CALL_TIMED_OUT)
+	ERROR_INVALID_CLUSTER_IPV6_ADDRESS                                       error = syscall.Errno(errnoERROR_INVALID_CLUSTER_IPV6_ADDRESS)
+	ERROR_CLUSTER_INTERNAL_INVALID_FUNCTION                                  error = syscall.Errno(errnoERROR_CLUSTER_INTERNAL_INVALID_FUNCTION)
+	ERROR_CLUSTER_PARAMETER_OUT_OF_BOUNDS                                    error = syscall.Errno(errnoERROR_CLUSTER_PARAMETER_OUT_OF_BOUNDS)
+	ERROR_CLUSTER_PARTIAL_SEND                                               error = syscall.Errno(errnoERROR_CLUSTER_PARTIAL_SEND)
+	ERROR_CLUSTER_REGISTRY_INVALID_FUNCTION                                  error = syscall.Errno(errnoERROR_CLUSTER_REGISTRY_INVALID_FUNCTION)
+	ERROR_CLUSTER_INVALID_STRING_TERMINATION                                 error = syscall.Errno(errnoERROR_CLUSTER_INVALID_STRING_TERMINATION)
+	ERROR_CLUSTER_INVALID_STRING_FORMAT                                      error = syscall.Errno(errnoERROR_CLUSTER_INVALID_STRING_FORMAT)
+	ERROR_CLUSTER_DATABASE_TRANSACTION_IN_PROGRESS                           error = syscall.Errno(errnoERROR_CLUSTER_DATABASE_TRANSACTION_IN_PROGRESS)
+	ERROR_CLUSTER_DATABASE_TRANSACTION_NOT_IN_PROGRESS                       error = syscall.Errno(errnoERROR_CLUSTER_DATABASE_TRANSACTION_NOT_IN_PROGRESS)
+	ERROR_CLUSTER_NULL_DATA                                                  error = syscall.Errno(errnoERROR_CLUSTER_NULL_DATA)
+	ERROR_CLUSTER_PARTIAL_READ                                               error = syscall.Errno(errnoERROR_CLUSTER_PARTIAL_READ)
+	ERROR_CLUSTER_PARTIAL_WRITE                                              error = syscall.Errno(errnoERROR_CLUSTER_PARTIAL_WRITE)
+	ERROR_CLUSTER_CANT_DESERIALIZE_DATA                                      error = syscall.Errno(errnoERROR_CLUSTER_CANT_DESERIALIZE_DATA)
+	ERROR_DEPENDENT_RESOURCE_PROPERTY_CONFLICT                               error = syscall.Errno(errnoERROR_DEPENDENT_RESOURCE_PROPERTY_CONFLICT)
+	ERROR_CLUSTER_NO_QUORUM                                                  error = syscall.Errno(errnoERROR_CLUSTER_NO_QUORUM)
+	ERROR_CLUSTER_INVALID_IPV6_NETWORK                                       error = syscall.Errno(errnoERROR_CLUSTER_INVALID_IPV6_NETWORK)
+	ERROR_CLUSTER_INVALID_IPV6_TUNNEL_NETWORK                                error = syscall.Errno(errnoERROR_CLUSTER_INVALID_IPV6_TUNNEL_NETWORK)
+	ERROR_QUORUM_NOT_ALLOWED_IN_THIS_GROUP                                   error = syscall.Errno(errnoERROR_QUORUM_NOT_ALLOWED_IN_THIS_GROUP)
+	ERROR_DEPENDENCY_TREE_TOO_COMPLEX                                        error = syscall.Errno(errnoERROR_DEPENDENCY_TREE_TOO_COMPLEX)
+	ERROR_EXCEPTION_IN_RESOURCE_CALL                                         error = syscall.Errno(errnoERROR_EXCEPTION_IN_RESOURCE_CALL)
+	ERROR_CLUSTER_RHS_FAILED_INITIALIZATION                                  error = syscall.Errno(errnoERROR_CLUSTER_RHS_FAILED_INITIALIZATION)
+	ERROR_CLUSTER_NOT_INSTALLED                                              error = syscall.Errno(errnoERROR_CLUSTER_NOT_INSTALLED)
+	ERROR_CLUSTER_RESOURCES_MUST_BE_ONLINE_ON_THE_SAME_NODE                  error = syscall.Errno(errnoERROR_CLUSTER_RESOURCES_MUST_BE_ONLINE_ON_THE_SAME_NODE)
+	ERROR_CLUSTER_MAX_NODES_IN_CLUSTER                                       error = syscall.Errno(errnoERROR_CLUSTER_MAX_NODES_IN_CLUSTER)
+	ERROR_CLUSTER_TOO_MANY_NODES                                             error = syscall.Errno(errnoERROR_CLUSTER_TOO_MANY_NODES)
+	ERROR_CLUSTER_OBJECT_ALREADY_USED                                        error = syscall.Errno(errnoERROR_CLUSTER_OBJECT_ALREADY_USED)
+	ERROR_NONCORE_GROUPS_FOUND                                               error = syscall.Errno(errnoERROR_NONCORE_GROUPS_FOUND)
+	ERROR_FILE_SHARE_RESOURCE_CONFLICT                                       error = syscall.Errno(errnoERROR_FILE_SHARE_RESOURCE_CONFLICT)
+	ERROR_CLUSTER_EVICT_INVALID_REQUEST                                      error = syscall.Errno(errnoERROR_CLUSTER_EVICT_INVALID_REQUEST)
+	ERROR_CLUSTER_SINGLETON_RESOURCE                                         error = syscall.Errno(errnoERROR_CLUSTER_SINGLETON_RESOURCE)
+	ERROR_CLUSTER_GROUP_SINGLETON_RESOURCE                                   error = syscall.Errno(errnoERROR_CLUSTER_GROUP_SINGLETON_RESOURCE)
+	ERROR_CLUSTER_RESOURCE_PROVIDER_FAILED                                   error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_PROVIDER_FAILED)
+	ERROR_CLUSTER_RESOURCE_CONFIGURATION_ERROR                               error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_CONFIGURATION_ERROR)
+	ERROR_CLUSTER_GROUP_BUSY                                                 error = syscall.Errno(errnoERROR_CLUSTER_GROUP_BUSY)
+	ERROR_CLUSTER_NOT_SHARED_VOLUME                                          error = syscall.Errno(errnoERROR_CLUSTER_NOT_SHARED_VOLUME)
+	ERROR_CLUSTER_INVALID_SECURITY_DESCRIPTOR                                error = syscall.Errno(errnoERROR_CLUSTER_INVALID_SECURITY_DESCRIPTOR)
+	ERROR_CLUSTER_SHARED_VOLUMES_IN_USE                                      error = syscall.Errno(errnoERROR_CLUSTER_SHARED_VOLUMES_IN_USE)
+	ERROR_CLUSTER_USE_SHARED_VOLUMES_API                                     error = syscall.Errno(errnoERROR_CLUSTER_USE_SHARED_VOLUMES_API)
+	ERROR_CLUSTER_BACKUP_IN_PROGRESS                                         error = syscall.Errno(errnoERROR_CLUSTER_BACKUP_IN_PROGRESS)
+	ERROR_NON_CSV_PATH                                                       error = syscall.Errno(errnoERROR_NON_CSV_PATH)
+	ERROR_CSV_VOLUME_NOT_LOCAL                                               error = syscall.Errno(errnoERROR_CSV_VOLUME_NOT_LOCAL)
+	ERROR_CLUSTER_WATCHDOG_TERMINATING                                       error = syscall.Errno(errnoERROR_CLUSTER_WATCHDOG_TERMINATING)
+	ERROR_CLUSTER_RESOURCE_VETOED_MOVE_INCOMPATIBLE_NODES                    error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_INCOMPATIBLE_NODES)
+	ERROR_CLUSTER_INVALID_NODE_WEIGHT                                        error = syscall.Errno(errnoERROR_CLUSTER_INVALID_NODE_WEIGHT)
+	ERROR_CLUSTER_RESOURCE_VETOED_CALL                                       error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_VETOED_CALL)
+	ERROR_RESMON_SYSTEM_RESOURCES_LACKING                                    error = syscall.Errno(errnoERROR_RESMON_SYSTEM_RESOURCES_LACKING)
+	ERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_DESTINATION   error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_DESTINATION)
+	ERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_SOURCE        error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_SOURCE)
+	ERROR_CLUSTER_GROUP_QUEUED                                               error = syscall.Errno(errnoERROR_CLUSTER_GROUP_QUEUED)
+	ERROR_CLUSTER_RESOURCE_LOCKED_STATUS                                     error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_LOCKED_STATUS)
+	ERROR_CLUSTER_SHARED_VOLUME_FAILOVER_NOT_ALLOWED                         error = syscall.Errno(errnoERROR_CLUSTER_SHARED_VOLUME_FAILOVER_NOT_ALLOWED)
+	ERROR_CLUSTER_NODE_DRAIN_IN_PROGRESS                                     error = syscall.Errno(errnoERROR_CLUSTER_NODE_DRAIN_IN_PROGRESS)
+	ERROR_CLUSTER_DISK_NOT_CONNECTED                                         error = syscall.Errno(errnoERROR_CLUSTER_DISK_NOT_CONNECTED)
+	ERROR_DISK_NOT_CSV_CAPABLE                                               error = syscall.Errno(errnoERROR_DISK_NOT_CSV_CAPABLE)
+	ERROR_RESOURCE_NOT_IN_AVAILABLE_STORAGE                                  error = syscall.Errno(errnoERROR_RESOURCE_NOT_IN_AVAILABLE_STORAGE)
+	ERROR_CLUSTER_SHARED_VOLUME_REDIRECTED                                   error = syscall.Errno(errnoERROR_CLUSTER_SHARED_VOLUME_REDIRECTED)
+	ERROR_CLUSTER_SHARED_VOLUME_NOT_REDIRECTED                               error = syscall.Errno(errnoERROR_CLUSTER_SHARED_VOLUME_NOT_REDIRECTED)
+	ERROR_CLUSTER_CANNOT_RETURN_PROPERTIES                                   error = syscall.Errno(errnoERROR_CLUSTER_CANNOT_RETURN_PROPERTIES)
+	ERROR_CLUSTER_RESOURCE_CONTAINS_UNSUPPORTED_DIFF_AREA_FOR_SHARED_VOLUMES error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_CONTAINS_UNSUPPORTED_DIFF_AREA_FOR_SHARED_VOLUMES)
+	ERROR_CLUSTER_RESOURCE_IS_IN_MAINTENANCE_MODE                            error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_IS_IN_MAINTENANCE_MODE)
+	ERROR_CLUSTER_AFFINITY_CONFLICT                                          error = syscall.Errno(errnoERROR_CLUSTER_AFFINITY_CONFLICT)
+	ERROR_CLUSTER_RESOURCE_IS_REPLICA_VIRTUAL_MACHINE                        error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_IS_REPLICA_VIRTUAL_MACHINE)
+	ERROR_CLUSTER_UPGRADE_INCOMPATIBLE_VERSIONS                              error = syscall.Errno(errnoERROR_CLUSTER_UPGRADE_INCOMPATIBLE_VERSIONS)
+	ERROR_CLUSTER_UPGRADE_FIX_QUORUM_NOT_SUPPORTED                           error = syscall.Errno(errnoERROR_CLUSTER_UPGRADE_FIX_QUORUM_NOT_SUPPORTED)
+	ERROR_CLUSTER_UPGRADE_RESTART_REQUIRED                                   error = syscall.Errno(errnoERROR_CLUSTER_UPGRADE_RESTART_REQUIRED)
+	ERROR_CLUSTER_UPGRADE_IN_PROGRESS                                        error = syscall.Errno(errnoERROR_CLUSTER_UPGRADE_IN_PROGRESS)
+	ERROR_CLUSTER_UPGRADE_INCOMPLETE                                         error = syscall.Errno(errnoERROR_CLUSTER_UPGRADE_INCOMPLETE)
+	ERROR_CLUSTER_NODE_IN_GRACE_PERIOD                                       error = syscall.Errno(errnoERROR_CLUSTER_NODE_IN_GRACE_PERIOD)
+	ERROR_CLUSTER_CSV_IO_PAUSE_TIMEOUT                                       error = syscall.Errno(errnoERROR_CLUSTER_CSV_IO_PAUSE_TIMEOUT)
+	ERROR_NODE_NOT_ACTIVE_CLUSTER_MEMBER                                     error = syscall.Errno(errnoERROR_NODE_NOT_ACTIVE_CLUSTER_MEMBER)
+	ERROR_CLUSTER_RESOURCE_NOT_MONITORED                                     error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_NOT_MONITORED)
+	ERROR_CLUSTER_RESOURCE_DOES_NOT_SUPPORT_UNMONITORED                      error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_DOES_NOT_SUPPORT_UNMONITORED)
+	ERROR_CLUSTER_RESOURCE_IS_REPLICATED                                     error = syscall.Errno(errnoERROR_CLUSTER_RESOURCE_IS_REPLICATED)
+	ERROR_CLUSTER_NODE_ISOLATED                                              error = syscall.Errno(errnoERROR_CLUSTER_NODE_ISOLATED)
+	ERROR_CLUSTER_NODE_QUARANTINED                                           error = syscall.Errno(errnoERROR_CLUSTER_NODE_QUARANTINED)
+	ERROR_CLUSTER_DATABASE_UPDATE_CONDITION_FAILED                           error = syscall.Errno(errnoERROR_CLUSTER_DATABASE_UPDATE_CONDITION_FAILED)
+	ERROR_CLUSTER_SPACE_DEGRADED                                             error = syscall.Errno(errnoERROR_CLUSTER_SPACE_DEGRADED)
+	ERROR_CLUSTER_TOKEN_DELEGATION_NOT_SUPPORTED                             error = syscall.Errno(errnoERROR_CLUSTER_TOKEN_DELEGATION_NOT_SUPPORTED)
+	ERROR_CLUSTER_CSV_INVALID_HANDLE                                         error = syscall.Errno(errnoERROR_CLUSTER_CSV_INVALID_HANDLE)
+	ERROR_CLUSTER_CSV_SUPPORTED_ONLY_ON_COORDINATOR                          error = syscall.Errno(errnoERROR_CLUSTER_CSV_SUPPORTED_ONLY_ON_COORDINATOR)
+	ERROR_GROUPSET_NOT_AVAILABLE                                             error = syscall.Errno(errnoERROR_GROUPSET_NOT_AVAILABLE)
+	ERROR_GROUPSET_NOT_FOUND                                                 error = syscall.Errno(errnoERROR_GROUPSET_NOT_FOUND)
+	ERROR_GROUPSET_CANT_PROVIDE                                              error = syscall.Errno(errnoERROR_GROUPSET_CANT_PROVIDE)
+	ERROR_CLUSTER_FAULT_DOMAIN_PARENT_NOT_FOUND                              error = syscall.Errno(errnoERROR_CLUSTER_FAULT_DOMAIN_PARENT_NOT_FOUND)
+	ERROR_CLUSTER_FAULT_DOMAIN_INVALID_HIERARCHY                             error = syscall.Errno(errnoERROR_CLUSTER_FAULT_DOMAIN_INVALID_HIERARCHY)
+	ERROR_CLUSTER_FAULT_DOMAIN_FAILED_S2D_VALIDATION                         error = syscall.Errno(errnoERROR_CLUSTER_FAULT_DOMAIN_FAILED_S2D_VALIDATION)
+	ERROR_CLUSTER_FAULT_DOMAIN_S2D_CONNECTIVITY_LOSS                         error = syscall.Errno(errnoERROR_CLUSTER_FAULT_DOMAIN_S2D_CONNECTIVITY_LOSS)
+	ERROR_CLUSTER_INVALID_INFRASTRUCTURE_FILESERVER_NAME                     error = syscall.Errno(errnoERROR_CLUSTER_INVALID_INFRASTRUCTURE_FILESERVER_NAME)
+	ERROR_CLUSTERSET_MANAGEMENT_CLUSTER_UNREACHABLE                          error = syscall.Errno(errnoERROR_CLUSTERSET_MANAGEMENT_CLUSTER_UNREACHABLE)
+)
+
+var catalog = []catalogEntry{
+	{errnoERROR_INVALID_FUNCTION, "ERROR_INVALID_FUNCTION", CategoryNone, ERROR_INVALID_FUNCTION},
+	{errnoERROR_FILE_NOT_FOUND, "ERROR_FILE_NOT_FOUND", CategoryNotFound, ERROR_FILE_NOT_FOUND},
+	{errnoERROR_PATH_NOT_FOUND, "ERROR_PATH_NOT_FOUND", CategoryNotFound, ERROR_PATH_NOT_FOUND},
+	{errnoERROR_TOO_MANY_OPEN_FILES, "ERROR_TOO_MANY_OPEN_FILES", CategoryTransient, ERROR_TOO_MANY_OPEN_FILES},
+	{errnoERROR_ACCESS_DENIED, "ERROR_ACCESS_DENIED", CategoryAccess, ERROR_ACCESS_DENIED},
+	{errnoERROR_INVALID_HANDLE, "ERROR_INVALID_HANDLE", CategoryNone, ERROR_INVALID_HANDLE},
+	{errnoERROR_NOT_ENOUGH_MEMORY, "ERROR_NOT_ENOUGH_MEMORY", CategoryTransient, ERROR_NOT_ENOUGH_MEMORY},
+	{errnoERROR_BAD_FORMAT, "ERROR_BAD_FORMAT", CategoryNone, ERROR_BAD_FORMAT},
+	{errnoERROR_INVALID_ACCESS, "ERROR_INVALID_ACCESS", CategoryNone, ERROR_INVALID_ACCESS},
+	{errnoERROR_INVALID_DATA, "ERROR_INVALID_DATA", CategoryNone, ERROR_INVALID_DATA},
+	{errnoERROR_OUTOFMEMORY, "ERROR_OUTOFMEMORY", CategoryTransient, ERROR_OUTOFMEMORY},
+	{errnoERROR_NOT_READY, "ERROR_NOT_READY", CategoryTransient, ERROR_NOT_READY},
+	{errnoERROR_GEN_FAILURE, "ERROR_GEN_FAILURE", CategoryNone, ERROR_GEN_FAILURE},
+	{errnoERROR_SHARING_VIOLATION, "ERROR_SHARING_VIOLATION", CategoryTransient, ERROR_SHARING_VIOLATION},
+	{errnoERROR_LOCK_VIOLATION, "ERROR_LOCK_VIOLATION", CategoryTransient, ERROR_LOCK_VIOLATION},
+	{errnoERROR_HANDLE_EOF, "ERROR_HANDLE_EOF", CategoryNone, ERROR_HANDLE_EOF},
+	{errnoERROR_NOT_SUPPORTED, "ERROR_NOT_SUPPORTED", CategoryNone, ERROR_NOT_SUPPORTED},
+	{errnoERROR_REM_NOT_LIST, "ERROR_REM_NOT_LIST", CategoryTransient, ERROR_REM_NOT_LIST},
+	{errnoERROR_BAD_NETPATH, "ERROR_BAD_NETPATH", CategoryNotFound, ERROR_BAD_NETPATH},
+	{errnoERROR_UNEXP_NET_ERR, "ERROR_UNEXP_NET_ERR", CategoryTransient, ERROR_UNEXP_NET_ERR},
+	{errnoERROR_NETNAME_DELETED, "ERROR_NETNAME_DELETED", CategoryTransient, ERROR_NETNAME_DELETED},
+	{errnoERROR_NETWORK_ACCESS_DENIED, "ERROR_NETWORK_ACCESS_DENIED", CategoryAccess, ERROR_NETWORK_ACCESS_DENIED},
+	{errnoERROR_BAD_NET_NAME, "ERROR_BAD_NET_NAME", CategoryNotFound, ERROR_BAD_NET_NAME},
+	{errnoERROR_FILE_EXISTS, "ERROR_FILE_EXISTS", CategoryStateConflict, ERROR_FILE_EXISTS},
+	{errnoERROR_INVALID_PARAMETER, "ERROR_INVALID_PARAMETER", CategoryNone, ERROR_INVALID_PARAMETER},
+	{errnoERROR_BROKEN_PIPE, "ERROR_BROKEN_PIPE", CategoryTransient, ERROR_BROKEN_PIPE},
+	{errnoERROR_BUFFER_OVERFLOW, "ERROR_BUFFER_OVERFLOW", CategoryNone, ERROR_BUFFER_OVERFLOW},
+	{errnoERROR_DISK_FULL, "ERROR_DISK_FULL", CategoryNone, ERROR_DISK_FULL},
+	{errnoERROR_CALL_NOT_IMPLEMENTED, "ERROR_CALL_NOT_IMPLEMENTED", CategoryNone, ERROR_CALL_NOT_IMPLEMENTED},
+	{errnoERROR_SEM_TIMEOUT, "ERROR_SEM_TIMEOUT", CategoryTransient, ERROR_SEM_TIMEOUT},
+	{errnoERROR_INSUFFICIENT_BUFFER, "ERROR_INSUFFICIENT_BUFFER", CategoryNone, ERROR_INSUFFICIENT_BUFFER},
+	{errnoERROR_INVALID_NAME, "ERROR_INVALID_NAME", CategoryNone, ERROR_INVALID_NAME},
+	{errnoERROR_MOD_NOT_FOUND, "ERROR_MOD_NOT_FOUND", CategoryNotFound, ERROR_MOD_NOT_FOUND},
+	{errnoERROR_PROC_NOT_FOUND, "ERROR_PROC_NOT_FOUND", CategoryNotFound, ERROR_PROC_NOT_FOUND},
+	{errnoERROR_DIR_NOT_EMPTY, "ERROR_DIR_NOT_EMPTY", CategoryStateConflict, ERROR_DIR_NOT_EMPTY},
+	{errnoERROR_BUSY, "ERROR_BUSY", CategoryTransient, ERROR_BUSY},
+	{errnoERROR_ALREADY_EXISTS, "ERROR_ALREADY_EXISTS", CategoryStateConflict, ERROR_ALREADY_EXISTS},
+	{errnoERROR_FILENAME_EXCED_RANGE, "ERROR_FILENAME_EXCED_RANGE", CategoryNone, ERROR_FILENAME_EXCED_RANGE},
+	{errnoERROR_MORE_DATA, "ERROR_MORE_DATA", CategoryNone, ERROR_MORE_DATA},
+	{errnoWAIT_TIMEOUT, "WAIT_TIMEOUT", CategoryTransient, WAIT_TIMEOUT},
+	{errnoERROR_NO_MORE_ITEMS, "ERROR_NO_MORE_ITEMS", CategoryNone, ERROR_NO_MORE_ITEMS},
+	{errnoERROR_OPERATION_ABORTED, "ERROR_OPERATION_ABORTED", CategoryNone, ERROR_OPERATION_ABORTED},
+	{errnoERROR_IO_PENDING, "ERROR_IO_PENDING", CategoryNone, ERROR_IO_PENDING},
+	{errnoERROR_INVALID_FLAGS, "ERROR_INVALID_FLAGS", CategoryNone, ERROR_INVALID_FLAGS},
+	{errnoERROR_BADDB, "ERROR_BADDB", CategoryNone, ERROR_BADDB},
+	{errnoERROR_BADKEY, "ERROR_BADKEY", CategoryNone, ERROR_BADKEY},
+	{errnoERROR_CANTOPEN, "ERROR_CANTOPEN", CategoryNone, ERROR_CANTOPEN},
+	{errnoERROR_CANTREAD, "ERROR_CANTREAD", CategoryNone, ERROR_CANTREAD},
+	{errnoERROR_CANTWRITE, "ERROR_CANTWRITE", CategoryNone, ERROR_CANTWRITE},
+	{errnoERROR_REGISTRY_CORRUPT, "ERROR_REGISTRY_CORRUPT", CategoryNone, ERROR_REGISTRY_CORRUPT},
+	{errnoERROR_KEY_DELETED, "ERROR_KEY_DELETED", CategoryNotFound, ERROR_KEY_DELETED},
+	{errnoERROR_DEPENDENT_SERVICES_RUNNING, "ERROR_DEPENDENT_SERVICES_RUNNING", CategoryStateConflict, ERROR_DEPENDENT_SERVICES_RUNNING},
+	{errnoERROR_SERVICE_REQUEST_TIMEOUT, "ERROR_SERVICE_REQUEST_TIMEOUT", CategoryTransient, ERROR_SERVICE_REQUEST_TIMEOUT},
+	{errnoERROR_SERVICE_ALREADY_RUNNING, "ERROR_SERVICE_ALREADY_RUNNING", CategoryStateConflict, ERROR_SERVICE_ALREADY_RUNNING},
+	{errnoERROR_CIRCULAR_DEPENDENCY, "ERROR_CIRCULAR_DEPENDENCY", CategoryStateConflict, ERROR_CIRCULAR_DEPENDENCY},
+	{errnoERROR_SERVICE_DOES_NOT_EXIST, "ERROR_SERVICE_DOES_NOT_EXIST", CategoryNotFound, ERROR_SERVICE_DOES_NOT_EXIST},
+	{errnoERROR_SERVICE_NOT_ACTIVE, "ERROR_SERVICE_NOT_ACTIVE", CategoryStateConflict, ERROR_SERVICE_NOT_ACTIVE},
+	{errnoERROR_SHUTDOWN_IN_PROGRESS, "ERROR_SHUTDOWN_IN_PROGRESS", CategoryTransient, ERROR_SHUTDOWN_IN_PROGRESS},
+	{errnoERROR_NOT_FOUND, "ERROR_NOT_FOUND", CategoryNotFound, ERROR_NOT_FOUND},
+	{errnoERROR_NO_NETWORK, "ERROR_NO_NETWORK", CategoryTransient, ERROR_NO_NETWORK},
+	{errnoERROR_CONNECTION_REFUSED, "ERROR_CONNECTION_REFUSED", CategoryTransient, ERROR_CONNECTION_REFUSED},
+	{errnoERROR_NETWORK_UNREACHABLE, "ERROR_NETWORK_UNREACHABLE", CategoryTransient, ERROR_NETWORK_UNREACHABLE},
+	{errnoERROR_HOST_UNREACHABLE, "ERROR_HOST_UNREACHABLE", CategoryTransient, ERROR_HOST_UNREACHABLE},
+	{errnoERROR_CONNECTION_ABORTED, "ERROR_CONNECTION_ABORTED", CategoryTransient, ERROR_CONNECTION_ABORTED},
+	{errnoERROR_RETRY, "ERROR_RETRY", CategoryTransient, ERROR_RETRY},
+	{errnoERROR_PRIVILEGE_NOT_HELD, "ERROR_PRIVILEGE_NOT_HELD", CategoryAccess, ERROR_PRIVILEGE_NOT_HELD},
+	{errnoERROR_LOGON_FAILURE, "ERROR_LOGON_FAILURE", CategoryAccess, ERROR_LOGON_FAILURE},
+	{errnoERROR_PASSWORD_EXPIRED, "ERROR_PASSWORD_EXPIRED", CategoryAccess, ERROR_PASSWORD_EXPIRED},
+	{errnoERROR_ACCOUNT_DISABLED, "ERROR_ACCOUNT_DISABLED", CategoryAccess, ERROR_ACCOUNT_DISABLED},
+	{errnoERROR_NONE_MAPPED, "ERROR_NONE_MAPPED", CategoryNotFound, ERROR_NONE_MAPPED},
+	{errnoERROR_NO_SYSTEM_RESOURCES, "ERROR_NO_SYSTEM_RESOURCES", CategoryTransient, ERROR_NO_SYSTEM_RESOURCES},
+	{errnoERROR_TIMEOUT, "ERROR_TIMEOUT", CategoryTransient, ERROR_TIMEOUT},
+	{errnoRPC_S_INVALID_STRING_BINDING, "RPC_S_INVALID_STRING_BINDING", CategoryNone, RPC_S_INVALID_STRING_BINDING},
+	{errnoRPC_S_WRONG_KIND_OF_BINDING, "RPC_S_WRONG_KIND_OF_BINDING", CategoryNone, RPC_S_WRONG_KIND_OF_BINDING},
+	{errnoRPC_S_INVALID_BINDING, "RPC_S_INVALID_BINDING", CategoryNone, RPC_S_INVALID_BINDING},
+	{errnoRPC_S_PROTSEQ_NOT_SUPPORTED, "RPC_S_PROTSEQ_NOT_SUPPORTED", CategoryNone, RPC_S_PROTSEQ_NOT_SUPPORTED},
+	{errnoRPC_S_INVALID_RPC_PROTSEQ, "RPC_S_INVALID_RPC_PROTSEQ", CategoryNone, RPC_S_INVALID_RPC_PROTSEQ},
+	{errnoRPC_S_INVALID_STRING_UUID, "RPC_S_INVALID_STRING_UUID", CategoryNone, RPC_S_INVALID_STRING_UUID},
+	{errnoRPC_S_INVALID_ENDPOINT_FORMAT, "RPC_S_INVALID_ENDPOINT_FORMAT", CategoryNone, RPC_S_INVALID_ENDPOINT_FORMAT},
+	{errnoRPC_S_INVALID_NET_ADDR, "RPC_S_INVALID_NET_ADDR", CategoryNone, RPC_S_INVALID_NET_ADDR},
+	{errnoRPC_S_NO_ENDPOINT_FOUND, "RPC_S_NO_ENDPOINT_FOUND", CategoryTransient, RPC_S_NO_ENDPOINT_FOUND},
+	{errnoRPC_S_INVALID_TIMEOUT, "RPC_S_INVALID_TIMEOUT", CategoryNone, RPC_S_INVALID_TIMEOUT},
+	{errnoRPC_S_OBJECT_NOT_FOUND, "RPC_S_OBJECT_NOT_FOUND", CategoryNotFound, RPC_S_OBJECT_NOT_FOUND},
+	{errnoRPC_S_ALREADY_REGISTERED, "RPC_S_ALREADY_REGISTERED", CategoryStateConflict, RPC_S_ALREADY_REGISTERED},
+	{errnoRPC_S_TYPE_ALREADY_REGISTERED, "RPC_S_TYPE_ALREADY_REGISTERED", CategoryStateConflict, RPC_S_TYPE_ALREADY_REGISTERED},
+	{errnoRPC_S_ALREADY_LISTENING, "RPC_S_ALREADY_LISTENING", CategoryStateConflict, RPC_S_ALREADY_LISTENING},
+	{errnoRPC_S_NO_PROTSEQS_REGISTERED, "RPC_S_NO_PROTSEQS_REGISTERED", CategoryNone, RPC_S_NO_PROTSEQS_REGISTERED},
+	{errnoRPC_S_NOT_LISTENING, "RPC_S_NOT_LISTENING", CategoryTransient, RPC_S_NOT_LISTENING},
+	{errnoRPC_S_UNKNOWN_MGR_TYPE, "RPC_S_UNKNOWN_MGR_TYPE", CategoryNone, RPC_S_UNKNOWN_MGR_TYPE},
+	{errnoRPC_S_UNKNOWN_IF, "RPC_S_UNKNOWN_IF", CategoryNotFound, RPC_S_UNKNOWN_IF},
+	{errnoRPC_S_NO_BINDINGS, "RPC_S_NO_BINDINGS", CategoryNone, RPC_S_NO_BINDINGS},
+	{errnoRPC_S_NO_PROTSEQS, "RPC_S_NO_PROTSEQS", CategoryNone, RPC_S_NO_PROTSEQS},
+	{errnoRPC_S_CANT_CREATE_ENDPOINT, "RPC_S_CANT_CREATE_ENDPOINT", CategoryNone, RPC_S_CANT_CREATE_ENDPOINT},
+	{errnoRPC_S_OUT_OF_RESOURCES, "RPC_S_OUT_OF_RESOURCES", CategoryTransient, RPC_S_OUT_OF_RESOURCES},
+	{errnoRPC_S_SERVER_UNAVAILABLE, "RPC_S_SERVER_UNAVAILABLE", CategoryTransient, RPC_S_SERVER_UNAVAILABLE},
+	{errnoRPC_S_SERVER_TOO_BUSY, "RPC_S_SERVER_TOO_BUSY", CategoryTransient, RPC_S_SERVER_TOO_BUSY},
+	{errnoRPC_S_INVALID_NETWORK_OPTIONS, "RPC_S_INVALID_NETWORK_OPTIONS", CategoryNone, RPC_S_INVALID_NETWORK_OPTIONS},
+	{errnoRPC_S_NO_CALL_ACTIVE, "RPC_S_NO_CALL_ACTIVE", CategoryNone, RPC_S_NO_CALL_ACTIVE},
+	{errnoRPC_S_CALL_FAILED, "RPC_S_CALL_FAILED", CategoryTransient, RPC_S_CALL_FAILED},
+	{errnoRPC_S_CALL_FAILED_DNE, "RPC_S_CALL_FAILED_DNE", CategoryTransient, RPC_S_CALL_FAILED_DNE},
+	{errnoRPC_S_PROTOCOL_ERROR, "RPC_S_PROTOCOL_ERROR", CategoryNone, RPC_S_PROTOCOL_ERROR},
+	{errnoRPC_S_PROXY_ACCESS_DENIED, "RPC_S_PROXY_ACCESS_DENIED", CategoryAccess, RPC_S_PROXY_ACCESS_DENIED},
+	{errnoRPC_S_UNSUPPORTED_TRANS_SYN, "RPC_S_UNSUPPORTED_TRANS_SYN", CategoryNone, RPC_S_UNSUPPORTED_TRANS_SYN},
+	{errnoRPC_S_UNSUPPORTED_TYPE, "RPC_S_UNSUPPORTED_TYPE", CategoryNone, RPC_S_UNSUPPORTED_TYPE},
+	{errnoRPC_S_INVALID_TAG, "RPC_S_INVALID_TAG", CategoryNone, RPC_S_INVALID_TAG},
+	{errnoRPC_S_INVALID_BOUND, "RPC_S_INVALID_BOUND", CategoryNone, RPC_S_INVALID_BOUND},
+	{errnoRPC_S_NO_ENTRY_NAME, "RPC_S_NO_ENTRY_NAME", CategoryNone, RPC_S_NO_ENTRY_NAME},
+	{errnoRPC_S_INVALID_NAME_SYNTAX, "RPC_S_INVALID_NAME_SYNTAX", CategoryNone, RPC_S_INVALID_NAME_SYNTAX},
+	{errnoRPC_S_UNSUPPORTED_NAME_SYNTAX, "RPC_S_UNSUPPORTED_NAME_SYNTAX", CategoryNone, RPC_S_UNSUPPORTED_NAME_SYNTAX},
+	{errnoRPC_S_UUID_NO_ADDRESS, "RPC_S_UUID_NO_ADDRESS", CategoryNone, RPC_S_UUID_NO_ADDRESS},
+	{errnoRPC_S_DUPLICATE_ENDPOINT, "RPC_S_DUPLICATE_ENDPOINT", CategoryStateConflict, RPC_S_DUPLICATE_ENDPOINT},
+	{errnoRPC_S_UNKNOWN_AUTHN_TYPE, "RPC_S_UNKNOWN_AUTHN_TYPE", CategoryNone, RPC_S_UNKNOWN_AUTHN_TYPE},
+	{errnoRPC_S_MAX_CALLS_TOO_SMALL, "RPC_S_MAX_CALLS_TOO_SMALL", CategoryNone, RPC_S_MAX_CALLS_TOO_SMALL},
+	{errnoRPC_S_STRING_TOO_LONG, "RPC_S_STRING_TOO_LONG", CategoryNone, RPC_S_STRING_TOO_LONG},
+	{errnoRPC_S_PROTSEQ_NOT_FOUND, "RPC_S_PROTSEQ_NOT_FOUND", CategoryNotFound, RPC_S_PROTSEQ_NOT_FOUND},
+	{errnoRPC_S_PROCNUM_OUT_OF_RANGE, "RPC_S_PROCNUM_OUT_OF_RANGE", CategoryNone, RPC_S_PROCNUM_OUT_OF_RANGE},
+	{errnoRPC_S_BINDING_HAS_NO_AUTH, "RPC_S_BINDING_HAS_NO_AUTH", CategoryAccess, RPC_S_BINDING_HAS_NO_AUTH},
+	{errnoRPC_S_UNKNOWN_AUTHN_SERVICE, "RPC_S_UNKNOWN_AUTHN_SERVICE", CategoryNone, RPC_S_UNKNOWN_AUTHN_SERVICE},
+	{errnoRPC_S_UNKNOWN_AUTHN_LEVEL, "RPC_S_UNKNOWN_AUTHN_LEVEL", CategoryNone, RPC_S_UNKNOWN_AUTHN_LEVEL},
+	{errnoRPC_S_INVALID_AUTH_IDENTITY, "RPC_S_INVALID_AUTH_IDENTITY", CategoryAccess, RPC_S_INVALID_AUTH_IDENTITY},
+	{errnoRPC_S_UNKNOWN_AUTHZ_SERVICE, "RPC_S_UNKNOWN_AUTHZ_SERVICE", CategoryNone, RPC_S_UNKNOWN_AUTHZ_SERVICE},
+	{errnoEPT_S_INVALID_ENTRY, "EPT_S_INVALID_ENTRY", CategoryNone, EPT_S_INVALID_ENTRY},
+	{errnoEPT_S_CANT_PERFORM_OP, "EPT_S_CANT_PERFORM_OP", CategoryNone, EPT_S_CANT_PERFORM_OP},
+	{errnoEPT_S_NOT_REGISTERED, "EPT_S_NOT_REGISTERED", CategoryTransient, EPT_S_NOT_REGISTERED},
+	{errnoRPC_S_NOTHING_TO_EXPORT, "RPC_S_NOTHING_TO_EXPORT", CategoryNone, RPC_S_NOTHING_TO_EXPORT},
+	{errnoRPC_S_INCOMPLETE_NAME, "RPC_S_INCOMPLETE_NAME", CategoryNone, RPC_S_INCOMPLETE_NAME},
+	{errnoRPC_S_INVALID_VERS_OPTION, "RPC_S_INVALID_VERS_OPTION", CategoryNone, RPC_S_INVALID_VERS_OPTION},
+	{errnoRPC_S_NO_MORE_MEMBERS, "RPC_S_NO_MORE_MEMBERS", CategoryNone, RPC_S_NO_MORE_MEMBERS},
+	{errnoRPC_S_NOT_ALL_OBJS_UNEXPORTED, "RPC_S_NOT_ALL_OBJS_UNEXPORTED", CategoryNone, RPC_S_NOT_ALL_OBJS_UNEXPORTED},
+	{errnoRPC_S_INTERFACE_NOT_FOUND, "RPC_S_INTERFACE_NOT_FOUND", CategoryNotFound, RPC_S_INTERFACE_NOT_FOUND},
+	{errnoRPC_S_ENTRY_ALREADY_EXISTS, "RPC_S_ENTRY_ALREADY_EXISTS", CategoryStateConflict, RPC_S_ENTRY_ALREADY_EXISTS},
+	{errnoRPC_S_ENTRY_NOT_FOUND, "RPC_S_ENTRY_NOT_FOUND", CategoryNotFound, RPC_S_ENTRY_NOT_FOUND},
+	{errnoRPC_S_NAME_SERVICE_UNAVAILABLE, "RPC_S_NAME_SERVICE_UNAVAILABLE", CategoryTransient, RPC_S_NAME_SERVICE_UNAVAILABLE},
+	{errnoRPC_S_INVALID_NAF_ID, "RPC_S_INVALID_NAF_ID", CategoryNone, RPC_S_INVALID_NAF_ID},
+	{errnoRPC_S_CANNOT_SUPPORT, "RPC_S_CANNOT_SUPPORT", CategoryNone, RPC_S_CANNOT_SUPPORT},
+	{errnoRPC_S_NO_CONTEXT_AVAILABLE, "RPC_S_NO_CONTEXT_AVAILABLE", CategoryNone, RPC_S_NO_CONTEXT_AVAILABLE},
+	{errnoRPC_S_INTERNAL_ERROR, "RPC_S_INTERNAL_ERROR", CategoryNone, RPC_S_INTERNAL_ERROR},
+	{errnoRPC_S_ZERO_DIVIDE, "RPC_S_ZERO_DIVIDE", CategoryNone, RPC_S_ZERO_DIVIDE},
+	{errnoRPC_S_ADDRESS_ERROR, "RPC_S_ADDRESS_ERROR", CategoryNone, RPC_S_ADDRESS_ERROR},
+	{errnoRPC_S_FP_DIV_ZERO, "RPC_S_FP_DIV_ZERO", CategoryNone, RPC_S_FP_DIV_ZERO},
+	{errnoRPC_S_FP_UNDERFLOW, "RPC_S_FP_UNDERFLOW", CategoryNone, RPC_S_FP_UNDERFLOW},
+	{errnoRPC_S_FP_OVERFLOW, "RPC_S_FP_OVERFLOW", CategoryNone, RPC_S_FP_OVERFLOW},
+	{errnoRPC_X_NO_MORE_ENTRIES, "RPC_X_NO_MORE_ENTRIES", CategoryNone, RPC_X_NO_MORE_ENTRIES},
+	{errnoRPC_X_SS_CHAR_TRANS_OPEN_FAIL, "RPC_X_SS_CHAR_TRANS_OPEN_FAIL", CategoryNone, RPC_X_SS_CHAR_TRANS_OPEN_FAIL},
+	{errnoRPC_X_SS_CHAR_TRANS_SHORT_FILE, "RPC_X_SS_CHAR_TRANS_SHORT_FILE", CategoryNone, RPC_X_SS_CHAR_TRANS_SHORT_FILE},
+	{errnoRPC_X_SS_IN_NULL_CONTEXT, "RPC_X_SS_IN_NULL_CONTEXT", CategoryNone, RPC_X_SS_IN_NULL_CONTEXT},
+	{errnoRPC_X_SS_CONTEXT_DAMAGED, "RPC_X_SS_CONTEXT_DAMAGED", CategoryNone, RPC_X_SS_CONTEXT_DAMAGED},
+	{errnoRPC_X_SS_HANDLES_MISMATCH, "RPC_X_SS_HANDLES_MISMATCH", CategoryNone, RPC_X_SS_HANDLES_MISMATCH},
+	{errnoRPC_X_SS_CANNOT_GET_CALL_HANDLE, "RPC_X_SS_CANNOT_GET_CALL_HANDLE", CategoryNone, RPC_X_SS_CANNOT_GET_CALL_HANDLE},
+	{errnoRPC_X_NULL_REF_POINTER, "RPC_X_NULL_REF_POINTER", CategoryNone, RPC_X_NULL_REF_POINTER},
+	{errnoRPC_X_ENUM_VALUE_OUT_OF_RANGE, "RPC_X_ENUM_VALUE_OUT_OF_RANGE", CategoryNone, RPC_X_ENUM_VALUE_OUT_OF_RANGE},
+	{errnoRPC_X_BYTE_COUNT_TOO_SMALL, "RPC_X_BYTE_COUNT_TOO_SMALL", CategoryNone, RPC_X_BYTE_COUNT_TOO_SMALL},
+	{errnoRPC_X_BAD_STUB_DATA, "RPC_X_BAD_STUB_DATA", CategoryNone, RPC_X_BAD_STUB_DATA},
+	{errnoRPC_S_CALL_CANCELLED, "RPC_S_CALL_CANCELLED", CategoryTransient, RPC_S_CALL_CANCELLED},
+	{errnoRPC_S_CALL_IN_PROGRESS, "RPC_S_CALL_IN_PROGRESS", CategoryStateConflict, RPC_S_CALL_IN_PROGRESS},
+	{errnoRPC_S_NO_MORE_BINDINGS, "RPC_S_NO_MORE_BINDINGS", CategoryNone, RPC_S_NO_MORE_BINDINGS},
+	{errnoRPC_S_GROUP_MEMBER_NOT_FOUND, "RPC_S_GROUP_MEMBER_NOT_FOUND", CategoryNotFound, RPC_S_GROUP_MEMBER_NOT_FOUND},
+	{errnoEPT_S_CANT_CREATE, "EPT_S_CANT_CREATE", CategoryNone, EPT_S_CANT_CREATE},
+	{errnoRPC_S_INVALID_OBJECT, "RPC_S_INVALID_OBJECT", CategoryNone, RPC_S_INVALID_OBJECT},
+	{errnoRPC_S_SEC_PKG_ERROR, "RPC_S_SEC_PKG_ERROR", CategoryAccess, RPC_S_SEC_PKG_ERROR},
+	{errnoRPC_S_NOT_CANCELLED, "RPC_S_NOT_CANCELLED", CategoryNone, RPC_S_NOT_CANCELLED},
+	{errnoERROR_ACCOUNT_LOCKED_OUT, "ERROR_ACCOUNT_LOCKED_OUT", CategoryAccess, ERROR_ACCOUNT_LOCKED_OUT},
+	{errnoERROR_DEPENDENT_RESOURCE_EXISTS, "ERROR_DEPENDENT_RESOURCE_EXISTS", CategoryStateConflict, ERROR_DEPENDENT_RESOURCE_EXISTS},
+	{errnoERROR_DEPENDENCY_NOT_FOUND, "ERROR_DEPENDENCY_NOT_FOUND", CategoryNotFound, ERROR_DEPENDENCY_NOT_FOUND},
+	{errnoERROR_DEPENDENCY_ALREADY_EXISTS, "ERROR_DEPENDENCY_ALREADY_EXISTS", CategoryStateConflict, ERROR_DEPENDENCY_ALREADY_EXISTS},
+	{errnoERROR_RESOURCE_NOT_ONLINE, "ERROR_RESOURCE_NOT_ONLINE", CategoryStateConflict, ERROR_RESOURCE_NOT_ONLINE},
+	{errnoERROR_HOST_NODE_NOT_AVAILABLE, "ERROR_HOST_NODE_NOT_AVAILABLE", CategoryTransient, ERROR_HOST_NODE_NOT_AVAILABLE},
+	{errnoERROR_RESOURCE_NOT_AVAILABLE, "ERROR_RESOURCE_NOT_AVAILABLE", CategoryTransient, ERROR_RESOURCE_NOT_AVAILABLE},
+	{errnoERROR_RESOURCE_NOT_FOUND, "ERROR_RESOURCE_NOT_FOUND", CategoryNotFound, ERROR_RESOURCE_NOT_FOUND},
+	{errnoERROR_SHUTDOWN_CLUSTER, "ERROR_SHUTDOWN_CLUSTER", CategoryTransient, ERROR_SHUTDOWN_CLUSTER},
+	{errnoERROR_CANT_EVICT_ACTIVE_NODE, "ERROR_CANT_EVICT_ACTIVE_NODE", CategoryStateConflict, ERROR_CANT_EVICT_ACTIVE_NODE},
+	{errnoERROR_OBJECT_ALREADY_EXISTS, "ERROR_OBJECT_ALREADY_EXISTS", CategoryStateConflict, ERROR_OBJECT_ALREADY_EXISTS},
+	{errnoERROR_OBJECT_IN_LIST, "ERROR_OBJECT_IN_LIST", CategoryStateConflict, ERROR_OBJECT_IN_LIST},
+	{errnoERROR_GROUP_NOT_AVAILABLE, "ERROR_GROUP_NOT_AVAILABLE", CategoryTransient, ERROR_GROUP_NOT_AVAILABLE},
+	{errnoERROR_GROUP_NOT_FOUND, "ERROR_GROUP_NOT_FOUND", CategoryNotFound, ERROR_GROUP_NOT_FOUND},
+	{errnoERROR_GROUP_NOT_ONLINE, "ERROR_GROUP_NOT_ONLINE", CategoryStateConflict, ERROR_GROUP_NOT_ONLINE},
+	{errnoERROR_HOST_NODE_NOT_RESOURCE_OWNER, "ERROR_HOST_NODE_NOT_RESOURCE_OWNER", CategoryStateConflict, ERROR_HOST_NODE_NOT_RESOURCE_OWNER},
+	{errnoERROR_HOST_NODE_NOT_GROUP_OWNER, "ERROR_HOST_NODE_NOT_GROUP_OWNER", CategoryStateConflict, ERROR_HOST_NODE_NOT_GROUP_OWNER},
+	{errnoERROR_RESMON_CREATE_FAILED, "ERROR_RESMON_CREATE_FAILED", CategoryNone, ERROR_RESMON_CREATE_FAILED},
+	{errnoERROR_RESMON_ONLINE_FAILED, "ERROR_RESMON_ONLINE_FAILED", CategoryNone, ERROR_RESMON_ONLINE_FAILED},
+	{errnoERROR_RESOURCE_ONLINE, "ERROR_RESOURCE_ONLINE", CategoryStateConflict, ERROR_RESOURCE_ONLINE},
+	{errnoERROR_QUORUM_RESOURCE, "ERROR_QUORUM_RESOURCE", CategoryStateConflict, ERROR_QUORUM_RESOURCE},
+	{errnoERROR_NOT_QUORUM_CAPABLE, "ERROR_NOT_QUORUM_CAPABLE", CategoryNone, ERROR_NOT_QUORUM_CAPABLE},
+	{errnoERROR_CLUSTER_SHUTTING_DOWN, "ERROR_CLUSTER_SHUTTING_DOWN", CategoryTransient, ERROR_CLUSTER_SHUTTING_DOWN},
+	{errnoERROR_INVALID_STATE, "ERROR_INVALID_STATE", CategoryStateConflict, ERROR_INVALID_STATE},
+	{errnoERROR_RESOURCE_PROPERTIES_STORED, "ERROR_RESOURCE_PROPERTIES_STORED", CategoryNone, ERROR_RESOURCE_PROPERTIES_STORED},
+	{errnoERROR_NOT_QUORUM_CLASS, "ERROR_NOT_QUORUM_CLASS", CategoryNone, ERROR_NOT_QUORUM_CLASS},
+	{errnoERROR_CORE_RESOURCE, "ERROR_CORE_RESOURCE", CategoryStateConflict, ERROR_CORE_RESOURCE},
+	{errnoERROR_QUORUM_RESOURCE_ONLINE_FAILED, "ERROR_QUORUM_RESOURCE_ONLINE_FAILED", CategoryNone, ERROR_QUORUM_RESOURCE_ONLINE_FAILED},
+	{errnoERROR_QUORUMLOG_OPEN_FAILED, "ERROR_QUORUMLOG_OPEN_FAILED", CategoryNone, ERROR_QUORUMLOG_OPEN_FAILED},
+	{errnoERROR_CLUSTERLOG_CORRUPT, "ERROR_CLUSTERLOG_CORRUPT", CategoryNone, ERROR_CLUSTERLOG_CORRUPT},
+	{errnoERROR_CLUSTERLOG_RECORD_EXCEEDS_MAXSIZE, "ERROR_CLUSTERLOG_RECORD_EXCEEDS_MAXSIZE", CategoryNone, ERROR_CLUSTERLOG_RECORD_EXCEEDS_MAXSIZE},
+	{errnoERROR_CLUSTERLOG_EXCEEDS_MAXSIZE, "ERROR_CLUSTERLOG_EXCEEDS_MAXSIZE", CategoryNone, ERROR_CLUSTERLOG_EXCEEDS_MAXSIZE},
+	{errnoERROR_CLUSTERLOG_CHKPOINT_NOT_FOUND, "ERROR_CLUSTERLOG_CHKPOINT_NOT_FOUND", CategoryNotFound, ERROR_CLUSTERLOG_CHKPOINT_NOT_FOUND},
+	{errnoERROR_CLUSTERLOG_NOT_ENOUGH_SPACE, "ERROR_CLUSTERLOG_NOT_ENOUGH_SPACE", CategoryNone, ERROR_CLUSTERLOG_NOT_ENOUGH_SPACE},
+	{errnoERROR_QUORUM_OWNER_ALIVE, "ERROR_QUORUM_OWNER_ALIVE", CategoryStateConflict, ERROR_QUORUM_OWNER_ALIVE},
+	{errnoERROR_NETWORK_NOT_AVAILABLE, "ERROR_NETWORK_NOT_AVAILABLE", CategoryTransient, ERROR_NETWORK_NOT_AVAILABLE},
+	{errnoERROR_NODE_NOT_AVAILABLE, "ERROR_NODE_NOT_AVAILABLE", CategoryTransient, ERROR_NODE_NOT_AVAILABLE},
+	{errnoERROR_ALL_NODES_NOT_AVAILABLE, "ERROR_ALL_NODES_NOT_AVAILABLE", CategoryTransient, ERROR_ALL_NODES_NOT_AVAILABLE},
+	{errnoERROR_RESOURCE_FAILED, "ERROR_RESOURCE_FAILED", CategoryNone, ERROR_RESOURCE_FAILED},
+	{errnoERROR_CLUSTER_INVALID_NODE, "ERROR_CLUSTER_INVALID_NODE", CategoryNone, ERROR_CLUSTER_INVALID_NODE},
+	{errnoERROR_CLUSTER_NODE_EXISTS, "ERROR_CLUSTER_NODE_EXISTS", CategoryStateConflict, ERROR_CLUSTER_NODE_EXISTS},
+	{errnoERROR_CLUSTER_JOIN_IN_PROGRESS, "ERROR_CLUSTER_JOIN_IN_PROGRESS", CategoryTransient, ERROR_CLUSTER_JOIN_IN_PROGRESS},
+	{errnoERROR_CLUSTER_NODE_NOT_FOUND, "ERROR_CLUSTER_NODE_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_NODE_NOT_FOUND},
+	{errnoERROR_CLUSTER_LOCAL_NODE_NOT_FOUND, "ERROR_CLUSTER_LOCAL_NODE_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_LOCAL_NODE_NOT_FOUND},
+	{errnoERROR_CLUSTER_NETWORK_EXISTS, "ERROR_CLUSTER_NETWORK_EXISTS", CategoryStateConflict, ERROR_CLUSTER_NETWORK_EXISTS},
+	{errnoERROR_CLUSTER_NETWORK_NOT_FOUND, "ERROR_CLUSTER_NETWORK_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_NETWORK_NOT_FOUND},
+	{errnoERROR_CLUSTER_NETINTERFACE_EXISTS, "ERROR_CLUSTER_NETINTERFACE_EXISTS", CategoryStateConflict, ERROR_CLUSTER_NETINTERFACE_EXISTS},
+	{errnoERROR_CLUSTER_NETINTERFACE_NOT_FOUND, "ERROR_CLUSTER_NETINTERFACE_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_NETINTERFACE_NOT_FOUND},
+	{errnoERROR_CLUSTER_INVALID_REQUEST, "ERROR_CLUSTER_INVALID_REQUEST", CategoryNone, ERROR_CLUSTER_INVALID_REQUEST},
+	{errnoERROR_CLUSTER_INVALID_NETWORK_PROVIDER, "ERROR_CLUSTER_INVALID_NETWORK_PROVIDER", CategoryNone, ERROR_CLUSTER_INVALID_NETWORK_PROVIDER},
+	{errnoERROR_CLUSTER_NODE_DOWN, "ERROR_CLUSTER_NODE_DOWN", CategoryTransient, ERROR_CLUSTER_NODE_DOWN},
+	{errnoERROR_CLUSTER_NODE_UNREACHABLE, "ERROR_CLUSTER_NODE_UNREACHABLE", CategoryTransient, ERROR_CLUSTER_NODE_UNREACHABLE},
+	{errnoERROR_CLUSTER_NODE_NOT_MEMBER, "ERROR_CLUSTER_NODE_NOT_MEMBER", CategoryStateConflict, ERROR_CLUSTER_NODE_NOT_MEMBER},
+	{errnoERROR_CLUSTER_JOIN_NOT_IN_PROGRESS, "ERROR_CLUSTER_JOIN_NOT_IN_PROGRESS", CategoryStateConflict, ERROR_CLUSTER_JOIN_NOT_IN_PROGRESS},
+	{errnoERROR_CLUSTER_INVALID_NETWORK, "ERROR_CLUSTER_INVALID_NETWORK", CategoryNone, ERROR_CLUSTER_INVALID_NETWORK},
+	{errnoERROR_CLUSTER_NODE_UP, "ERROR_CLUSTER_NODE_UP", CategoryStateConflict, ERROR_CLUSTER_NODE_UP},
+	{errnoERROR_CLUSTER_IPADDR_IN_USE, "ERROR_CLUSTER_IPADDR_IN_USE", CategoryStateConflict, ERROR_CLUSTER_IPADDR_IN_USE},
+	{errnoERROR_CLUSTER_NODE_NOT_PAUSED, "ERROR_CLUSTER_NODE_NOT_PAUSED", CategoryStateConflict, ERROR_CLUSTER_NODE_NOT_PAUSED},
+	{errnoERROR_CLUSTER_NO_SECURITY_CONTEXT, "ERROR_CLUSTER_NO_SECURITY_CONTEXT", CategoryAccess, ERROR_CLUSTER_NO_SECURITY_CONTEXT},
+	{errnoERROR_CLUSTER_NETWORK_NOT_INTERNAL, "ERROR_CLUSTER_NETWORK_NOT_INTERNAL", CategoryNone, ERROR_CLUSTER_NETWORK_NOT_INTERNAL},
+	{errnoERROR_CLUSTER_NODE_ALREADY_UP, "ERROR_CLUSTER_NODE_ALREADY_UP", CategoryStateConflict, ERROR_CLUSTER_NODE_ALREADY_UP},
+	{errnoERROR_CLUSTER_NODE_ALREADY_DOWN, "ERROR_CLUSTER_NODE_ALREADY_DOWN", CategoryStateConflict, ERROR_CLUSTER_NODE_ALREADY_DOWN},
+	{errnoERROR_CLUSTER_NETWORK_ALREADY_ONLINE, "ERROR_CLUSTER_NETWORK_ALREADY_ONLINE", CategoryStateConflict, ERROR_CLUSTER_NETWORK_ALREADY_ONLINE},
+	{errnoERROR_CLUSTER_NETWORK_ALREADY_OFFLINE, "ERROR_CLUSTER_NETWORK_ALREADY_OFFLINE", CategoryStateConflict, ERROR_CLUSTER_NETWORK_ALREADY_OFFLINE},
+	{errnoERROR_CLUSTER_NODE_ALREADY_MEMBER, "ERROR_CLUSTER_NODE_ALREADY_MEMBER", CategoryStateConflict, ERROR_CLUSTER_NODE_ALREADY_MEMBER},
+	{errnoERROR_CLUSTER_LAST_INTERNAL_NETWORK, "ERROR_CLUSTER_LAST_INTERNAL_NETWORK", CategoryStateConflict, ERROR_CLUSTER_LAST_INTERNAL_NETWORK},
+	{errnoERROR_CLUSTER_NETWORK_HAS_DEPENDENTS, "ERROR_CLUSTER_NETWORK_HAS_DEPENDENTS", CategoryStateConflict, ERROR_CLUSTER_NETWORK_HAS_DEPENDENTS},
+	{errnoERROR_INVALID_OPERATION_ON_QUORUM, "ERROR_INVALID_OPERATION_ON_QUORUM", CategoryStateConflict, ERROR_INVALID_OPERATION_ON_QUORUM},
+	{errnoERROR_DEPENDENCY_NOT_ALLOWED, "ERROR_DEPENDENCY_NOT_ALLOWED", CategoryNone, ERROR_DEPENDENCY_NOT_ALLOWED},
+	{errnoERROR_CLUSTER_NODE_PAUSED, "ERROR_CLUSTER_NODE_PAUSED", CategoryStateConflict, ERROR_CLUSTER_NODE_PAUSED},
+	{errnoERROR_NODE_CANT_HOST_RESOURCE, "ERROR_NODE_CANT_HOST_RESOURCE", CategoryNone, ERROR_NODE_CANT_HOST_RESOURCE},
+	{errnoERROR_CLUSTER_NODE_NOT_READY, "ERROR_CLUSTER_NODE_NOT_READY", CategoryTransient, ERROR_CLUSTER_NODE_NOT_READY},
+	{errnoERROR_CLUSTER_NODE_SHUTTING_DOWN, "ERROR_CLUSTER_NODE_SHUTTING_DOWN", CategoryTransient, ERROR_CLUSTER_NODE_SHUTTING_DOWN},
+	{errnoERROR_CLUSTER_JOIN_ABORTED, "ERROR_CLUSTER_JOIN_ABORTED", CategoryNone, ERROR_CLUSTER_JOIN_ABORTED},
+	{errnoERROR_CLUSTER_INCOMPATIBLE_VERSIONS, "ERROR_CLUSTER_INCOMPATIBLE_VERSIONS", CategoryNone, ERROR_CLUSTER_INCOMPATIBLE_VERSIONS},
+	{errnoERROR_CLUSTER_MAXNUM_OF_RESOURCES_EXCEEDED, "ERROR_CLUSTER_MAXNUM_OF_RESOURCES_EXCEEDED", CategoryNone, ERROR_CLUSTER_MAXNUM_OF_RESOURCES_EXCEEDED},
+	{errnoERROR_CLUSTER_SYSTEM_CONFIG_CHANGED, "ERROR_CLUSTER_SYSTEM_CONFIG_CHANGED", CategoryNone, ERROR_CLUSTER_SYSTEM_CONFIG_CHANGED},
+	{errnoERROR_CLUSTER_RESOURCE_TYPE_NOT_FOUND, "ERROR_CLUSTER_RESOURCE_TYPE_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_RESOURCE_TYPE_NOT_FOUND},
+	{errnoERROR_CLUSTER_RESTYPE_NOT_SUPPORTED, "ERROR_CLUSTER_RESTYPE_NOT_SUPPORTED", CategoryNone, ERROR_CLUSTER_RESTYPE_NOT_SUPPORTED},
+	{errnoERROR_CLUSTER_RESNAME_NOT_FOUND, "ERROR_CLUSTER_RESNAME_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_RESNAME_NOT_FOUND},
+	{errnoERROR_CLUSTER_NO_RPC_PACKAGES_REGISTERED, "ERROR_CLUSTER_NO_RPC_PACKAGES_REGISTERED", CategoryNone, ERROR_CLUSTER_NO_RPC_PACKAGES_REGISTERED},
+	{errnoERROR_CLUSTER_OWNER_NOT_IN_PREFLIST, "ERROR_CLUSTER_OWNER_NOT_IN_PREFLIST", CategoryStateConflict, ERROR_CLUSTER_OWNER_NOT_IN_PREFLIST},
+	{errnoERROR_CLUSTER_DATABASE_SEQMISMATCH, "ERROR_CLUSTER_DATABASE_SEQMISMATCH", CategoryTransient, ERROR_CLUSTER_DATABASE_SEQMISMATCH},
+	{errnoERROR_RESMON_INVALID_STATE, "ERROR_RESMON_INVALID_STATE", CategoryStateConflict, ERROR_RESMON_INVALID_STATE},
+	{errnoERROR_CLUSTER_GUM_NOT_LOCKER, "ERROR_CLUSTER_GUM_NOT_LOCKER", CategoryTransient, ERROR_CLUSTER_GUM_NOT_LOCKER},
+	{errnoERROR_QUORUM_DISK_NOT_FOUND, "ERROR_QUORUM_DISK_NOT_FOUND", CategoryNotFound, ERROR_QUORUM_DISK_NOT_FOUND},
+	{errnoERROR_DATABASE_BACKUP_CORRUPT, "ERROR_DATABASE_BACKUP_CORRUPT", CategoryNone, ERROR_DATABASE_BACKUP_CORRUPT},
+	{errnoERROR_CLUSTER_NODE_ALREADY_HAS_DFS_ROOT, "ERROR_CLUSTER_NODE_ALREADY_HAS_DFS_ROOT", CategoryStateConflict, ERROR_CLUSTER_NODE_ALREADY_HAS_DFS_ROOT},
+	{errnoERROR_RESOURCE_PROPERTY_UNCHANGEABLE, "ERROR_RESOURCE_PROPERTY_UNCHANGEABLE", CategoryStateConflict, ERROR_RESOURCE_PROPERTY_UNCHANGEABLE},
+	{errnoERROR_CLUSTER_MEMBERSHIP_INVALID_STATE, "ERROR_CLUSTER_MEMBERSHIP_INVALID_STATE", CategoryStateConflict, ERROR_CLUSTER_MEMBERSHIP_INVALID_STATE},
+	{errnoERROR_CLUSTER_QUORUMLOG_NOT_FOUND, "ERROR_CLUSTER_QUORUMLOG_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_QUORUMLOG_NOT_FOUND},
+	{errnoERROR_CLUSTER_MEMBERSHIP_HALT, "ERROR_CLUSTER_MEMBERSHIP_HALT", CategoryTransient, ERROR_CLUSTER_MEMBERSHIP_HALT},
+	{errnoERROR_CLUSTER_INSTANCE_ID_MISMATCH, "ERROR_CLUSTER_INSTANCE_ID_MISMATCH", CategoryNone, ERROR_CLUSTER_INSTANCE_ID_MISMATCH},
+	{errnoERROR_CLUSTER_NETWORK_NOT_FOUND_FOR_IP, "ERROR_CLUSTER_NETWORK_NOT_FOUND_FOR_IP", CategoryNotFound, ERROR_CLUSTER_NETWORK_NOT_FOUND_FOR_IP},
+	{errnoERROR_CLUSTER_PROPERTY_DATA_TYPE_MISMATCH, "ERROR_CLUSTER_PROPERTY_DATA_TYPE_MISMATCH", CategoryNone, ERROR_CLUSTER_PROPERTY_DATA_TYPE_MISMATCH},
+	{errnoERROR_CLUSTER_EVICT_WITHOUT_CLEANUP, "ERROR_CLUSTER_EVICT_WITHOUT_CLEANUP", CategoryNone, ERROR_CLUSTER_EVICT_WITHOUT_CLEANUP},
+	{errnoERROR_CLUSTER_PARAMETER_MISMATCH, "ERROR_CLUSTER_PARAMETER_MISMATCH", CategoryNone, ERROR_CLUSTER_PARAMETER_MISMATCH},
+	{errnoERROR_NODE_CANNOT_BE_CLUSTERED, "ERROR_NODE_CANNOT_BE_CLUSTERED", CategoryNone, ERROR_NODE_CANNOT_BE_CLUSTERED},
+	{errnoERROR_CLUSTER_WRONG_OS_VERSION, "ERROR_CLUSTER_WRONG_OS_VERSION", CategoryNone, ERROR_CLUSTER_WRONG_OS_VERSION},
+	{errnoERROR_CLUSTER_CANT_CREATE_DUP_CLUSTER_NAME, "ERROR_CLUSTER_CANT_CREATE_DUP_CLUSTER_NAME", CategoryStateConflict, ERROR_CLUSTER_CANT_CREATE_DUP_CLUSTER_NAME},
+	{errnoERROR_CLUSCFG_ALREADY_COMMITTED, "ERROR_CLUSCFG_ALREADY_COMMITTED", CategoryStateConflict, ERROR_CLUSCFG_ALREADY_COMMITTED},
+	{errnoERROR_CLUSCFG_ROLLBACK_FAILED, "ERROR_CLUSCFG_ROLLBACK_FAILED", CategoryNone, ERROR_CLUSCFG_ROLLBACK_FAILED},
+	{errnoERROR_CLUSCFG_SYSTEM_DISK_DRIVE_LETTER_CONFLICT, "ERROR_CLUSCFG_SYSTEM_DISK_DRIVE_LETTER_CONFLICT", CategoryNone, ERROR_CLUSCFG_SYSTEM_DISK_DRIVE_LETTER_CONFLICT},
+	{errnoERROR_CLUSTER_OLD_VERSION, "ERROR_CLUSTER_OLD_VERSION", CategoryNone, ERROR_CLUSTER_OLD_VERSION},
+	{errnoERROR_CLUSTER_MISMATCHED_COMPUTER_ACCT_NAME, "ERROR_CLUSTER_MISMATCHED_COMPUTER_ACCT_NAME", CategoryNone, ERROR_CLUSTER_MISMATCHED_COMPUTER_ACCT_NAME},
+	{errnoERROR_CLUSTER_NO_NET_ADAPTERS, "ERROR_CLUSTER_NO_NET_ADAPTERS", CategoryNone, ERROR_CLUSTER_NO_NET_ADAPTERS},
+	{errnoERROR_CLUSTER_POISONED, "ERROR_CLUSTER_POISONED", CategoryNone, ERROR_CLUSTER_POISONED},
+	{errnoERROR_CLUSTER_GROUP_MOVING, "ERROR_CLUSTER_GROUP_MOVING", CategoryTransient, ERROR_CLUSTER_GROUP_MOVING},
+	{errnoERROR_CLUSTER_RESOURCE_TYPE_BUSY, "ERROR_CLUSTER_RESOURCE_TYPE_BUSY", CategoryTransient, ERROR_CLUSTER_RESOURCE_TYPE_BUSY},
+	{errnoERROR_RESOURCE_CALL_TIMED_OUT, "ERROR_RESOURCE_CALL_TIMED_OUT", CategoryTransient, ERROR_RESOURCE_CALL_TIMED_OUT},
+	{errnoERROR_INVALID_CLUSTER_IPV6_ADDRESS, "ERROR_INVALID_CLUSTER_IPV6_ADDRESS", CategoryNone, ERROR_INVALID_CLUSTER_IPV6_ADDRESS},
+	{errnoERROR_CLUSTER_INTERNAL_INVALID_FUNCTION, "ERROR_CLUSTER_INTERNAL_INVALID_FUNCTION", CategoryNone, ERROR_CLUSTER_INTERNAL_INVALID_FUNCTION},
+	{errnoERROR_CLUSTER_PARAMETER_OUT_OF_BOUNDS, "ERROR_CLUSTER_PARAMETER_OUT_OF_BOUNDS", CategoryNone, ERROR_CLUSTER_PARAMETER_OUT_OF_BOUNDS},
+	{errnoERROR_CLUSTER_PARTIAL_SEND, "ERROR_CLUSTER_PARTIAL_SEND", CategoryTransient, ERROR_CLUSTER_PARTIAL_SEND},
+	{errnoERROR_CLUSTER_REGISTRY_INVALID_FUNCTION, "ERROR_CLUSTER_REGISTRY_INVALID_FUNCTION", CategoryNone, ERROR_CLUSTER_REGISTRY_INVALID_FUNCTION},
+	{errnoERROR_CLUSTER_INVALID_STRING_TERMINATION, "ERROR_CLUSTER_INVALID_STRING_TERMINATION", CategoryNone, ERROR_CLUSTER_INVALID_STRING_TERMINATION},
+	{errnoERROR_CLUSTER_INVALID_STRING_FORMAT, "ERROR_CLUSTER_INVALID_STRING_FORMAT", CategoryNone, ERROR_CLUSTER_INVALID_STRING_FORMAT},
+	{errnoERROR_CLUSTER_DATABASE_TRANSACTION_IN_PROGRESS, "ERROR_CLUSTER_DATABASE_TRANSACTION_IN_PROGRESS", CategoryTransient, ERROR_CLUSTER_DATABASE_TRANSACTION_IN_PROGRESS},
+	{errnoERROR_CLUSTER_DATABASE_TRANSACTION_NOT_IN_PROGRESS, "ERROR_CLUSTER_DATABASE_TRANSACTION_NOT_IN_PROGRESS", CategoryStateConflict, ERROR_CLUSTER_DATABASE_TRANSACTION_NOT_IN_PROGRESS},
+	{errnoERROR_CLUSTER_NULL_DATA, "ERROR_CLUSTER_NULL_DATA", CategoryNone, ERROR_CLUSTER_NULL_DATA},
+	{errnoERROR_CLUSTER_PARTIAL_READ, "ERROR_CLUSTER_PARTIAL_READ", CategoryTransient, ERROR_CLUSTER_PARTIAL_READ},
+	{errnoERROR_CLUSTER_PARTIAL_WRITE, "ERROR_CLUSTER_PARTIAL_WRITE", CategoryTransient, ERROR_CLUSTER_PARTIAL_WRITE},
+	{errnoERROR_CLUSTER_CANT_DESERIALIZE_DATA, "ERROR_CLUSTER_CANT_DESERIALIZE_DATA", CategoryNone, ERROR_CLUSTER_CANT_DESERIALIZE_DATA},
+	{errnoERROR_DEPENDENT_RESOURCE_PROPERTY_CONFLICT, "ERROR_DEPENDENT_RESOURCE_PROPERTY_CONFLICT", CategoryStateConflict, ERROR_DEPENDENT_RESOURCE_PROPERTY_CONFLICT},
+	{errnoERROR_CLUSTER_NO_QUORUM, "ERROR_CLUSTER_NO_QUORUM", CategoryTransient, ERROR_CLUSTER_NO_QUORUM},
+	{errnoERROR_CLUSTER_INVALID_IPV6_NETWORK, "ERROR_CLUSTER_INVALID_IPV6_NETWORK", CategoryNone, ERROR_CLUSTER_INVALID_IPV6_NETWORK},
+	{errnoERROR_CLUSTER_INVALID_IPV6_TUNNEL_NETWORK, "ERROR_CLUSTER_INVALID_IPV6_TUNNEL_NETWORK", CategoryNone, ERROR_CLUSTER_INVALID_IPV6_TUNNEL_NETWORK},
+	{errnoERROR_QUORUM_NOT_ALLOWED_IN_THIS_GROUP, "ERROR_QUORUM_NOT_ALLOWED_IN_THIS_GROUP", CategoryNone, ERROR_QUORUM_NOT_ALLOWED_IN_THIS_GROUP},
+	{errnoERROR_DEPENDENCY_TREE_TOO_COMPLEX, "ERROR_DEPENDENCY_TREE_TOO_COMPLEX", CategoryNone, ERROR_DEPENDENCY_TREE_TOO_COMPLEX},
+	{errnoERROR_EXCEPTION_IN_RESOURCE_CALL, "ERROR_EXCEPTION_IN_RESOURCE_CALL", CategoryNone, ERROR_EXCEPTION_IN_RESOURCE_CALL},
+	{errnoERROR_CLUSTER_RHS_FAILED_INITIALIZATION, "ERROR_CLUSTER_RHS_FAILED_INITIALIZATION", CategoryNone, ERROR_CLUSTER_RHS_FAILED_INITIALIZATION},
+	{errnoERROR_CLUSTER_NOT_INSTALLED, "ERROR_CLUSTER_NOT_INSTALLED", CategoryNotFound, ERROR_CLUSTER_NOT_INSTALLED},
+	{errnoERROR_CLUSTER_RESOURCES_MUST_BE_ONLINE_ON_THE_SAME_NODE, "ERROR_CLUSTER_RESOURCES_MUST_BE_ONLINE_ON_THE_SAME_NODE", CategoryStateConflict, ERROR_CLUSTER_RESOURCES_MUST_BE_ONLINE_ON_THE_SAME_NODE},
+	{errnoERROR_CLUSTER_MAX_NODES_IN_CLUSTER, "ERROR_CLUSTER_MAX_NODES_IN_CLUSTER", CategoryNone, ERROR_CLUSTER_MAX_NODES_IN_CLUSTER},
+	{errnoERROR_CLUSTER_TOO_MANY_NODES, "ERROR_CLUSTER_TOO_MANY_NODES", CategoryNone, ERROR_CLUSTER_TOO_MANY_NODES},
+	{errnoERROR_CLUSTER_OBJECT_ALREADY_USED, "ERROR_CLUSTER_OBJECT_ALREADY_USED", CategoryStateConflict, ERROR_CLUSTER_OBJECT_ALREADY_USED},
+	{errnoERROR_NONCORE_GROUPS_FOUND, "ERROR_NONCORE_GROUPS_FOUND", CategoryStateConflict, ERROR_NONCORE_GROUPS_FOUND},
+	{errnoERROR_FILE_SHARE_RESOURCE_CONFLICT, "ERROR_FILE_SHARE_RESOURCE_CONFLICT", CategoryStateConflict, ERROR_FILE_SHARE_RESOURCE_CONFLICT},
+	{errnoERROR_CLUSTER_EVICT_INVALID_REQUEST, "ERROR_CLUSTER_EVICT_INVALID_REQUEST", CategoryNone, ERROR_CLUSTER_EVICT_INVALID_REQUEST},
+	{errnoERROR_CLUSTER_SINGLETON_RESOURCE, "ERROR_CLUSTER_SINGLETON_RESOURCE", CategoryStateConflict, ERROR_CLUSTER_SINGLETON_RESOURCE},
+	{errnoERROR_CLUSTER_GROUP_SINGLETON_RESOURCE, "ERROR_CLUSTER_GROUP_SINGLETON_RESOURCE", CategoryStateConflict, ERROR_CLUSTER_GROUP_SINGLETON_RESOURCE},
+	{errnoERROR_CLUSTER_RESOURCE_PROVIDER_FAILED, "ERROR_CLUSTER_RESOURCE_PROVIDER_FAILED", CategoryNone, ERROR_CLUSTER_RESOURCE_PROVIDER_FAILED},
+	{errnoERROR_CLUSTER_RESOURCE_CONFIGURATION_ERROR, "ERROR_CLUSTER_RESOURCE_CONFIGURATION_ERROR", CategoryNone, ERROR_CLUSTER_RESOURCE_CONFIGURATION_ERROR},
+	{errnoERROR_CLUSTER_GROUP_BUSY, "ERROR_CLUSTER_GROUP_BUSY", CategoryTransient, ERROR_CLUSTER_GROUP_BUSY},
+	{errnoERROR_CLUSTER_NOT_SHARED_VOLUME, "ERROR_CLUSTER_NOT_SHARED_VOLUME", CategoryNone, ERROR_CLUSTER_NOT_SHARED_VOLUME},
+	{errnoERROR_CLUSTER_INVALID_SECURITY_DESCRIPTOR, "ERROR_CLUSTER_INVALID_SECURITY_DESCRIPTOR", CategoryNone, ERROR_CLUSTER_INVALID_SECURITY_DESCRIPTOR},
+	{errnoERROR_CLUSTER_SHARED_VOLUMES_IN_USE, "ERROR_CLUSTER_SHARED_VOLUMES_IN_USE", CategoryStateConflict, ERROR_CLUSTER_SHARED_VOLUMES_IN_USE},
+	{errnoERROR_CLUSTER_USE_SHARED_VOLUMES_API, "ERROR_CLUSTER_USE_SHARED_VOLUMES_API", CategoryNone, ERROR_CLUSTER_USE_SHARED_VOLUMES_API},
+	{errnoERROR_CLUSTER_BACKUP_IN_PROGRESS, "ERROR_CLUSTER_BACKUP_IN_PROGRESS", CategoryTransient, ERROR_CLUSTER_BACKUP_IN_PROGRESS},
+	{errnoERROR_NON_CSV_PATH, "ERROR_NON_CSV_PATH", CategoryNone, ERROR_NON_CSV_PATH},
+	{errnoERROR_CSV_VOLUME_NOT_LOCAL, "ERROR_CSV_VOLUME_NOT_LOCAL", CategoryNone, ERROR_CSV_VOLUME_NOT_LOCAL},
+	{errnoERROR_CLUSTER_WATCHDOG_TERMINATING, "ERROR_CLUSTER_WATCHDOG_TERMINATING", CategoryTransient, ERROR_CLUSTER_WATCHDOG_TERMINATING},
+	{errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_INCOMPATIBLE_NODES, "ERROR_CLUSTER_RESOURCE_VETOED_MOVE_INCOMPATIBLE_NODES", CategoryNone, ERROR_CLUSTER_RESOURCE_VETOED_MOVE_INCOMPATIBLE_NODES},
+	{errnoERROR_CLUSTER_INVALID_NODE_WEIGHT, "ERROR_CLUSTER_INVALID_NODE_WEIGHT", CategoryNone, ERROR_CLUSTER_INVALID_NODE_WEIGHT},
+	{errnoERROR_CLUSTER_RESOURCE_VETOED_CALL, "ERROR_CLUSTER_RESOURCE_VETOED_CALL", CategoryNone, ERROR_CLUSTER_RESOURCE_VETOED_CALL},
+	{errnoERROR_RESMON_SYSTEM_RESOURCES_LACKING, "ERROR_RESMON_SYSTEM_RESOURCES_LACKING", CategoryTransient, ERROR_RESMON_SYSTEM_RESOURCES_LACKING},
+	{errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_DESTINATION, "ERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_DESTINATION", CategoryNone, ERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_DESTINATION},
+	{errnoERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_SOURCE, "ERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_SOURCE", CategoryNone, ERROR_CLUSTER_RESOURCE_VETOED_MOVE_NOT_ENOUGH_RESOURCES_ON_SOURCE},
+	{errnoERROR_CLUSTER_GROUP_QUEUED, "ERROR_CLUSTER_GROUP_QUEUED", CategoryTransient, ERROR_CLUSTER_GROUP_QUEUED},
+	{errnoERROR_CLUSTER_RESOURCE_LOCKED_STATUS, "ERROR_CLUSTER_RESOURCE_LOCKED_STATUS", CategoryStateConflict, ERROR_CLUSTER_RESOURCE_LOCKED_STATUS},
+	{errnoERROR_CLUSTER_SHARED_VOLUME_FAILOVER_NOT_ALLOWED, "ERROR_CLUSTER_SHARED_VOLUME_FAILOVER_NOT_ALLOWED", CategoryNone, ERROR_CLUSTER_SHARED_VOLUME_FAILOVER_NOT_ALLOWED},
+	{errnoERROR_CLUSTER_NODE_DRAIN_IN_PROGRESS, "ERROR_CLUSTER_NODE_DRAIN_IN_PROGRESS", CategoryTransient, ERROR_CLUSTER_NODE_DRAIN_IN_PROGRESS},
+	{errnoERROR_CLUSTER_DISK_NOT_CONNECTED, "ERROR_CLUSTER_DISK_NOT_CONNECTED", CategoryNone, ERROR_CLUSTER_DISK_NOT_CONNECTED},
+	{errnoERROR_DISK_NOT_CSV_CAPABLE, "ERROR_DISK_NOT_CSV_CAPABLE", CategoryNone, ERROR_DISK_NOT_CSV_CAPABLE},
+	{errnoERROR_RESOURCE_NOT_IN_AVAILABLE_STORAGE, "ERROR_RESOURCE_NOT_IN_AVAILABLE_STORAGE", CategoryNone, ERROR_RESOURCE_NOT_IN_AVAILABLE_STORAGE},
+	{errnoERROR_CLUSTER_SHARED_VOLUME_REDIRECTED, "ERROR_CLUSTER_SHARED_VOLUME_REDIRECTED", CategoryStateConflict, ERROR_CLUSTER_SHARED_VOLUME_REDIRECTED},
+	{errnoERROR_CLUSTER_SHARED_VOLUME_NOT_REDIRECTED, "ERROR_CLUSTER_SHARED_VOLUME_NOT_REDIRECTED", CategoryStateConflict, ERROR_CLUSTER_SHARED_VOLUME_NOT_REDIRECTED},
+	{errnoERROR_CLUSTER_CANNOT_RETURN_PROPERTIES, "ERROR_CLUSTER_CANNOT_RETURN_PROPERTIES", CategoryNone, ERROR_CLUSTER_CANNOT_RETURN_PROPERTIES},
+	{errnoERROR_CLUSTER_RESOURCE_CONTAINS_UNSUPPORTED_DIFF_AREA_FOR_SHARED_VOLUMES, "ERROR_CLUSTER_RESOURCE_CONTAINS_UNSUPPORTED_DIFF_AREA_FOR_SHARED_VOLUMES", CategoryNone, ERROR_CLUSTER_RESOURCE_CONTAINS_UNSUPPORTED_DIFF_AREA_FOR_SHARED_VOLUMES},
+	{errnoERROR_CLUSTER_RESOURCE_IS_IN_MAINTENANCE_MODE, "ERROR_CLUSTER_RESOURCE_IS_IN_MAINTENANCE_MODE", CategoryStateConflict, ERROR_CLUSTER_RESOURCE_IS_IN_MAINTENANCE_MODE},
+	{errnoERROR_CLUSTER_AFFINITY_CONFLICT, "ERROR_CLUSTER_AFFINITY_CONFLICT", CategoryStateConflict, ERROR_CLUSTER_AFFINITY_CONFLICT},
+	{errnoERROR_CLUSTER_RESOURCE_IS_REPLICA_VIRTUAL_MACHINE, "ERROR_CLUSTER_RESOURCE_IS_REPLICA_VIRTUAL_MACHINE", CategoryNone, ERROR_CLUSTER_RESOURCE_IS_REPLICA_VIRTUAL_MACHINE},
+	{errnoERROR_CLUSTER_UPGRADE_INCOMPATIBLE_VERSIONS, "ERROR_CLUSTER_UPGRADE_INCOMPATIBLE_VERSIONS", CategoryNone, ERROR_CLUSTER_UPGRADE_INCOMPATIBLE_VERSIONS},
+	{errnoERROR_CLUSTER_UPGRADE_FIX_QUORUM_NOT_SUPPORTED, "ERROR_CLUSTER_UPGRADE_FIX_QUORUM_NOT_SUPPORTED", CategoryNone, ERROR_CLUSTER_UPGRADE_FIX_QUORUM_NOT_SUPPORTED},
+	{errnoERROR_CLUSTER_UPGRADE_RESTART_REQUIRED, "ERROR_CLUSTER_UPGRADE_RESTART_REQUIRED", CategoryNone, ERROR_CLUSTER_UPGRADE_RESTART_REQUIRED},
+	{errnoERROR_CLUSTER_UPGRADE_IN_PROGRESS, "ERROR_CLUSTER_UPGRADE_IN_PROGRESS", CategoryTransient, ERROR_CLUSTER_UPGRADE_IN_PROGRESS},
+	{errnoERROR_CLUSTER_UPGRADE_INCOMPLETE, "ERROR_CLUSTER_UPGRADE_INCOMPLETE", CategoryNone, ERROR_CLUSTER_UPGRADE_INCOMPLETE},
+	{errnoERROR_CLUSTER_NODE_IN_GRACE_PERIOD, "ERROR_CLUSTER_NODE_IN_GRACE_PERIOD", CategoryTransient, ERROR_CLUSTER_NODE_IN_GRACE_PERIOD},
+	{errnoERROR_CLUSTER_CSV_IO_PAUSE_TIMEOUT, "ERROR_CLUSTER_CSV_IO_PAUSE_TIMEOUT", CategoryTransient, ERROR_CLUSTER_CSV_IO_PAUSE_TIMEOUT},
+	{errnoERROR_NODE_NOT_ACTIVE_CLUSTER_MEMBER, "ERROR_NODE_NOT_ACTIVE_CLUSTER_MEMBER", CategoryStateConflict, ERROR_NODE_NOT_ACTIVE_CLUSTER_MEMBER},
+	{errnoERROR_CLUSTER_RESOURCE_NOT_MONITORED, "ERROR_CLUSTER_RESOURCE_NOT_MONITORED", CategoryStateConflict, ERROR_CLUSTER_RESOURCE_NOT_MONITORED},
+	{errnoERROR_CLUSTER_RESOURCE_DOES_NOT_SUPPORT_UNMONITORED, "ERROR_CLUSTER_RESOURCE_DOES_NOT_SUPPORT_UNMONITORED", CategoryNone, ERROR_CLUSTER_RESOURCE_DOES_NOT_SUPPORT_UNMONITORED},
+	{errnoERROR_CLUSTER_RESOURCE_IS_REPLICATED, "ERROR_CLUSTER_RESOURCE_IS_REPLICATED", CategoryNone, ERROR_CLUSTER_RESOURCE_IS_REPLICATED},
+	{errnoERROR_CLUSTER_NODE_ISOLATED, "ERROR_CLUSTER_NODE_ISOLATED", CategoryTransient, ERROR_CLUSTER_NODE_ISOLATED},
+	{errnoERROR_CLUSTER_NODE_QUARANTINED, "ERROR_CLUSTER_NODE_QUARANTINED", CategoryStateConflict, ERROR_CLUSTER_NODE_QUARANTINED},
+	{errnoERROR_CLUSTER_DATABASE_UPDATE_CONDITION_FAILED, "ERROR_CLUSTER_DATABASE_UPDATE_CONDITION_FAILED", CategoryTransient, ERROR_CLUSTER_DATABASE_UPDATE_CONDITION_FAILED},
+	{errnoERROR_CLUSTER_SPACE_DEGRADED, "ERROR_CLUSTER_SPACE_DEGRADED", CategoryNone, ERROR_CLUSTER_SPACE_DEGRADED},
+	{errnoERROR_CLUSTER_TOKEN_DELEGATION_NOT_SUPPORTED, "ERROR_CLUSTER_TOKEN_DELEGATION_NOT_SUPPORTED", CategoryAccess, ERROR_CLUSTER_TOKEN_DELEGATION_NOT_SUPPORTED},
+	{errnoERROR_CLUSTER_CSV_INVALID_HANDLE, "ERROR_CLUSTER_CSV_INVALID_HANDLE", CategoryNone, ERROR_CLUSTER_CSV_INVALID_HANDLE},
+	{errnoERROR_CLUSTER_CSV_SUPPORTED_ONLY_ON_COORDINATOR, "ERROR_CLUSTER_CSV_SUPPORTED_ONLY_ON_COORDINATOR", CategoryNone, ERROR_CLUSTER_CSV_SUPPORTED_ONLY_ON_COORDINATOR},
+	{errnoERROR_GROUPSET_NOT_AVAILABLE, "ERROR_GROUPSET_NOT_AVAILABLE", CategoryTransient, ERROR_GROUPSET_NOT_AVAILABLE},
+	{errnoERROR_GROUPSET_NOT_FOUND, "ERROR_GROUPSET_NOT_FOUND", CategoryNotFound, ERROR_GROUPSET_NOT_FOUND},
+	{errnoERROR_GROUPSET_CANT_PROVIDE, "ERROR_GROUPSET_CANT_PROVIDE", CategoryNone, ERROR_GROUPSET_CANT_PROVIDE},
+	{errnoERROR_CLUSTER_FAULT_DOMAIN_PARENT_NOT_FOUND, "ERROR_CLUSTER_FAULT_DOMAIN_PARENT_NOT_FOUND", CategoryNotFound, ERROR_CLUSTER_FAULT_DOMAIN_PARENT_NOT_FOUND},
+	{errnoERROR_CLUSTER_FAULT_DOMAIN_INVALID_HIERARCHY, "ERROR_CLUSTER_FAULT_DOMAIN_INVALID_HIERARCHY", CategoryNone, ERROR_CLUSTER_FAULT_DOMAIN_INVALID_HIERARCHY},
+	{errnoERROR_CLUSTER_FAULT_DOMAIN_FAILED_S2D_VALIDATION, "ERROR_CLUSTER_FAULT_DOMAIN_FAILED_S2D_VALIDATION", CategoryNone, ERROR_CLUSTER_FAULT_DOMAIN_FAILED_S2D_VALIDATION},
+	{errnoERROR_CLUSTER_FAULT_DOMAIN_S2D_CONNECTIVITY_LOSS, "ERROR_CLUSTER_FAULT_DOMAIN_S2D_CONNECTIVITY_LOSS", CategoryNone, ERROR_CLUSTER_FAULT_DOMAIN_S2D_CONNECTIVITY_LOSS},
+	{errnoERROR_CLUSTER_INVALID_INFRASTRUCTURE_FILESERVER_NAME, "ERROR_CLUSTER_INVALID_INFRASTRUCTURE_FILESERVER_NAME", CategoryNone, ERROR_CLUSTER_INVALID_INFRASTRUCTURE_FILESERVER_NAME},
+	{errnoERROR_CLUSTERSET_MANAGEMENT_CLUSTER_UNREACHABLE, "ERROR_CLUSTERSET_MANAGEMENT_CLUSTER_UNREACHABLE", CategoryTransient, ERROR_CLUSTERSET_MANAGEMENT_CLUSTER_UNREACHABLE},
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package kernel32
+
+import "github.com/KnicKnic/go-windows/pkg/errors"
+
+func LocalAlloc(length uint64) (ptr uintptr, err error) {
+	return 0, errors.ErrNotSupported
+}
+
+// LocalFree does nothing, LocalAlloc never returns memory on this platform
+func LocalFree(mem uintptr) {
+}
+
+func ExpandEnvironmentStrings(value string) (expanded string, err error) {
+	return "", errors.ErrNotSupported
+}
@@ -0,0 +1,55 @@
+package kernel32
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/KnicKnic/go-windows/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+// Do the interface allocations only once for common
+
+var (
+	modkernel32    = windows.NewLazySystemDLL("kernel32.dll")
+	procLocalAlloc = modkernel32.NewProc("LocalAlloc")
+	procLocalFree  = modkernel32.NewProc("LocalFree")
+
+	procExpandEnvironmentStrings = modkernel32.NewProc("ExpandEnvironmentStringsW")
+)
+
+func LocalAlloc(length uint64) (ptr uintptr, err error) {
+	ptr, _, lastError := syscall.Syscall(procLocalAlloc.Addr(), 2, uintptr(LocalAlloc_LPTR), uintptr(length), 0)
+	err = errors.NotNill(ptr, lastError)
+	return
+}
+
+func LocalFree(mem uintptr) {
+	syscall.Syscall(procLocalFree.Addr(), 1, uintptr(mem), 0, 0)
+	return
+}
+
+// ExpandEnvironmentStrings replaces %NAME% references in value
+// with the value of the environment variable NAME
+func ExpandEnvironmentStrings(value string) (expanded string, err error) {
+	src, err := windows.UTF16PtrFromString(value)
+	if err != nil {
+		return
+	}
+
+	size := uint32(len(value) + 1)
+	for {
+		dest := make([]uint16, size)
+		r0, _, lastError := syscall.Syscall(procExpandEnvironmentStrings.Addr(), 3, uintptr(unsafe.Pointer(src)), uintptr(unsafe.Pointer(&dest[0])), uintptr(size))
+		needed := uint32(r0)
+		if needed == 0 {
+			err = errors.Ensure(lastError)
+			return
+		}
+		if needed <= size {
+			expanded = windows.UTF16ToString(dest[:needed])
+			return
+		}
+		size = needed
+	}
+}
@@ -1,45 +1,35 @@
 package ntdll
 
-import (
-	"syscall"
-	"unsafe"
+import "unsafe"
 
-	"github.com/KnicKnic/go-windows/pkg/kernel32"
-	"golang.org/x/sys/windows"
-)
+// maxCopy bounds the slices made over memory given by address
+const maxCopy = 1 << 30
 
-// Do the interface allocations only once for common
-
-var (
-	modntdll   = windows.NewLazySystemDLL("ntdll.dll")
-	procmemcpy = modntdll.NewProc("memcpy")
-)
+// memory returns the size bytes at address as a slice
+func memory(address uintptr, size uint64) []byte {
+	// reinterpret instead of converting the uintptr, the memory is not Go's
+	pointer := *(*unsafe.Pointer)(unsafe.Pointer(&address))
+	return (*[maxCopy]byte)(pointer)[:size:size]
+}
 
+// Memcpy copies size bytes from src to dest and returns dest
 func Memcpy(dest uintptr, src uintptr, size uint64) (ptr uintptr) {
-	r0, _, _ := syscall.Syscall(procmemcpy.Addr(), 3, uintptr(dest), uintptr(src), uintptr(size))
-	ptr = uintptr(r0)
-	return
+	if size != 0 {
+		copy(memory(dest, size), memory(src, size))
+	}
+	return dest
 }
 
+// MemcpyDestC copies size bytes of src to the memory at dest
 func MemcpyDestC(dest uintptr, src []byte, size uint64) {
 	if size != 0 {
-		_, _, _ = syscall.Syscall(procmemcpy.Addr(), 3, uintptr(dest), uintptr(unsafe.Pointer(&src[0])), uintptr(size))
+		copy(memory(dest, size), src[:size])
 	}
 }
 
+// MemcpySrcC copies size bytes of the memory at src to dest
 func MemcpySrcC(dest []byte, src uintptr, size uint64) {
 	if size != 0 {
-		_, _, _ = syscall.Syscall(procmemcpy.Addr(), 3, uintptr(unsafe.Pointer(&dest[0])), uintptr(src), uintptr(size))
-	}
-}
-
-func MemcpyLocalAlloc(data []byte) (ptr uintptr, err error) {
-
-	size := uint64(len(data))
-	ptr, err = kernel32.LocalAlloc(size)
-	if err != nil {
-		return
+		copy(dest[:size], memory(src, size))
 	}
-	MemcpyDestC(ptr, data, uint64(size))
-	return
 }
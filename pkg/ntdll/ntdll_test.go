@@ -0,0 +1,38 @@
+package ntdll
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// package level so the memory stays put while only its address is held
+var (
+	testSrc  = []byte{1, 2, 3, 4}
+	testDest = make([]byte, 4)
+)
+
+func address(b []byte, index int) uintptr {
+	return uintptr(unsafe.Pointer(&b[index]))
+}
+
+func TestMemcpy(t *testing.T) {
+	ptr := Memcpy(address(testDest, 0), address(testSrc, 0), 3)
+	assert.Equal(t, address(testDest, 0), ptr)
+	assert.Equal(t, []byte{1, 2, 3, 0}, testDest)
+
+	copy(testDest, make([]byte, 4))
+	MemcpyDestC(address(testDest, 1), testSrc, 2)
+	assert.Equal(t, []byte{0, 1, 2, 0}, testDest)
+
+	copy(testDest, make([]byte, 4))
+	MemcpySrcC(testDest, address(testSrc, 2), 2)
+	assert.Equal(t, []byte{3, 4, 0, 0}, testDest)
+
+	assert.NotPanics(t, func() {
+		Memcpy(0, 0, 0)
+		MemcpyDestC(0, nil, 0)
+		MemcpySrcC(nil, 0, 0)
+	})
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package ntdll
+
+import "github.com/KnicKnic/go-windows/pkg/errors"
+
+// MemcpyLocalAlloc returns errors.ErrNotSupported
+func MemcpyLocalAlloc(data []byte) (ptr uintptr, err error) {
+	return 0, errors.ErrNotSupported
+}
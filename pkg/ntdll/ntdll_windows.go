@@ -0,0 +1,18 @@
+package ntdll
+
+import (
+	"github.com/KnicKnic/go-windows/pkg/kernel32"
+)
+
+// MemcpyLocalAlloc copies data to memory from kernel32.LocalAlloc, free it
+// with kernel32.LocalFree. Outside Windows it returns errors.ErrNotSupported
+func MemcpyLocalAlloc(data []byte) (ptr uintptr, err error) {
+
+	size := uint64(len(data))
+	ptr, err = kernel32.LocalAlloc(size)
+	if err != nil {
+		return
+	}
+	MemcpyDestC(ptr, data, uint64(size))
+	return
+}
@@ -2,6 +2,8 @@ package util
 
 import (
 	"encoding/binary"
+	"syscall"
+	"unicode/utf16"
 	"unsafe"
 )
 
@@ -15,3 +17,98 @@ func Uint64ToByte(data uint64) (output []byte) {
 	binary.LittleEndian.PutUint64(output, data)
 	return
 }
+
+// ByteToUint32 reads a little endian uint32, data must be at least 4 bytes
+func ByteToUint32(data []byte) uint32 {
+	return binary.LittleEndian.Uint32(data)
+}
+
+// ByteToUint64 reads a little endian uint64, data must be at least 8 bytes
+func ByteToUint64(data []byte) uint64 {
+	return binary.LittleEndian.Uint64(data)
+}
+
+// StringToUTF16Bytes encodes data as a null terminated little endian UTF-16 string
+// it returns syscall.EINVAL if data contains a null
+func StringToUTF16Bytes(data string) (output []byte, err error) {
+	for i := 0; i < len(data); i++ {
+		if data[i] == 0 {
+			err = syscall.EINVAL
+			return
+		}
+	}
+	chars := utf16.Encode([]rune(data + "\x00"))
+	output = make([]byte, 2*len(chars))
+	for i, char := range chars {
+		binary.LittleEndian.PutUint16(output[2*i:], char)
+	}
+	return
+}
+
+// UTF16BytesToString decodes a little endian UTF-16 string
+// stopping at the first null, the null terminator is optional
+func UTF16BytesToString(data []byte) string {
+	chars := utf16BytesToChars(data)
+	for i, char := range chars {
+		if char == 0 {
+			chars = chars[:i]
+			break
+		}
+	}
+	return string(utf16.Decode(chars))
+}
+
+// StringsToUTF16Bytes encodes data as a REG_MULTI_SZ, each string is null terminated
+// and the list is terminated by an extra null
+// it returns syscall.EINVAL if any string is empty or contains a null
+func StringsToUTF16Bytes(data []string) (output []byte, err error) {
+	for _, str := range data {
+		if str == "" {
+			err = syscall.EINVAL
+			return
+		}
+		var encoded []byte
+		encoded, err = StringToUTF16Bytes(str)
+		if err != nil {
+			return
+		}
+		output = append(output, encoded...)
+	}
+	output = append(output, 0, 0)
+	return
+}
+
+// UTF16BytesToStrings decodes a REG_MULTI_SZ, stopping at the first empty string
+// missing null terminators are tolerated
+func UTF16BytesToStrings(data []byte) (output []string) {
+	output = []string{}
+	chars := utf16BytesToChars(data)
+	for len(chars) > 0 {
+		end := len(chars)
+		for i, char := range chars {
+			if char == 0 {
+				end = i
+				break
+			}
+		}
+		if end == 0 {
+			break
+		}
+		output = append(output, string(utf16.Decode(chars[:end])))
+		if end == len(chars) {
+			break
+		}
+		chars = chars[end+1:]
+	}
+	return
+}
+
+// utf16BytesToChars splits little endian bytes into UTF-16 chars
+// a trailing odd byte is ignored
+func utf16BytesToChars(data []byte) []uint16 {
+	chars := make([]uint16, len(data)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	return chars
+}
@@ -1,6 +1,81 @@
 package util
 
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
 // func TestGuidToByte(t *testing.T) {
 
 // 	syscall.GUID
 // }
+
+func TestUintToByte(t *testing.T) {
+	data := Uint32ToByte(0x01020304)
+	assert.Equal(t, []byte{4, 3, 2, 1}, data)
+	assert.Equal(t, uint32(0x01020304), ByteToUint32(data))
+
+	data = Uint64ToByte(0x0102030405060708)
+	assert.Equal(t, []byte{8, 7, 6, 5, 4, 3, 2, 1}, data)
+	assert.Equal(t, uint64(0x0102030405060708), ByteToUint64(data))
+}
+
+func TestStringToUTF16Bytes(t *testing.T) {
+	data, err := StringToUTF16Bytes("ab")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{'a', 0, 'b', 0, 0, 0}, data)
+	assert.Equal(t, "ab", UTF16BytesToString(data))
+
+	data, err = StringToUTF16Bytes("")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0}, data)
+	assert.Equal(t, "", UTF16BytesToString(data))
+
+	// surrogate pair
+	data, err = StringToUTF16Bytes("\U0001F600")
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(data))
+	assert.Equal(t, "\U0001F600", UTF16BytesToString(data))
+
+	_, err = StringToUTF16Bytes("a\x00b")
+	assert.Equal(t, syscall.EINVAL, err)
+}
+
+func TestUTF16BytesToString(t *testing.T) {
+	// missing null terminator
+	assert.Equal(t, "ab", UTF16BytesToString([]byte{'a', 0, 'b', 0}))
+	// trailing odd byte
+	assert.Equal(t, "ab", UTF16BytesToString([]byte{'a', 0, 'b', 0, 0}))
+	// stops at the first null
+	assert.Equal(t, "a", UTF16BytesToString([]byte{'a', 0, 0, 0, 'b', 0}))
+	assert.Equal(t, "", UTF16BytesToString(nil))
+}
+
+func TestStringsToUTF16Bytes(t *testing.T) {
+	data, err := StringsToUTF16Bytes([]string{"a", "bc"})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{'a', 0, 0, 0, 'b', 0, 'c', 0, 0, 0, 0, 0}, data)
+	assert.Equal(t, []string{"a", "bc"}, UTF16BytesToStrings(data))
+
+	data, err = StringsToUTF16Bytes(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0}, data)
+	assert.Equal(t, []string{}, UTF16BytesToStrings(data))
+
+	_, err = StringsToUTF16Bytes([]string{"a", ""})
+	assert.Equal(t, syscall.EINVAL, err)
+	_, err = StringsToUTF16Bytes([]string{"a\x00"})
+	assert.Equal(t, syscall.EINVAL, err)
+}
+
+func TestUTF16BytesToStrings(t *testing.T) {
+	// missing final null
+	assert.Equal(t, []string{"a", "b"}, UTF16BytesToStrings([]byte{'a', 0, 0, 0, 'b', 0, 0, 0}))
+	// missing both nulls
+	assert.Equal(t, []string{"a", "b"}, UTF16BytesToStrings([]byte{'a', 0, 0, 0, 'b', 0}))
+	// stops at the first empty string
+	assert.Equal(t, []string{"a"}, UTF16BytesToStrings([]byte{'a', 0, 0, 0, 0, 0, 'b', 0}))
+	assert.Equal(t, []string{}, UTF16BytesToStrings(nil))
+}